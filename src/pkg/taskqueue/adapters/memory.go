@@ -0,0 +1,219 @@
+package adapters
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue"
+)
+
+// MemoryRepositoryConfig bounds a MemoryRepository so long-running small
+// deployments do not grow without limit.
+type MemoryRepositoryConfig struct {
+	// TTL evicts finished tasks this long after their last update. Zero
+	// keeps them forever.
+	TTL time.Duration
+
+	// Capacity caps how many tasks are stored; beyond it the oldest
+	// finished tasks are evicted first, then the oldest overall. Zero
+	// means unbounded.
+	Capacity int
+}
+
+// MemoryRepository is a thread-safe in-memory TaskRepository, suitable
+// for small deployments, examples and the embedded server mode. It keeps
+// a by-status index so status listings do not scan every task, and can
+// be bounded with NewBoundedMemoryRepository.
+type MemoryRepository struct {
+	mu       sync.RWMutex
+	config   MemoryRepositoryConfig
+	tasks    map[string]*taskqueue.Task
+	byStatus map[taskqueue.TaskStatus]map[string]*taskqueue.Task
+}
+
+// NewMemoryRepository returns an empty, unbounded repository.
+func NewMemoryRepository() *MemoryRepository {
+	return NewBoundedMemoryRepository(MemoryRepositoryConfig{})
+}
+
+// NewBoundedMemoryRepository returns an empty repository with TTL and
+// capacity eviction.
+func NewBoundedMemoryRepository(config MemoryRepositoryConfig) *MemoryRepository {
+	return &MemoryRepository{
+		config:   config,
+		tasks:    make(map[string]*taskqueue.Task),
+		byStatus: make(map[taskqueue.TaskStatus]map[string]*taskqueue.Task),
+	}
+}
+
+// Save implements taskqueue.TaskRepository.
+func (r *MemoryRepository) Save(_ context.Context, task *taskqueue.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.evictLocked()
+	clone := *task
+	r.storeLocked(&clone)
+	return nil
+}
+
+// GetByID implements taskqueue.TaskRepository.
+func (r *MemoryRepository) GetByID(_ context.Context, id string) (*taskqueue.Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	task, ok := r.tasks[id]
+	if !ok {
+		return nil, taskqueue.ErrTaskNotFound
+	}
+	clone := *task
+	return &clone, nil
+}
+
+// UpdateStatus implements taskqueue.TaskRepository.
+func (r *MemoryRepository) UpdateStatus(_ context.Context, id string, status taskqueue.TaskStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	task, ok := r.tasks[id]
+	if !ok {
+		return taskqueue.ErrTaskNotFound
+	}
+	r.unindexLocked(task)
+	task.Status = status
+	task.UpdatedAt = time.Now().UTC()
+	r.indexLocked(task)
+	return nil
+}
+
+// ListPending implements taskqueue.TaskRepository.
+func (r *MemoryRepository) ListPending(_ context.Context, limit int) ([]*taskqueue.Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var pending []*taskqueue.Task
+	for _, task := range r.byStatus[taskqueue.TaskStatusPending] {
+		if limit > 0 && len(pending) >= limit {
+			break
+		}
+		clone := *task
+		pending = append(pending, &clone)
+	}
+	return pending, nil
+}
+
+// ListTasks implements taskqueue.TaskLister, using the status index when
+// the filter names one.
+func (r *MemoryRepository) ListTasks(_ context.Context, filter taskqueue.TaskFilter) ([]*taskqueue.Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	source := r.tasks
+	if filter.Status != "" {
+		source = r.byStatus[filter.Status]
+	}
+	var matched []*taskqueue.Task
+	for _, task := range source {
+		if !filter.Matches(task) {
+			continue
+		}
+		clone := *task
+		matched = append(matched, &clone)
+	}
+	return matched, nil
+}
+
+// CountByStatus implements taskqueue.StatusCounter.
+func (r *MemoryRepository) CountByStatus(context.Context) (map[taskqueue.TaskStatus]uint64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	counts := make(map[taskqueue.TaskStatus]uint64)
+	for status, tasks := range r.byStatus {
+		if len(tasks) > 0 {
+			counts[status] = uint64(len(tasks))
+		}
+	}
+	return counts, nil
+}
+
+// Len returns how many tasks are stored.
+func (r *MemoryRepository) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.tasks)
+}
+
+// storeLocked inserts or replaces a task and keeps the index in sync.
+// Callers hold the write lock.
+func (r *MemoryRepository) storeLocked(task *taskqueue.Task) {
+	if previous, ok := r.tasks[task.ID]; ok {
+		r.unindexLocked(previous)
+	}
+	r.tasks[task.ID] = task
+	r.indexLocked(task)
+}
+
+// indexLocked adds the task to its status bucket.
+func (r *MemoryRepository) indexLocked(task *taskqueue.Task) {
+	bucket, ok := r.byStatus[task.Status]
+	if !ok {
+		bucket = make(map[string]*taskqueue.Task)
+		r.byStatus[task.Status] = bucket
+	}
+	bucket[task.ID] = task
+}
+
+// unindexLocked removes the task from its status bucket.
+func (r *MemoryRepository) unindexLocked(task *taskqueue.Task) {
+	delete(r.byStatus[task.Status], task.ID)
+}
+
+// deleteLocked removes the task entirely.
+func (r *MemoryRepository) deleteLocked(task *taskqueue.Task) {
+	r.unindexLocked(task)
+	delete(r.tasks, task.ID)
+}
+
+// evictLocked applies TTL and capacity limits before a new task is
+// stored. Callers hold the write lock.
+func (r *MemoryRepository) evictLocked() {
+	if r.config.TTL > 0 {
+		cutoff := time.Now().UTC().Add(-r.config.TTL)
+		for _, task := range r.tasks {
+			if task.IsTerminal() && task.UpdatedAt.Before(cutoff) {
+				r.deleteLocked(task)
+			}
+		}
+	}
+	if r.config.Capacity <= 0 {
+		return
+	}
+	for len(r.tasks) >= r.config.Capacity {
+		if victim := r.oldestLocked(true); victim != nil {
+			r.deleteLocked(victim)
+			continue
+		}
+		if victim := r.oldestLocked(false); victim != nil {
+			r.deleteLocked(victim)
+			continue
+		}
+		return
+	}
+}
+
+// oldestLocked returns the least recently updated task, optionally
+// restricted to finished ones.
+func (r *MemoryRepository) oldestLocked(terminalOnly bool) *taskqueue.Task {
+	var oldest *taskqueue.Task
+	for _, task := range r.tasks {
+		if terminalOnly && !task.IsTerminal() {
+			continue
+		}
+		if oldest == nil || task.UpdatedAt.Before(oldest.UpdatedAt) {
+			oldest = task
+		}
+	}
+	return oldest
+}
+
+var (
+	_ taskqueue.TaskRepository = (*MemoryRepository)(nil)
+	_ taskqueue.StatusCounter  = (*MemoryRepository)(nil)
+	_ taskqueue.TaskLister     = (*MemoryRepository)(nil)
+)
@@ -0,0 +1,47 @@
+// Package adapters contains concrete implementations of the taskqueue
+// repository and publisher contracts. Broker- or database-specific code
+// lives here so importing the core package never pulls in an SDK the
+// consumer does not use.
+package adapters
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue"
+)
+
+// NoopPublisher discards every message. Useful for tests and deployments
+// that do not consume results.
+type NoopPublisher struct{}
+
+// Publish implements taskqueue.Publisher.
+func (NoopPublisher) Publish(context.Context, string, []byte) error {
+	return nil
+}
+
+// LogPublisher writes every message to a structured logger instead of a
+// broker, which is handy for local development.
+type LogPublisher struct {
+	Logger *slog.Logger
+}
+
+// NewLogPublisher returns a publisher logging through logger, or
+// slog.Default when nil.
+func NewLogPublisher(logger *slog.Logger) *LogPublisher {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &LogPublisher{Logger: logger}
+}
+
+// Publish implements taskqueue.Publisher.
+func (p *LogPublisher) Publish(_ context.Context, topic string, payload []byte) error {
+	p.Logger.Info("taskqueue: message published", "topic", topic, "payload", string(payload))
+	return nil
+}
+
+var (
+	_ taskqueue.Publisher = NoopPublisher{}
+	_ taskqueue.Publisher = (*LogPublisher)(nil)
+)
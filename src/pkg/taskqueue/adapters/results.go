@@ -0,0 +1,66 @@
+package adapters
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue"
+)
+
+// MemoryResultRepository is a thread-safe in-memory ResultRepository,
+// keeping one result per task ID.
+type MemoryResultRepository struct {
+	mu      sync.RWMutex
+	results map[string]*taskqueue.TaskResult
+}
+
+// NewMemoryResultRepository returns an empty repository.
+func NewMemoryResultRepository() *MemoryResultRepository {
+	return &MemoryResultRepository{results: make(map[string]*taskqueue.TaskResult)}
+}
+
+// SaveResult implements taskqueue.ResultRepository.
+func (r *MemoryResultRepository) SaveResult(_ context.Context, result *taskqueue.TaskResult) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clone := *result
+	r.results[result.TaskID] = &clone
+	return nil
+}
+
+// GetResult implements taskqueue.ResultRepository.
+func (r *MemoryResultRepository) GetResult(_ context.Context, taskID string) (*taskqueue.TaskResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result, ok := r.results[taskID]
+	if !ok {
+		return nil, taskqueue.ErrResultNotFound
+	}
+	clone := *result
+	return &clone, nil
+}
+
+// ListResults implements taskqueue.ResultRepository, returning matches
+// newest first.
+func (r *MemoryResultRepository) ListResults(_ context.Context, filter taskqueue.ResultFilter) ([]*taskqueue.TaskResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var matches []*taskqueue.TaskResult
+	for _, result := range r.results {
+		if !filter.Matches(result) {
+			continue
+		}
+		clone := *result
+		matches = append(matches, &clone)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].CompletedAt.After(matches[j].CompletedAt)
+	})
+	if filter.Limit > 0 && len(matches) > filter.Limit {
+		matches = matches[:filter.Limit]
+	}
+	return matches, nil
+}
+
+var _ taskqueue.ResultRepository = (*MemoryResultRepository)(nil)
@@ -0,0 +1,318 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue"
+)
+
+// ErrConcurrentUpdate is returned when an optimistic status update keeps
+// losing against concurrent writers.
+var ErrConcurrentUpdate = errors.New("adapters: concurrent task update")
+
+// SQLDialect selects the flavor-specific bits of SQLRepository.
+type SQLDialect int
+
+const (
+	// DialectSQLite targets SQLite; ListPending relies on SQLite's
+	// single-writer model instead of row locks.
+	DialectSQLite SQLDialect = iota
+
+	// DialectPostgres targets PostgreSQL; ListPending claims rows with
+	// FOR UPDATE SKIP LOCKED so competing workers never hand out the
+	// same task.
+	DialectPostgres
+)
+
+// occRetries is how often an optimistic status update is retried before
+// giving up with ErrConcurrentUpdate.
+const occRetries = 3
+
+// sqlSchema creates the tasks table. Types are chosen to work unchanged
+// on SQLite and PostgreSQL; times are stored as RFC 3339 text.
+const sqlSchema = `
+CREATE TABLE IF NOT EXISTS taskqueue_tasks (
+	id          TEXT PRIMARY KEY,
+	type        TEXT NOT NULL,
+	data        TEXT NOT NULL DEFAULT '',
+	status      TEXT NOT NULL,
+	priority    INTEGER NOT NULL DEFAULT 0,
+	retries     INTEGER NOT NULL DEFAULT 0,
+	max_retries INTEGER NOT NULL DEFAULT 0,
+	metadata    TEXT NOT NULL DEFAULT '',
+	created_at  TEXT NOT NULL,
+	updated_at  TEXT NOT NULL,
+	expires_at  TEXT NOT NULL DEFAULT '',
+	timeout     INTEGER NOT NULL DEFAULT 0,
+	deadline    TEXT NOT NULL DEFAULT '',
+	version     INTEGER NOT NULL DEFAULT 1
+);
+CREATE INDEX IF NOT EXISTS taskqueue_tasks_status ON taskqueue_tasks (status, priority, created_at);`
+
+// sqlMigrations bring tables created by earlier schema versions up to
+// date. Each statement must be safe to re-run: additions that already
+// landed fail with a duplicate-column error, which Migrate ignores.
+var sqlMigrations = []string{
+	`ALTER TABLE taskqueue_tasks ADD COLUMN timeout INTEGER NOT NULL DEFAULT 0`,
+	`ALTER TABLE taskqueue_tasks ADD COLUMN deadline TEXT NOT NULL DEFAULT ''`,
+}
+
+// SQLRepository is a taskqueue.TaskRepository backed by database/sql,
+// aimed at PostgreSQL and SQLite. The caller owns the *sql.DB and brings
+// their own driver; Migrate installs the schema.
+type SQLRepository struct {
+	db      *sql.DB
+	dialect SQLDialect
+}
+
+// NewSQLRepository wraps the database handle for the given dialect.
+func NewSQLRepository(db *sql.DB, dialect SQLDialect) *SQLRepository {
+	return &SQLRepository{db: db, dialect: dialect}
+}
+
+// Migrate creates the schema when it does not exist yet and applies the
+// column additions later versions introduced. It is safe to run on
+// every start.
+func (r *SQLRepository) Migrate(ctx context.Context) error {
+	for _, stmt := range strings.Split(sqlSchema, ";") {
+		if strings.TrimSpace(stmt) == "" {
+			continue
+		}
+		if _, err := r.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("adapters: migrate tasks schema: %w", err)
+		}
+	}
+	for _, stmt := range sqlMigrations {
+		if _, err := r.db.ExecContext(ctx, stmt); err != nil {
+			if isDuplicateColumn(err) {
+				continue
+			}
+			return fmt.Errorf("adapters: migrate tasks schema: %w", err)
+		}
+	}
+	return nil
+}
+
+// isDuplicateColumn matches the SQLite and PostgreSQL errors for adding
+// a column that already exists.
+func isDuplicateColumn(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "duplicate column") || strings.Contains(msg, "already exists")
+}
+
+// rebind converts ?-placeholders to the dialect's own style.
+func (r *SQLRepository) rebind(query string) string {
+	if r.dialect != DialectPostgres {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, c := range query {
+		if c == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// Save implements taskqueue.TaskRepository as an upsert; overwriting an
+// existing row bumps its version.
+func (r *SQLRepository) Save(ctx context.Context, task *taskqueue.Task) error {
+	data, err := encodeJSON(task.Data)
+	if err != nil {
+		return fmt.Errorf("adapters: encode task data: %w", err)
+	}
+	metadata, err := encodeJSON(task.Metadata)
+	if err != nil {
+		return fmt.Errorf("adapters: encode task metadata: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, r.rebind(`
+		INSERT INTO taskqueue_tasks
+			(id, type, data, status, priority, retries, max_retries, metadata, created_at, updated_at, expires_at, timeout, deadline)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			type = excluded.type, data = excluded.data, status = excluded.status,
+			priority = excluded.priority, retries = excluded.retries,
+			max_retries = excluded.max_retries, metadata = excluded.metadata,
+			updated_at = excluded.updated_at, expires_at = excluded.expires_at,
+			timeout = excluded.timeout, deadline = excluded.deadline,
+			version = taskqueue_tasks.version + 1`),
+		task.ID, task.Type, data, string(task.Status), task.Priority, task.Retries,
+		task.MaxRetries, metadata, encodeTime(task.CreatedAt), encodeTime(task.UpdatedAt),
+		encodeTime(task.ExpiresAt), int64(task.Timeout), encodeTime(task.Deadline))
+	if err != nil {
+		return fmt.Errorf("adapters: save task %s: %w", task.ID, err)
+	}
+	return nil
+}
+
+// GetByID implements taskqueue.TaskRepository.
+func (r *SQLRepository) GetByID(ctx context.Context, id string) (*taskqueue.Task, error) {
+	row := r.db.QueryRowContext(ctx, r.rebind(
+		`SELECT id, type, data, status, priority, retries, max_retries, metadata, created_at, updated_at, expires_at, timeout, deadline
+		 FROM taskqueue_tasks WHERE id = ?`), id)
+	task, err := scanTask(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, taskqueue.ErrTaskNotFound
+	}
+	return task, err
+}
+
+// UpdateStatus implements taskqueue.TaskRepository with optimistic
+// concurrency: the update only lands when the row version is unchanged
+// since it was read, retrying a few times before ErrConcurrentUpdate.
+func (r *SQLRepository) UpdateStatus(ctx context.Context, id string, status taskqueue.TaskStatus) error {
+	for attempt := 0; attempt < occRetries; attempt++ {
+		var version int64
+		err := r.db.QueryRowContext(ctx, r.rebind(
+			`SELECT version FROM taskqueue_tasks WHERE id = ?`), id).Scan(&version)
+		if errors.Is(err, sql.ErrNoRows) {
+			return taskqueue.ErrTaskNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("adapters: read task version %s: %w", id, err)
+		}
+
+		result, err := r.db.ExecContext(ctx, r.rebind(
+			`UPDATE taskqueue_tasks SET status = ?, updated_at = ?, version = ?
+			 WHERE id = ? AND version = ?`),
+			string(status), encodeTime(time.Now().UTC()), version+1, id, version)
+		if err != nil {
+			return fmt.Errorf("adapters: update task status %s: %w", id, err)
+		}
+		if rows, err := result.RowsAffected(); err == nil && rows == 1 {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: task %s", ErrConcurrentUpdate, id)
+}
+
+// ListPending implements taskqueue.TaskRepository, highest priority and
+// oldest first. On PostgreSQL the selected rows are claimed with FOR
+// UPDATE SKIP LOCKED, so it should run inside a transaction-scoped
+// context when workers compete.
+func (r *SQLRepository) ListPending(ctx context.Context, limit int) ([]*taskqueue.Task, error) {
+	query := `SELECT id, type, data, status, priority, retries, max_retries, metadata, created_at, updated_at, expires_at, timeout, deadline
+		 FROM taskqueue_tasks WHERE status = ? ORDER BY priority DESC, created_at`
+	args := []any{string(taskqueue.TaskStatusPending)}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+	if r.dialect == DialectPostgres {
+		query += " FOR UPDATE SKIP LOCKED"
+	}
+
+	rows, err := r.db.QueryContext(ctx, r.rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("adapters: list pending tasks: %w", err)
+	}
+	defer rows.Close()
+
+	var pending []*taskqueue.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("adapters: list pending tasks: %w", err)
+	}
+	return pending, nil
+}
+
+// scanner covers *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanTask reads one task row.
+func scanTask(row scanner) (*taskqueue.Task, error) {
+	var task taskqueue.Task
+	var data, metadata, status, createdAt, updatedAt, expiresAt, deadline string
+	var timeout int64
+	err := row.Scan(&task.ID, &task.Type, &data, &status, &task.Priority,
+		&task.Retries, &task.MaxRetries, &metadata, &createdAt, &updatedAt, &expiresAt,
+		&timeout, &deadline)
+	if err != nil {
+		return nil, err
+	}
+	task.Status = taskqueue.TaskStatus(status)
+	if err := decodeJSON(data, &task.Data); err != nil {
+		return nil, fmt.Errorf("adapters: decode task data: %w", err)
+	}
+	if err := decodeJSON(metadata, &task.Metadata); err != nil {
+		return nil, fmt.Errorf("adapters: decode task metadata: %w", err)
+	}
+	if task.CreatedAt, err = decodeTime(createdAt); err != nil {
+		return nil, fmt.Errorf("adapters: decode created_at: %w", err)
+	}
+	if task.UpdatedAt, err = decodeTime(updatedAt); err != nil {
+		return nil, fmt.Errorf("adapters: decode updated_at: %w", err)
+	}
+	if task.ExpiresAt, err = decodeTime(expiresAt); err != nil {
+		return nil, fmt.Errorf("adapters: decode expires_at: %w", err)
+	}
+	task.Timeout = time.Duration(timeout)
+	if task.Deadline, err = decodeTime(deadline); err != nil {
+		return nil, fmt.Errorf("adapters: decode deadline: %w", err)
+	}
+	return &task, nil
+}
+
+// encodeJSON marshals a map column, writing the empty string for nil.
+func encodeJSON(v any) (string, error) {
+	switch m := v.(type) {
+	case map[string]any:
+		if m == nil {
+			return "", nil
+		}
+	case map[string]string:
+		if m == nil {
+			return "", nil
+		}
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// decodeJSON unmarshals a map column, leaving nil for the empty string.
+func decodeJSON(data string, v any) error {
+	if data == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(data), v)
+}
+
+// encodeTime formats a time column, writing the empty string for the
+// zero time.
+func encodeTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// decodeTime parses a time column.
+func decodeTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
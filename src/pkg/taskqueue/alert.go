@@ -0,0 +1,119 @@
+package taskqueue
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// AlertThresholds configures the conditions AlertEvaluator checks. Zero
+// fields disable the corresponding check.
+type AlertThresholds struct {
+	// MaxFailureRate is the tolerated ratio of failed to finished tasks,
+	// in the range (0, 1].
+	MaxFailureRate float64
+
+	// MaxQueueDepth is the tolerated number of buffered tasks.
+	MaxQueueDepth int
+
+	// MaxProcessDuration is the tolerated maximum observed task
+	// processing duration. Requires WorkerConfig.Metrics.
+	MaxProcessDuration time.Duration
+}
+
+// AlertEvent describes one threshold breach.
+type AlertEvent struct {
+	Name      string    `json:"name"`
+	Message   string    `json:"message"`
+	Value     float64   `json:"value"`
+	Threshold float64   `json:"threshold"`
+	At        time.Time `json:"at"`
+}
+
+// AlertFunc receives threshold breaches.
+type AlertFunc func(AlertEvent)
+
+// AlertEvaluator periodically checks worker statistics against configured
+// thresholds and invokes callbacks on breaches, so basic alerting works
+// without an external monitoring stack.
+type AlertEvaluator struct {
+	worker     *Worker
+	thresholds AlertThresholds
+	callbacks  []AlertFunc
+}
+
+// NewAlertEvaluator builds an evaluator for the given worker.
+func NewAlertEvaluator(worker *Worker, thresholds AlertThresholds, callbacks ...AlertFunc) *AlertEvaluator {
+	return &AlertEvaluator{worker: worker, thresholds: thresholds, callbacks: callbacks}
+}
+
+// Evaluate runs all configured checks once and returns the breaches, after
+// delivering them to the callbacks.
+func (e *AlertEvaluator) Evaluate() []AlertEvent {
+	now := time.Now().UTC()
+	var events []AlertEvent
+
+	if e.thresholds.MaxFailureRate > 0 {
+		stats := e.worker.Stats()
+		finished := stats.Completed + stats.Failed
+		if finished > 0 {
+			rate := float64(stats.Failed) / float64(finished)
+			if rate > e.thresholds.MaxFailureRate {
+				events = append(events, AlertEvent{
+					Name:      "failure_rate",
+					Message:   fmt.Sprintf("failure rate %.2f exceeds %.2f", rate, e.thresholds.MaxFailureRate),
+					Value:     rate,
+					Threshold: e.thresholds.MaxFailureRate,
+					At:        now,
+				})
+			}
+		}
+	}
+
+	if e.thresholds.MaxQueueDepth > 0 {
+		depth := e.worker.QueueDepth()
+		if depth > e.thresholds.MaxQueueDepth {
+			events = append(events, AlertEvent{
+				Name:      "queue_depth",
+				Message:   fmt.Sprintf("queue depth %d exceeds %d", depth, e.thresholds.MaxQueueDepth),
+				Value:     float64(depth),
+				Threshold: float64(e.thresholds.MaxQueueDepth),
+				At:        now,
+			})
+		}
+	}
+
+	if e.thresholds.MaxProcessDuration > 0 && e.worker.config.Metrics != nil {
+		max := e.worker.config.Metrics.Duration("task.process").Max
+		if max > e.thresholds.MaxProcessDuration {
+			events = append(events, AlertEvent{
+				Name:      "process_duration",
+				Message:   fmt.Sprintf("max processing time %s exceeds %s", max, e.thresholds.MaxProcessDuration),
+				Value:     max.Seconds(),
+				Threshold: e.thresholds.MaxProcessDuration.Seconds(),
+				At:        now,
+			})
+		}
+	}
+
+	for _, event := range events {
+		for _, cb := range e.callbacks {
+			cb(event)
+		}
+	}
+	return events
+}
+
+// Run evaluates at the given interval until ctx is cancelled.
+func (e *AlertEvaluator) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.Evaluate()
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertEvaluatorQueueDepth(t *testing.T) {
+	w := NewWorker(WorkerConfig{BufferSize: 10}, newMockRepository(), newMockPublisher())
+	for i := 0; i < 3; i++ {
+		require.NoError(t, w.SubmitTask(context.Background(), &Task{Type: "email"}))
+	}
+
+	var fired []AlertEvent
+	eval := NewAlertEvaluator(w, AlertThresholds{MaxQueueDepth: 2}, func(e AlertEvent) {
+		fired = append(fired, e)
+	})
+
+	events := eval.Evaluate()
+	require.Len(t, events, 1)
+	assert.Equal(t, "queue_depth", events[0].Name)
+	assert.Equal(t, float64(3), events[0].Value)
+	assert.Equal(t, events, fired)
+}
+
+func TestAlertEvaluatorFailureRate(t *testing.T) {
+	w := NewWorker(WorkerConfig{}, newMockRepository(), newMockPublisher())
+	w.stats.completed.Add(1)
+	w.stats.failed.Add(3)
+
+	eval := NewAlertEvaluator(w, AlertThresholds{MaxFailureRate: 0.5})
+	events := eval.Evaluate()
+	require.Len(t, events, 1)
+	assert.Equal(t, "failure_rate", events[0].Name)
+	assert.InDelta(t, 0.75, events[0].Value, 1e-9)
+
+	// Below threshold: no events.
+	w.stats.completed.Add(10)
+	assert.Empty(t, eval.Evaluate())
+}
@@ -0,0 +1,16 @@
+package taskqueue
+
+import "github.com/kodflow/taskqueue/pkg/taskqueue/metrics"
+
+// MetricsCollector aliases the collector that moved to the metrics
+// subpackage, so existing callers keep compiling during the migration.
+//
+// Deprecated: use metrics.Collector directly.
+type MetricsCollector = metrics.Collector
+
+// NewMetricsCollector returns a collector from the metrics subpackage.
+//
+// Deprecated: use metrics.NewCollector directly.
+func NewMetricsCollector() *MetricsCollector {
+	return metrics.NewCollector()
+}
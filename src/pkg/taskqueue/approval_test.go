@@ -0,0 +1,74 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiresApprovalFlag(t *testing.T) {
+	task := NewTask("deploy", nil)
+	assert.False(t, task.RequiresApproval())
+
+	task.SetRequiresApproval()
+	assert.True(t, task.RequiresApproval())
+
+	task.Metadata[ApprovedByMetadataKey] = "alex"
+	assert.False(t, task.RequiresApproval(), "approved tasks pass the gate")
+}
+
+func TestApprovalGateHoldsAndReleases(t *testing.T) {
+	repo := newMockRepository()
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, repo, nil)
+
+	executed := make(chan struct{})
+	w.Executors().Register("deploy", ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		close(executed)
+		return &TaskResult{Output: "done"}, nil
+	}))
+	w.Start()
+
+	task := NewTask("deploy", nil)
+	task.SetRequiresApproval()
+	require.NoError(t, w.SubmitTask(context.Background(), task))
+
+	require.Eventually(t, func() bool { return len(w.Held()) == 1 }, 2*time.Second, 5*time.Millisecond)
+	select {
+	case <-executed:
+		t.Fatal("held task must not execute before approval")
+	default:
+	}
+	saved, err := repo.GetByID(context.Background(), task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, TaskStatusAwaitingApproval, saved.Status)
+
+	require.NoError(t, w.Approve(context.Background(), task.ID, "alex"))
+	select {
+	case <-executed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("approved task was not executed")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	require.NoError(t, w.Shutdown(ctx))
+
+	assert.Equal(t, "alex", task.Metadata[ApprovedByMetadataKey])
+	assert.NotEmpty(t, task.Metadata[ApprovedAtMetadataKey])
+	assert.Empty(t, w.Held())
+	assert.EqualValues(t, 1, w.Stats().Completed)
+}
+
+func TestApproveUnknownTask(t *testing.T) {
+	w := NewWorker(WorkerConfig{}, newMockRepository(), nil)
+	defer w.Shutdown(context.Background())
+
+	assert.ErrorIs(t, w.Approve(context.Background(), "missing", "alex"), ErrTaskNotFound)
+}
+
+func TestApprovalStatusIsRegistered(t *testing.T) {
+	assert.True(t, IsValidStatus(TaskStatusAwaitingApproval))
+	assert.Contains(t, TransitionTable()[TaskStatusPending], TaskStatusAwaitingApproval)
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticTokens(t *testing.T) {
+	tokens := StaticTokens{"s3cret": {Subject: "ops", Roles: []string{"admin"}}}
+
+	principal, err := tokens.Authenticate("s3cret")
+	require.NoError(t, err)
+	assert.Equal(t, "ops", principal.Subject)
+	assert.True(t, principal.HasRole("admin"))
+
+	_, err = tokens.Authenticate("wrong")
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	secret := []byte("signing-key")
+	token, err := SignJWT(secret, Principal{Subject: "alice", Roles: []string{"submit"}}, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	authn := NewJWTAuthenticator(secret)
+	principal, err := authn.Authenticate(token)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", principal.Subject)
+	assert.True(t, principal.HasRole("submit"))
+
+	expired, err := SignJWT(secret, Principal{Subject: "alice"}, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	_, err = authn.Authenticate(expired)
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+
+	_, err = NewJWTAuthenticator([]byte("other-key")).Authenticate(token)
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+}
+
+func TestMiddlewareAndRequireRole(t *testing.T) {
+	tokens := StaticTokens{
+		"admin-token":  {Subject: "ops", Roles: []string{"admin"}},
+		"viewer-token": {Subject: "dev", Roles: []string{"viewer"}},
+	}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := PrincipalFromContext(r.Context())
+		require.True(t, ok)
+		w.Write([]byte(principal.Subject))
+	})
+	handler = Middleware(tokens, nil)(RequireRole("admin")(handler))
+
+	tests := []struct {
+		name       string
+		token      string
+		wantStatus int
+	}{
+		{"no token", "", http.StatusUnauthorized},
+		{"bad token", "nope", http.StatusUnauthorized},
+		{"wrong role", "viewer-token", http.StatusForbidden},
+		{"admin", "admin-token", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package taskqueue
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Provenance metadata stamped on backfilled tasks.
+const (
+	// BackfilledFromMetadataKey records the ID of the original task a
+	// backfilled copy was derived from.
+	BackfilledFromMetadataKey = "taskqueue.backfilled_from"
+
+	// BackfilledAtMetadataKey records when the backfill resubmitted the
+	// task, encoded as RFC 3339 with nanoseconds.
+	BackfilledAtMetadataKey = "taskqueue.backfilled_at"
+)
+
+// TaskFilter selects repository tasks for bulk operations such as
+// Backfill. Zero fields match everything.
+type TaskFilter struct {
+	// Status matches tasks currently in this status.
+	Status TaskStatus
+
+	// Type matches tasks of this type.
+	Type string
+
+	// Since matches tasks last updated at or after this time.
+	Since time.Time
+
+	// Until matches tasks last updated before this time.
+	Until time.Time
+
+	// CreatedSince matches tasks created at or after this time.
+	CreatedSince time.Time
+
+	// CreatedUntil matches tasks created before this time.
+	CreatedUntil time.Time
+}
+
+// Matches reports whether the task satisfies the filter.
+func (f TaskFilter) Matches(task *Task) bool {
+	if f.Status != "" && task.Status != f.Status {
+		return false
+	}
+	if f.Type != "" && task.Type != f.Type {
+		return false
+	}
+	if !f.Since.IsZero() && task.UpdatedAt.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !task.UpdatedAt.Before(f.Until) {
+		return false
+	}
+	if !f.CreatedSince.IsZero() && task.CreatedAt.Before(f.CreatedSince) {
+		return false
+	}
+	if !f.CreatedUntil.IsZero() && !task.CreatedAt.Before(f.CreatedUntil) {
+		return false
+	}
+	return true
+}
+
+// TaskLister is the optional repository capability bulk operations need:
+// listing tasks beyond the pending ones.
+type TaskLister interface {
+	// ListTasks returns the tasks matching the filter.
+	ListTasks(ctx context.Context, filter TaskFilter) ([]*Task, error)
+}
+
+// ListTasks returns the repository tasks matching the filter. The
+// repository must implement TaskLister; ErrNoTaskLister is returned
+// otherwise.
+func (w *Worker) ListTasks(ctx context.Context, filter TaskFilter) ([]*Task, error) {
+	lister, ok := repositoryAs[TaskLister](w.repo)
+	if !ok {
+		return nil, ErrNoTaskLister
+	}
+	tasks, err := lister.ListTasks(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("taskqueue: list tasks: %w", err)
+	}
+	return tasks, nil
+}
+
+// Backfill resubmits copies of the repository tasks matching the filter,
+// at most rate submissions per second (unpaced when rate is zero). Each
+// copy gets a fresh ID and provenance metadata pointing at its original;
+// transform, when non-nil, may adjust a copy before submission or return
+// nil to skip it. It returns how many tasks were resubmitted.
+//
+// The repository must implement TaskLister; ErrNoTaskLister is returned
+// otherwise.
+func (w *Worker) Backfill(ctx context.Context, filter TaskFilter, transform func(*Task) *Task, rate float64) (int, error) {
+	lister, ok := repositoryAs[TaskLister](w.repo)
+	if !ok {
+		return 0, ErrNoTaskLister
+	}
+	tasks, err := lister.ListTasks(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("taskqueue: list tasks for backfill: %w", err)
+	}
+
+	var ticker *time.Ticker
+	if rate > 0 {
+		ticker = time.NewTicker(time.Duration(float64(time.Second) / rate))
+		defer ticker.Stop()
+	}
+
+	submitted := 0
+	for _, original := range tasks {
+		if ticker != nil {
+			select {
+			case <-ctx.Done():
+				return submitted, ctx.Err()
+			case <-ticker.C:
+			}
+		}
+		task := cloneTemplate(original)
+		if task.Metadata == nil {
+			task.Metadata = make(map[string]string, 2)
+		}
+		task.Metadata[BackfilledFromMetadataKey] = original.ID
+		task.Metadata[BackfilledAtMetadataKey] = time.Now().UTC().Format(time.RFC3339Nano)
+		if transform != nil {
+			if task = transform(task); task == nil {
+				continue
+			}
+		}
+		if err := w.SubmitTask(ctx, task); err != nil {
+			return submitted, fmt.Errorf("taskqueue: backfill %s: %w", original.ID, err)
+		}
+		submitted++
+	}
+	w.logger.Info("taskqueue: backfill finished", "matched", len(tasks), "submitted", submitted)
+	return submitted, nil
+}
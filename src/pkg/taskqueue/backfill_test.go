@@ -0,0 +1,104 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ListTasks implements TaskLister for the test repository.
+func (m *mockRepository) ListTasks(_ context.Context, filter TaskFilter) ([]*Task, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var matched []*Task
+	for _, task := range m.tasks {
+		if filter.Matches(task) {
+			matched = append(matched, task)
+		}
+	}
+	return matched, nil
+}
+
+func TestTaskFilterMatches(t *testing.T) {
+	now := time.Now().UTC()
+	task := &Task{Type: "email", Status: TaskStatusFailed, CreatedAt: now.Add(-time.Hour), UpdatedAt: now}
+
+	tests := []struct {
+		name   string
+		filter TaskFilter
+		want   bool
+	}{
+		{"empty filter matches", TaskFilter{}, true},
+		{"status match", TaskFilter{Status: TaskStatusFailed}, true},
+		{"status mismatch", TaskFilter{Status: TaskStatusCompleted}, false},
+		{"type mismatch", TaskFilter{Type: "report"}, false},
+		{"within window", TaskFilter{Since: now.Add(-time.Hour), Until: now.Add(time.Hour)}, true},
+		{"before window", TaskFilter{Since: now.Add(time.Minute)}, false},
+		{"after window", TaskFilter{Until: now.Add(-time.Minute)}, false},
+		{"within created window", TaskFilter{CreatedSince: now.Add(-2 * time.Hour), CreatedUntil: now}, true},
+		{"created too early", TaskFilter{CreatedSince: now.Add(-time.Minute)}, false},
+		{"created too late", TaskFilter{CreatedUntil: now.Add(-2 * time.Hour)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.Matches(task))
+		})
+	}
+}
+
+func TestBackfillResubmitsMatchingTasksWithProvenance(t *testing.T) {
+	repo := newMockRepository()
+	worker := NewWorker(WorkerConfig{WorkerCount: 1}, repo, nil)
+
+	ctx := context.Background()
+	failed := &Task{ID: "f1", Type: "email", Status: TaskStatusFailed, UpdatedAt: time.Now().UTC()}
+	require.NoError(t, repo.Save(ctx, failed))
+	require.NoError(t, repo.Save(ctx, &Task{ID: "ok1", Type: "email", Status: TaskStatusCompleted, UpdatedAt: time.Now().UTC()}))
+
+	submitted, err := worker.Backfill(ctx, TaskFilter{Status: TaskStatusFailed}, nil, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, submitted)
+
+	var copies []*Task
+	for task := range worker.cache.Values() {
+		copies = append(copies, task)
+	}
+	require.Len(t, copies, 1)
+	assert.NotEqual(t, "f1", copies[0].ID)
+	assert.Equal(t, "f1", copies[0].Metadata[BackfilledFromMetadataKey])
+	assert.NotEmpty(t, copies[0].Metadata[BackfilledAtMetadataKey])
+	assert.Equal(t, TaskStatusPending, copies[0].Status)
+}
+
+func TestBackfillAppliesTransformAndSkipsNil(t *testing.T) {
+	repo := newMockRepository()
+	worker := NewWorker(WorkerConfig{WorkerCount: 1}, repo, nil)
+
+	ctx := context.Background()
+	require.NoError(t, repo.Save(ctx, &Task{ID: "f1", Type: "email", Status: TaskStatusFailed}))
+	require.NoError(t, repo.Save(ctx, &Task{ID: "f2", Type: "report", Status: TaskStatusFailed}))
+
+	submitted, err := worker.Backfill(ctx, TaskFilter{Status: TaskStatusFailed}, func(task *Task) *Task {
+		if task.Type == "report" {
+			return nil
+		}
+		task.Priority = 5
+		return task
+	}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, submitted)
+}
+
+func TestBackfillRequiresTaskLister(t *testing.T) {
+	worker := NewWorker(WorkerConfig{}, listlessRepository{newMockRepository()}, nil)
+	_, err := worker.Backfill(context.Background(), TaskFilter{}, nil, 0)
+	assert.ErrorIs(t, err, ErrNoTaskLister)
+}
+
+// listlessRepository hides the mock's ListTasks method.
+type listlessRepository struct{ *mockRepository }
+
+func (listlessRepository) ListTasks() {}
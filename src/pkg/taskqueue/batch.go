@@ -0,0 +1,50 @@
+package taskqueue
+
+import "context"
+
+// BatchOutcome is the result of one task within a SubmitBatch call.
+type BatchOutcome struct {
+	// TaskID identifies the task; empty when a nil task was submitted.
+	TaskID string
+
+	// Err is the submission error, nil for accepted tasks.
+	Err error
+}
+
+// BatchResult reports the per-task outcomes of one SubmitBatch call, in
+// submission order.
+type BatchResult struct {
+	Accepted int
+	Rejected int
+	Outcomes []BatchOutcome
+}
+
+// SubmitBatch submits many tasks in one call, recording a per-task
+// accept or reject outcome instead of stopping at the first failure, so
+// repository-driven backfills do not need their own submit loops. Each
+// task goes through the normal SubmitTask path — validation, dedup,
+// signing, persistence and stats all behave exactly as for single
+// submissions. Rejections come back both in the outcomes and as a
+// *MultiError mapping batch indexes to their submission errors; the
+// context ending mid-batch is returned as-is.
+func (w *Worker) SubmitBatch(ctx context.Context, tasks []*Task, opts ...SubmitOption) (BatchResult, error) {
+	result := BatchResult{Outcomes: make([]BatchOutcome, 0, len(tasks))}
+	merr := &MultiError{}
+	for i, task := range tasks {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		outcome := BatchOutcome{Err: w.SubmitTask(ctx, task, opts...)}
+		if task != nil {
+			outcome.TaskID = task.ID
+		}
+		if outcome.Err == nil {
+			result.Accepted++
+		} else {
+			result.Rejected++
+			merr.Add(i, outcome.Err)
+		}
+		result.Outcomes = append(result.Outcomes, outcome)
+	}
+	return result, merr.ErrOrNil()
+}
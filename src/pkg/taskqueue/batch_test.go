@@ -0,0 +1,56 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitBatchReportsPerTaskOutcomes(t *testing.T) {
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), nil)
+	w.Executors().Register("email", ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		return &TaskResult{}, nil
+	}))
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	good := NewTask("email", nil)
+	invalid := NewTask("", nil)
+	result, err := w.SubmitBatch(context.Background(), []*Task{good, invalid, nil})
+	require.Error(t, err)
+
+	var merr *MultiError
+	require.ErrorAs(t, err, &merr)
+	assert.Equal(t, 2, merr.Len())
+	assert.ErrorIs(t, merr.ByIndex(1), ErrInvalidTask)
+	assert.ErrorIs(t, merr.ByIndex(2), ErrNilTask)
+	assert.NoError(t, merr.ByIndex(0))
+
+	assert.Equal(t, 1, result.Accepted)
+	assert.Equal(t, 2, result.Rejected)
+	require.Len(t, result.Outcomes, 3)
+	assert.Equal(t, good.ID, result.Outcomes[0].TaskID)
+	assert.NoError(t, result.Outcomes[0].Err)
+	assert.ErrorIs(t, result.Outcomes[1].Err, ErrInvalidTask)
+	assert.ErrorIs(t, result.Outcomes[2].Err, ErrNilTask)
+	assert.Empty(t, result.Outcomes[2].TaskID)
+
+	require.Eventually(t, func() bool {
+		snapshot := w.Stats()
+		return snapshot.Completed == 1 && snapshot.Rejected == 1
+	}, 2*time.Second, 5*time.Millisecond)
+}
+
+func TestSubmitBatchStopsWhenContextEnds(t *testing.T) {
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), nil)
+	defer w.Shutdown(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := w.SubmitBatch(ctx, []*Task{NewTask("email", nil)})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, result.Outcomes)
+}
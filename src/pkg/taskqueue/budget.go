@@ -0,0 +1,106 @@
+package taskqueue
+
+import (
+	"sync"
+	"time"
+)
+
+// SLADeadlineMetadataKey carries a task's end-to-end latency deadline in
+// its metadata, encoded as RFC 3339 with nanoseconds.
+const SLADeadlineMetadataKey = "taskqueue.sla_deadline"
+
+// nearMissFraction is how much of the latency budget may remain at
+// completion before the worker logs a near-miss warning.
+const nearMissFraction = 0.1
+
+// SetSLADeadline annotates the task with a latency deadline the caller
+// expects it to complete by.
+func (t *Task) SetSLADeadline(deadline time.Time) {
+	if t.Metadata == nil {
+		t.Metadata = make(map[string]string)
+	}
+	t.Metadata[SLADeadlineMetadataKey] = deadline.UTC().Format(time.RFC3339Nano)
+}
+
+// SLADeadline returns the task's latency deadline, if one was set.
+func (t *Task) SLADeadline() (time.Time, bool) {
+	raw, ok := t.Metadata[SLADeadlineMetadataKey]
+	if !ok {
+		return time.Time{}, false
+	}
+	deadline, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return deadline, true
+}
+
+// SLACount holds per-type deadline outcomes.
+type SLACount struct {
+	Met    uint64 `json:"met"`
+	Missed uint64 `json:"missed"`
+}
+
+// slaStats tracks deadline outcomes per task type.
+type slaStats struct {
+	mu     sync.Mutex
+	counts map[string]*SLACount
+}
+
+// newSLAStats builds an empty tracker.
+func newSLAStats() *slaStats {
+	return &slaStats{counts: make(map[string]*SLACount)}
+}
+
+// record counts one deadline outcome for the type.
+func (s *slaStats) record(taskType string, met bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count, ok := s.counts[taskType]
+	if !ok {
+		count = &SLACount{}
+		s.counts[taskType] = count
+	}
+	if met {
+		count.Met++
+	} else {
+		count.Missed++
+	}
+}
+
+// snapshot returns a copy of the per-type counts.
+func (s *slaStats) snapshot() map[string]SLACount {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts := make(map[string]SLACount, len(s.counts))
+	for taskType, count := range s.counts {
+		counts[taskType] = *count
+	}
+	return counts
+}
+
+// SLACounts returns per-type counters of tasks that met or missed their
+// annotated latency deadline.
+func (w *Worker) SLACounts() map[string]SLACount {
+	return w.sla.snapshot()
+}
+
+// recordSLA records whether a finished task met its annotated deadline,
+// warning when it completed with almost none of its budget left.
+func (w *Worker) recordSLA(task *Task, completedAt time.Time) {
+	deadline, ok := task.SLADeadline()
+	if !ok {
+		return
+	}
+	met := !completedAt.After(deadline)
+	w.sla.record(task.Type, met)
+	if !met {
+		return
+	}
+	budget := deadline.Sub(task.CreatedAt)
+	remaining := deadline.Sub(completedAt)
+	if budget > 0 && remaining.Seconds() < budget.Seconds()*nearMissFraction {
+		w.logger.Warn("taskqueue: task nearly missed its deadline",
+			"task_id", task.ID, "type", task.Type, "remaining", remaining, "budget", budget)
+	}
+}
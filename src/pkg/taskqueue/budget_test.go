@@ -0,0 +1,68 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSLADeadlineRoundTrip(t *testing.T) {
+	task := NewTask("email", nil)
+	_, ok := task.SLADeadline()
+	assert.False(t, ok)
+
+	deadline := time.Now().Add(time.Minute).UTC()
+	task.SetSLADeadline(deadline)
+	got, ok := task.SLADeadline()
+	require.True(t, ok)
+	assert.True(t, got.Equal(deadline))
+}
+
+func TestRecordSLACountsMetAndMissed(t *testing.T) {
+	worker := NewWorker(WorkerConfig{}, newMockRepository(), nil)
+	now := time.Now().UTC()
+
+	met := NewTask("email", nil)
+	met.SetSLADeadline(now.Add(time.Minute))
+	worker.recordSLA(met, now)
+
+	missed := NewTask("email", nil)
+	missed.SetSLADeadline(now.Add(-time.Second))
+	worker.recordSLA(missed, now)
+
+	unannotated := NewTask("email", nil)
+	worker.recordSLA(unannotated, now)
+
+	counts := worker.SLACounts()
+	assert.EqualValues(t, 1, counts["email"].Met)
+	assert.EqualValues(t, 1, counts["email"].Missed)
+}
+
+func TestWorkerTracksDeadlinesEndToEnd(t *testing.T) {
+	worker := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), nil)
+	worker.Executors().Register("email", ExecutorFunc(func(ctx context.Context, task *Task) (*TaskResult, error) {
+		if task.Data["fail"] == true {
+			return nil, assert.AnError
+		}
+		return &TaskResult{}, nil
+	}))
+
+	ctx := context.Background()
+	ok := NewTask("email", nil)
+	ok.SetSLADeadline(time.Now().Add(time.Minute))
+	require.NoError(t, worker.SubmitTask(ctx, ok))
+
+	failing := NewTask("email", map[string]any{"fail": true})
+	failing.SetSLADeadline(time.Now().Add(time.Minute))
+	require.NoError(t, worker.SubmitTask(ctx, failing))
+
+	worker.Start()
+	require.NoError(t, worker.Shutdown(ctx))
+
+	counts := worker.SLACounts()
+	assert.EqualValues(t, 1, counts["email"].Met)
+	assert.EqualValues(t, 1, counts["email"].Missed)
+}
@@ -0,0 +1,114 @@
+package taskqueue
+
+import (
+	"context"
+	"fmt"
+)
+
+// BulkOptions tune the administrative bulk operations.
+type BulkOptions struct {
+	// DryRun reports what would change without touching any task.
+	DryRun bool
+
+	// Progress, when set, is called after each examined task with how
+	// many have been processed so far out of how many matched.
+	Progress func(processed, matched int)
+}
+
+// BulkResult summarizes one bulk operation.
+type BulkResult struct {
+	// Matched is how many tasks the filter selected.
+	Matched int `json:"matched"`
+
+	// Changed is how many of them were (or, in dry-run, would be)
+	// cancelled or requeued.
+	Changed int `json:"changed"`
+
+	// Skipped is how many matched tasks were left alone because the
+	// change was not a legal transition for their status.
+	Skipped int `json:"skipped"`
+}
+
+// BulkCancel cancels every repository task matching the filter whose
+// status legally allows it, so incident cleanup does not need one-off
+// scripts against the store. The repository must implement TaskLister.
+func (w *Worker) BulkCancel(ctx context.Context, filter TaskFilter, opts BulkOptions) (BulkResult, error) {
+	return w.bulkApply(ctx, filter, opts, "cancel", func(ctx context.Context, task *Task) (bool, error) {
+		if !task.CanTransition(TaskStatusCancelled) {
+			return false, nil
+		}
+		if !opts.DryRun {
+			if _, carried := w.cache.Load(task.ID); carried {
+				// The marker makes the pool drop the task at dequeue;
+				// without it the executor would still run and overwrite
+				// the cancelled status.
+				w.cancelRequests.Store(task.ID, struct{}{})
+			}
+			w.transition(ctx, task, TaskStatusCancelled)
+			w.cache.Delete(task.ID)
+		}
+		return true, nil
+	})
+}
+
+// BulkRequeue resubmits every repository task matching the filter whose
+// status legally allows returning to pending — typically failed tasks
+// after an incident. Tasks keep their IDs. The repository must implement
+// TaskLister.
+func (w *Worker) BulkRequeue(ctx context.Context, filter TaskFilter, opts BulkOptions) (BulkResult, error) {
+	return w.bulkApply(ctx, filter, opts, "requeue", func(ctx context.Context, task *Task) (bool, error) {
+		if task.Status != TaskStatusPending && !task.CanTransition(TaskStatusPending) {
+			return false, nil
+		}
+		if opts.DryRun {
+			return true, nil
+		}
+		w.statuses.Decrement(task.Status)
+		task.Status = TaskStatusPending
+		if err := w.SubmitTask(ctx, task); err != nil {
+			w.statuses.Increment(task.Status)
+			return false, err
+		}
+		return true, nil
+	})
+}
+
+// bulkApply runs one change over every task matching the filter,
+// reporting progress and accumulating the result.
+func (w *Worker) bulkApply(ctx context.Context, filter TaskFilter, opts BulkOptions, name string, apply func(context.Context, *Task) (bool, error)) (BulkResult, error) {
+	lister, ok := repositoryAs[TaskLister](w.repo)
+	if !ok {
+		return BulkResult{}, ErrNoTaskLister
+	}
+	tasks, err := lister.ListTasks(ctx, filter)
+	if err != nil {
+		return BulkResult{}, fmt.Errorf("taskqueue: list tasks for bulk %s: %w", name, err)
+	}
+
+	result := BulkResult{Matched: len(tasks)}
+	merr := &MultiError{}
+	for i, task := range tasks {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		changed, err := apply(ctx, task)
+		switch {
+		case err != nil:
+			// Keep going: one broken task must not abort incident
+			// cleanup for the rest, the aggregate reports it.
+			merr.Add(i, fmt.Errorf("taskqueue: bulk %s %s: %w", name, task.ID, err))
+		case changed:
+			result.Changed++
+		default:
+			result.Skipped++
+		}
+		if opts.Progress != nil {
+			opts.Progress(i+1, len(tasks))
+		}
+	}
+	w.logger.Info("taskqueue: bulk operation finished",
+		"operation", name, "dry_run", opts.DryRun,
+		"matched", result.Matched, "changed", result.Changed, "skipped", result.Skipped,
+		"failed", merr.Len())
+	return result, merr.ErrOrNil()
+}
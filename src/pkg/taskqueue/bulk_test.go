@@ -0,0 +1,119 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkCancelCancelsEligibleTasks(t *testing.T) {
+	repo := newMockRepository()
+	worker := NewWorker(WorkerConfig{}, repo, nil)
+
+	ctx := context.Background()
+	require.NoError(t, repo.Save(ctx, &Task{ID: "p1", Type: "email", Status: TaskStatusPending}))
+	require.NoError(t, repo.Save(ctx, &Task{ID: "f1", Type: "email", Status: TaskStatusFailed}))
+	require.NoError(t, repo.Save(ctx, &Task{ID: "c1", Type: "email", Status: TaskStatusCompleted}))
+
+	var steps []int
+	result, err := worker.BulkCancel(ctx, TaskFilter{Type: "email"}, BulkOptions{
+		Progress: func(processed, matched int) { steps = append(steps, processed); assert.Equal(t, 3, matched) },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, BulkResult{Matched: 3, Changed: 2, Skipped: 1}, result)
+	assert.Equal(t, []int{1, 2, 3}, steps)
+
+	cancelled, err := repo.GetByID(ctx, "p1")
+	require.NoError(t, err)
+	assert.Equal(t, TaskStatusCancelled, cancelled.Status)
+	done, err := repo.GetByID(ctx, "c1")
+	require.NoError(t, err)
+	assert.Equal(t, TaskStatusCompleted, done.Status)
+}
+
+func TestBulkCancelDryRunChangesNothing(t *testing.T) {
+	repo := newMockRepository()
+	worker := NewWorker(WorkerConfig{}, repo, nil)
+
+	ctx := context.Background()
+	require.NoError(t, repo.Save(ctx, &Task{ID: "p1", Type: "email", Status: TaskStatusPending}))
+
+	result, err := worker.BulkCancel(ctx, TaskFilter{}, BulkOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, BulkResult{Matched: 1, Changed: 1}, result)
+
+	task, err := repo.GetByID(ctx, "p1")
+	require.NoError(t, err)
+	assert.Equal(t, TaskStatusPending, task.Status)
+}
+
+func TestBulkRequeueResubmitsFailedTasks(t *testing.T) {
+	repo := newMockRepository()
+	worker := NewWorker(WorkerConfig{WorkerCount: 1}, repo, nil)
+	processed := make(chan string, 2)
+	worker.Executors().Register("email", ExecutorFunc(func(_ context.Context, task *Task) (*TaskResult, error) {
+		processed <- task.ID
+		return &TaskResult{}, nil
+	}))
+
+	ctx := context.Background()
+	require.NoError(t, repo.Save(ctx, &Task{ID: "f1", Type: "email", Status: TaskStatusFailed, UpdatedAt: time.Now().UTC()}))
+	require.NoError(t, repo.Save(ctx, &Task{ID: "c1", Type: "email", Status: TaskStatusCompleted}))
+
+	result, err := worker.BulkRequeue(ctx, TaskFilter{Type: "email"}, BulkOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, BulkResult{Matched: 2, Changed: 1, Skipped: 1}, result)
+
+	worker.Start()
+	require.NoError(t, worker.Shutdown(ctx))
+	assert.Equal(t, "f1", <-processed)
+}
+
+func TestBulkOperationsRequireTaskLister(t *testing.T) {
+	worker := NewWorker(WorkerConfig{}, listlessRepository{newMockRepository()}, nil)
+	_, err := worker.BulkCancel(context.Background(), TaskFilter{}, BulkOptions{})
+	assert.ErrorIs(t, err, ErrNoTaskLister)
+	_, err = worker.BulkRequeue(context.Background(), TaskFilter{}, BulkOptions{})
+	assert.ErrorIs(t, err, ErrNoTaskLister)
+}
+
+func TestBulkCancelStopsBufferedTaskFromExecuting(t *testing.T) {
+	repo := newMockRepository()
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, repo, nil)
+	release := make(chan struct{})
+	executed := make(chan string, 2)
+	w.Executors().Register("email", ExecutorFunc(func(_ context.Context, task *Task) (*TaskResult, error) {
+		executed <- task.ID
+		<-release
+		return &TaskResult{}, nil
+	}))
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	ctx := context.Background()
+	blocker := NewTask("email", nil)
+	require.NoError(t, w.SubmitTask(ctx, blocker))
+	<-executed
+
+	victim := NewTask("email", nil)
+	require.NoError(t, w.SubmitTask(ctx, victim))
+
+	result, err := w.BulkCancel(ctx, TaskFilter{Status: TaskStatusPending}, BulkOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Changed)
+
+	close(release)
+	require.Eventually(t, func() bool { return w.Stats().Completed == 1 }, 2*time.Second, 5*time.Millisecond)
+
+	select {
+	case id := <-executed:
+		t.Fatalf("cancelled task %s was executed", id)
+	case <-time.After(100 * time.Millisecond):
+	}
+	stored, err := repo.GetByID(ctx, victim.ID)
+	require.NoError(t, err)
+	assert.Equal(t, TaskStatusCancelled, stored.Status)
+}
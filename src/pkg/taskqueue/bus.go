@@ -0,0 +1,129 @@
+package taskqueue
+
+import "sync"
+
+// DropPolicy controls what a bounded subscription does with a published
+// event when its buffer is full.
+type DropPolicy int
+
+const (
+	// DropNewest discards the incoming event, keeping what is buffered.
+	DropNewest DropPolicy = iota
+
+	// DropOldest evicts the oldest buffered event to make room.
+	DropOldest
+
+	// BlockPublisher makes Publish wait until the subscriber catches up.
+	// Use only for subscribers that must not miss events, such as an
+	// audit log; a stalled subscriber stalls the publisher.
+	BlockPublisher
+)
+
+// subscription is one bounded subscriber of a topic.
+type subscription[T any] struct {
+	ch     chan T
+	policy DropPolicy
+}
+
+// Topic is a typed event stream with bounded, independently dropping
+// subscribers. The zero value is not usable; use NewTopic.
+type Topic[T any] struct {
+	mu   sync.Mutex
+	subs map[*subscription[T]]struct{}
+}
+
+// NewTopic returns an empty topic.
+func NewTopic[T any]() *Topic[T] {
+	return &Topic[T]{subs: make(map[*subscription[T]]struct{})}
+}
+
+// Subscribe registers a subscriber with the given buffer size and drop
+// policy and returns its channel with a cancel function. Cancel closes
+// the channel and must be called when done.
+func (t *Topic[T]) Subscribe(buffer int, policy DropPolicy) (<-chan T, func()) {
+	if buffer <= 0 {
+		buffer = 64
+	}
+	sub := &subscription[T]{ch: make(chan T, buffer), policy: policy}
+	t.mu.Lock()
+	t.subs[sub] = struct{}{}
+	t.mu.Unlock()
+	cancel := func() {
+		t.mu.Lock()
+		if _, ok := t.subs[sub]; ok {
+			delete(t.subs, sub)
+			close(sub.ch)
+		}
+		t.mu.Unlock()
+	}
+	return sub.ch, cancel
+}
+
+// Publish delivers the event to every subscriber according to its drop
+// policy.
+func (t *Topic[T]) Publish(event T) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for sub := range t.subs {
+		switch sub.policy {
+		case DropOldest:
+			for {
+				select {
+				case sub.ch <- event:
+				default:
+					select {
+					case <-sub.ch:
+					default:
+					}
+					continue
+				}
+				break
+			}
+		case BlockPublisher:
+			sub.ch <- event
+		default:
+			select {
+			case sub.ch <- event:
+			default:
+			}
+		}
+	}
+}
+
+// EventBus groups the package's typed in-process topics. The worker
+// publishes to it so hooks, SSE streaming and audit logging subscribe to
+// one bus instead of each growing a bespoke call site.
+type EventBus struct {
+	// Lifecycle carries one event per task lifecycle step.
+	Lifecycle *Topic[TaskEvent]
+
+	// SLO carries error budget threshold crossings.
+	SLO *Topic[SLOEvent]
+
+	// Shed carries memory governor load-shedding transitions.
+	Shed *Topic[ShedEvent]
+
+	// Results carries every task result, successful or failed, as it
+	// lands.
+	Results *Topic[*TaskResult]
+
+	// Drain carries progress updates while a drain works through the
+	// backlog.
+	Drain *Topic[DrainEvent]
+
+	// Progress carries intermediate progress reports from executors of
+	// long-running tasks.
+	Progress *Topic[TaskProgress]
+}
+
+// NewEventBus returns a bus with all topics initialized.
+func NewEventBus() *EventBus {
+	return &EventBus{
+		Lifecycle: NewTopic[TaskEvent](),
+		SLO:       NewTopic[SLOEvent](),
+		Shed:      NewTopic[ShedEvent](),
+		Results:   NewTopic[*TaskResult](),
+		Drain:     NewTopic[DrainEvent](),
+		Progress:  NewTopic[TaskProgress](),
+	}
+}
@@ -0,0 +1,96 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopicDropNewestKeepsBufferedEvents(t *testing.T) {
+	topic := NewTopic[TaskEvent]()
+	ch, cancel := topic.Subscribe(1, DropNewest)
+	defer cancel()
+
+	topic.Publish(TaskEvent{TaskID: "t1"})
+	topic.Publish(TaskEvent{TaskID: "t2"})
+
+	event := <-ch
+	assert.Equal(t, "t1", event.TaskID)
+	select {
+	case extra := <-ch:
+		t.Fatalf("unexpected buffered event %s", extra.TaskID)
+	default:
+	}
+}
+
+func TestTopicDropOldestEvictsForNewEvents(t *testing.T) {
+	topic := NewTopic[TaskEvent]()
+	ch, cancel := topic.Subscribe(1, DropOldest)
+	defer cancel()
+
+	topic.Publish(TaskEvent{TaskID: "t1"})
+	topic.Publish(TaskEvent{TaskID: "t2"})
+
+	event := <-ch
+	assert.Equal(t, "t2", event.TaskID)
+}
+
+func TestTopicBlockPublisherWaitsForSubscriber(t *testing.T) {
+	topic := NewTopic[TaskEvent]()
+	ch, cancel := topic.Subscribe(1, BlockPublisher)
+	defer cancel()
+
+	topic.Publish(TaskEvent{TaskID: "t1"})
+	published := make(chan struct{})
+	go func() {
+		topic.Publish(TaskEvent{TaskID: "t2"})
+		close(published)
+	}()
+
+	select {
+	case <-published:
+		t.Fatal("publish completed against a full blocking subscriber")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	assert.Equal(t, "t1", (<-ch).TaskID)
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("publish did not complete after drain")
+	}
+	assert.Equal(t, "t2", (<-ch).TaskID)
+}
+
+func TestTopicCancelUnsubscribesAndCloses(t *testing.T) {
+	topic := NewTopic[TaskEvent]()
+	ch, cancel := topic.Subscribe(1, DropNewest)
+	cancel()
+	cancel() // idempotent
+
+	_, open := <-ch
+	assert.False(t, open)
+	topic.Publish(TaskEvent{TaskID: "t1"})
+}
+
+func TestEventBusWiresGovernorAndSLOTopics(t *testing.T) {
+	bus := NewEventBus()
+
+	shed, cancelShed := bus.Shed.Subscribe(1, DropNewest)
+	defer cancelShed()
+	governor := NewMemoryGovernor(MemoryGovernorConfig{Limit: 1, Bus: bus})
+	require.True(t, governor.Check(context.Background()))
+	event := <-shed
+	assert.True(t, event.Shedding)
+
+	crossings, cancelSLO := bus.SLO.Subscribe(4, DropNewest)
+	defer cancelSLO()
+	tracker := NewSLOTracker([]SLO{{Name: "email", Target: 0.5}})
+	tracker.Notify(bus.SLO)
+	tracker.Record("email", false, 0)
+	crossing := <-crossings
+	assert.Equal(t, "email", crossing.Name)
+}
@@ -0,0 +1,186 @@
+package taskqueue
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"sync/atomic"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue/syncmap"
+)
+
+// TaskCache is a concurrent map of task ID to task, used as a hot-path
+// lookup in front of the repository.
+type TaskCache struct {
+	m syncmap.Map[string, *Task]
+}
+
+// NewTaskCache returns an empty cache.
+func NewTaskCache() *TaskCache {
+	return &TaskCache{}
+}
+
+// Store inserts or replaces a task.
+func (c *TaskCache) Store(task *Task) {
+	c.m.Store(task.ID, task)
+}
+
+// Load returns the cached task and whether it was present.
+func (c *TaskCache) Load(id string) (*Task, bool) {
+	return c.m.Load(id)
+}
+
+// LoadOrStore returns the existing task for the ID if present, otherwise
+// stores and returns the given task. loaded is true if the task existed.
+func (c *TaskCache) LoadOrStore(task *Task) (actual *Task, loaded bool) {
+	return c.m.LoadOrStore(task.ID, task)
+}
+
+// Delete removes a task from the cache.
+func (c *TaskCache) Delete(id string) {
+	c.m.Delete(id)
+}
+
+// Range calls fn for each cached task until fn returns false.
+func (c *TaskCache) Range(fn func(id string, task *Task) bool) {
+	c.m.Range(fn)
+}
+
+// All returns an iterator over the cached tasks keyed by ID.
+func (c *TaskCache) All() iter.Seq2[string, *Task] {
+	return c.m.All()
+}
+
+// Keys returns an iterator over the cached task IDs.
+func (c *TaskCache) Keys() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		c.m.Range(func(id string, _ *Task) bool {
+			return yield(id)
+		})
+	}
+}
+
+// Values returns an iterator over the cached tasks.
+func (c *TaskCache) Values() iter.Seq[*Task] {
+	return func(yield func(*Task) bool) {
+		c.m.Range(func(_ string, task *Task) bool {
+			return yield(task)
+		})
+	}
+}
+
+// Len returns the number of cached tasks.
+func (c *TaskCache) Len() int {
+	return c.m.Len()
+}
+
+// StatusIndex keeps a live count of tasks per status so dashboards can
+// read totals without scanning the repository.
+type StatusIndex struct {
+	m syncmap.Map[TaskStatus, *atomic.Uint64]
+}
+
+// NewStatusIndex returns an empty index.
+func NewStatusIndex() *StatusIndex {
+	return &StatusIndex{}
+}
+
+func (i *StatusIndex) counter(status TaskStatus) *atomic.Uint64 {
+	v, ok := i.m.Load(status)
+	if !ok {
+		v, _ = i.m.LoadOrStore(status, new(atomic.Uint64))
+	}
+	return v
+}
+
+// Increment adds one to the count for status.
+func (i *StatusIndex) Increment(status TaskStatus) {
+	i.counter(status).Add(1)
+}
+
+// Decrement subtracts one from the count for status. A decrement on a
+// zero counter is a no-op rather than wrapping around, since a transient
+// drift must not turn into a 2^64-1 reading on dashboards.
+func (i *StatusIndex) Decrement(status TaskStatus) {
+	c := i.counter(status)
+	for {
+		cur := c.Load()
+		if cur == 0 {
+			return
+		}
+		if c.CompareAndSwap(cur, cur-1) {
+			return
+		}
+	}
+}
+
+// Set overwrites the count for status, typically after a Rebuild.
+func (i *StatusIndex) Set(status TaskStatus, n uint64) {
+	i.counter(status).Store(n)
+}
+
+// Count returns the current count for status.
+func (i *StatusIndex) Count(status TaskStatus) uint64 {
+	return i.counter(status).Load()
+}
+
+// StatusCounter is implemented by repositories that can count their tasks
+// per status, enabling StatusIndex.Rebuild without widening TaskRepository.
+type StatusCounter interface {
+	CountByStatus(ctx context.Context) (map[TaskStatus]uint64, error)
+}
+
+// Rebuild recomputes all counts from the repository, correcting any drift
+// accumulated by the incremental counters. Statuses absent from the
+// repository result are reset to zero.
+func (i *StatusIndex) Rebuild(ctx context.Context, repo StatusCounter) error {
+	counts, err := repo.CountByStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("taskqueue: rebuild status index: %w", err)
+	}
+	i.m.Range(func(status TaskStatus, c *atomic.Uint64) bool {
+		if _, ok := counts[status]; !ok {
+			c.Store(0)
+		}
+		return true
+	})
+	for status, n := range counts {
+		i.Set(status, n)
+	}
+	return nil
+}
+
+// Counts returns a copy of all per-status counts.
+func (i *StatusIndex) Counts() map[TaskStatus]uint64 {
+	counts := make(map[TaskStatus]uint64)
+	i.m.Range(func(status TaskStatus, c *atomic.Uint64) bool {
+		counts[status] = c.Load()
+		return true
+	})
+	return counts
+}
+
+// RouteCache maps task types to publishing topics.
+type RouteCache struct {
+	m syncmap.Map[string, string]
+}
+
+// NewRouteCache returns an empty cache.
+func NewRouteCache() *RouteCache {
+	return &RouteCache{}
+}
+
+// Set binds a task type to a topic.
+func (c *RouteCache) Set(taskType, topic string) {
+	c.m.Store(taskType, topic)
+}
+
+// Get returns the topic for a task type and whether one was set.
+func (c *RouteCache) Get(taskType string) (string, bool) {
+	return c.m.Load(taskType)
+}
+
+// Delete removes the binding for a task type.
+func (c *RouteCache) Delete(taskType string) {
+	c.m.Delete(taskType)
+}
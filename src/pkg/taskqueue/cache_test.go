@@ -0,0 +1,79 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskCacheIterators(t *testing.T) {
+	cache := NewTaskCache()
+	cache.Store(&Task{ID: "a", Type: "email"})
+	cache.Store(&Task{ID: "b", Type: "webhook"})
+
+	seen := make(map[string]string)
+	for id, task := range cache.All() {
+		seen[id] = task.Type
+	}
+	assert.Equal(t, map[string]string{"a": "email", "b": "webhook"}, seen)
+
+	var keys []string
+	for id := range cache.Keys() {
+		keys = append(keys, id)
+	}
+	assert.ElementsMatch(t, []string{"a", "b"}, keys)
+
+	count := 0
+	for range cache.Values() {
+		count++
+		break // early termination must not panic
+	}
+	assert.Equal(t, 1, count)
+}
+
+func TestWorkerRegistryAll(t *testing.T) {
+	registry := NewWorkerRegistry()
+	registry.Register(&WorkerInfo{ID: 1})
+	registry.Register(&WorkerInfo{ID: 2})
+
+	var ids []int
+	for id := range registry.All() {
+		ids = append(ids, id)
+	}
+	assert.ElementsMatch(t, []int{1, 2}, ids)
+}
+
+func TestStatusIndexUnderflowAndSet(t *testing.T) {
+	idx := NewStatusIndex()
+
+	idx.Decrement(TaskStatusPending)
+	assert.EqualValues(t, 0, idx.Count(TaskStatusPending), "decrement at zero must not wrap")
+
+	idx.Increment(TaskStatusPending)
+	idx.Increment(TaskStatusPending)
+	idx.Decrement(TaskStatusPending)
+	assert.EqualValues(t, 1, idx.Count(TaskStatusPending))
+
+	idx.Set(TaskStatusFailed, 42)
+	assert.EqualValues(t, 42, idx.Count(TaskStatusFailed))
+}
+
+type stubStatusCounter map[TaskStatus]uint64
+
+func (s stubStatusCounter) CountByStatus(context.Context) (map[TaskStatus]uint64, error) {
+	return s, nil
+}
+
+func TestStatusIndexRebuild(t *testing.T) {
+	idx := NewStatusIndex()
+	idx.Set(TaskStatusPending, 10)
+	idx.Set(TaskStatusFailed, 3)
+
+	err := idx.Rebuild(context.Background(), stubStatusCounter{TaskStatusPending: 7})
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 7, idx.Count(TaskStatusPending))
+	assert.EqualValues(t, 0, idx.Count(TaskStatusFailed), "stale statuses reset to zero")
+}
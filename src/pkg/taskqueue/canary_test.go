@@ -0,0 +1,73 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func canarySides() (stable, canary Executor) {
+	stable = ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		return &TaskResult{Output: "stable"}, nil
+	})
+	canary = ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		return nil, assert.AnError
+	})
+	return stable, canary
+}
+
+func TestCanaryExecutorRoutesByPercent(t *testing.T) {
+	stable, canary := canarySides()
+	ctx := context.Background()
+
+	// 0%: everything goes to the stable side.
+	executor := NewCanaryExecutor(stable, canary, 0)
+	for i := 0; i < 10; i++ {
+		result, err := executor.Execute(ctx, NewTask("email", nil))
+		require.NoError(t, err)
+		assert.Equal(t, "stable", result.Output)
+	}
+	stats := executor.Stats()
+	assert.EqualValues(t, 10, stats.StableRuns)
+	assert.Zero(t, stats.CanaryRuns)
+
+	// Ramping to 100% sends everything to the canary, with failures
+	// counted separately.
+	executor.SetPercent(100)
+	for i := 0; i < 10; i++ {
+		_, err := executor.Execute(ctx, NewTask("email", nil))
+		assert.Error(t, err)
+	}
+	stats = executor.Stats()
+	assert.Equal(t, 100, stats.Percent)
+	assert.EqualValues(t, 10, stats.CanaryRuns)
+	assert.EqualValues(t, 10, stats.CanaryFailures)
+	assert.EqualValues(t, 10, stats.StableRuns)
+	assert.Zero(t, stats.StableFailures)
+}
+
+func TestSetPercentClamps(t *testing.T) {
+	stable, canary := canarySides()
+	executor := NewCanaryExecutor(stable, canary, 150)
+	assert.Equal(t, 100, executor.Stats().Percent)
+	executor.SetPercent(-5)
+	assert.Equal(t, 0, executor.Stats().Percent)
+}
+
+func TestRegisterCanaryWrapsExistingExecutor(t *testing.T) {
+	stable, canary := canarySides()
+	registry := NewExecutorRegistry()
+	registry.Register("email", stable)
+
+	wrapper, err := registry.RegisterCanary("email", canary, 0)
+	require.NoError(t, err)
+
+	registered, err := registry.Get("email")
+	require.NoError(t, err)
+	assert.Same(t, wrapper, registered)
+
+	_, err = registry.RegisterCanary("unknown", canary, 10)
+	assert.ErrorIs(t, err, ErrNoExecutor)
+}
@@ -0,0 +1,60 @@
+package taskqueue
+
+import (
+	"context"
+	"fmt"
+)
+
+// EventCancelled is emitted when a task is cancelled through CancelTask.
+const EventCancelled EventKind = "cancelled"
+
+// CancelTask cancels the task with the given ID. A task currently
+// executing has its context cancelled and ends cancelled instead of
+// failed; a task still waiting in the buffer is dropped when the pool
+// reaches it. Tasks the worker no longer carries — finished or never
+// submitted here — return ErrTaskNotFound.
+func (w *Worker) CancelTask(ctx context.Context, taskID string) error {
+	if _, ok := w.cache.Load(taskID); !ok {
+		return fmt.Errorf("taskqueue: cancel task %s: %w", taskID, ErrTaskNotFound)
+	}
+	w.cancelRequests.Store(taskID, struct{}{})
+	if cancel, ok := w.cancels.Load(taskID); ok {
+		cancel()
+		return nil
+	}
+	// Still buffered: record the terminal status now so reads see it;
+	// the pool consumes the marker and drops the task at dequeue. The
+	// repository is the arbiter so a task that already reached a
+	// terminal status is never rewritten.
+	stored, err := w.repo.GetByID(ctx, taskID)
+	if err != nil {
+		w.cancelRequests.Delete(taskID)
+		return fmt.Errorf("taskqueue: cancel task %s: %w", taskID, err)
+	}
+	if !stored.CanTransition(TaskStatusCancelled) {
+		w.cancelRequests.Delete(taskID)
+		return fmt.Errorf("taskqueue: cancel task %s: %w", taskID, ErrTaskNotFound)
+	}
+	if err := w.repo.UpdateStatus(ctx, taskID, TaskStatusCancelled); err != nil {
+		return fmt.Errorf("taskqueue: cancel task %s: %w", taskID, err)
+	}
+	return nil
+}
+
+// cancelRequested reports whether CancelTask was called for the task.
+func (w *Worker) cancelRequested(task *Task) bool {
+	_, ok := w.cancelRequests.Load(task.ID)
+	return ok
+}
+
+// finishCancel moves the task to the cancelled status, drops it from the
+// hot cache and announces the cancellation.
+func (w *Worker) finishCancel(ctx context.Context, task *Task) {
+	w.cancelRequests.Delete(task.ID)
+	w.transition(ctx, task, TaskStatusCancelled)
+	w.cache.Delete(task.ID)
+	w.emitEvent(EventCancelled, task, TaskStatusCancelled, nil)
+	w.recordGroup(task, false)
+	w.recordScatter(task, nil)
+	w.log(ctx).Info("taskqueue: task cancelled", "task_id", task.ID)
+}
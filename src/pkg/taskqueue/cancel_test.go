@@ -0,0 +1,121 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelTaskUnknownIDIsNotFound(t *testing.T) {
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), nil)
+	defer w.Shutdown(context.Background())
+	assert.ErrorIs(t, w.CancelTask(context.Background(), "missing"), ErrTaskNotFound)
+}
+
+func TestCancelTaskDropsBufferedTask(t *testing.T) {
+	repo := newMockRepository()
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, repo, nil)
+	executed := make(chan struct{}, 1)
+	w.Executors().Register("email", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		executed <- struct{}{}
+		return &TaskResult{}, nil
+	}))
+
+	events, cancelEvents := w.SubscribeEvents(16)
+	defer cancelEvents()
+
+	task := NewTask("email", nil)
+	require.NoError(t, w.SubmitTask(context.Background(), task))
+	require.NoError(t, w.CancelTask(context.Background(), task.ID))
+
+	stored, err := repo.GetByID(context.Background(), task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, TaskStatusCancelled, stored.Status, "the status is terminal before the pool reaches the task")
+
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case event := <-events:
+			if event.Kind == EventCancelled {
+				assert.Equal(t, task.ID, event.TaskID)
+				assert.Equal(t, TaskStatusCancelled, event.Status)
+				select {
+				case <-executed:
+					t.Fatal("cancelled task was executed")
+				default:
+				}
+				return
+			}
+		case <-deadline:
+			t.Fatal("no cancellation event")
+		}
+	}
+}
+
+func TestCancelTaskAbortsInFlightExecution(t *testing.T) {
+	repo := newMockRepository()
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, repo, nil)
+	started := make(chan string, 1)
+	w.Executors().Register("slow", ExecutorFunc(func(ctx context.Context, task *Task) (*TaskResult, error) {
+		started <- task.ID
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}))
+
+	events, cancelEvents := w.SubscribeEvents(16)
+	defer cancelEvents()
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	require.NoError(t, w.SubmitTask(context.Background(), NewTask("slow", nil)))
+
+	var taskID string
+	select {
+	case taskID = <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("task never started")
+	}
+	require.NoError(t, w.CancelTask(context.Background(), taskID))
+
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case event := <-events:
+			if event.Kind == EventCancelled {
+				assert.Equal(t, taskID, event.TaskID)
+				stored, err := repo.GetByID(context.Background(), taskID)
+				require.NoError(t, err)
+				assert.Equal(t, TaskStatusCancelled, stored.Status)
+				assert.Zero(t, w.Stats().Failed, "a cancelled task does not count as failed")
+				return
+			}
+		case <-deadline:
+			t.Fatal("no cancellation event")
+		}
+	}
+}
+
+func TestCancelTaskAfterCompletionIsNotFound(t *testing.T) {
+	repo := newMockRepository()
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, repo, nil)
+	w.Executors().Register("email", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		return &TaskResult{}, nil
+	}))
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	task := NewTask("email", nil)
+	require.NoError(t, w.SubmitTask(context.Background(), task))
+	require.Eventually(t, func() bool { return w.Stats().Completed == 1 }, 2*time.Second, 5*time.Millisecond)
+
+	assert.ErrorIs(t, w.CancelTask(context.Background(), task.ID), ErrTaskNotFound)
+	stored, err := repo.GetByID(context.Background(), task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, TaskStatusCompleted, stored.Status, "a finished task keeps its terminal status")
+}
@@ -0,0 +1,131 @@
+package taskqueue
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Chaos injects random delays and failures into repository and publisher
+// calls. It exists for tests: wrapping the worker's dependencies with
+// ChaosRepository and ChaosPublisher perturbs the timing of every
+// channel handoff downstream of them, which is how the shutdown stress
+// tests prove the drain and flush guarantees hold under adverse
+// schedules. It is safe for concurrent use.
+type Chaos struct {
+	// FailureRate is the probability in [0, 1] that a call fails with
+	// ErrChaosInjected instead of reaching the real dependency.
+	FailureRate float64
+
+	// MaxDelay is the upper bound of the random delay added before each
+	// call. Zero adds no delay.
+	MaxDelay time.Duration
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewChaos builds an injector with a deterministic seed so failing runs
+// can be replayed.
+func NewChaos(seed uint64, failureRate float64, maxDelay time.Duration) *Chaos {
+	return &Chaos{
+		FailureRate: failureRate,
+		MaxDelay:    maxDelay,
+		rng:         rand.New(rand.NewPCG(seed, 0)),
+	}
+}
+
+// strike sleeps a random slice of MaxDelay and reports whether the call
+// should fail, honoring context cancellation during the delay.
+func (c *Chaos) strike(ctx context.Context) error {
+	c.mu.Lock()
+	var delay time.Duration
+	if c.MaxDelay > 0 {
+		delay = time.Duration(c.rng.Int64N(int64(c.MaxDelay)))
+	}
+	fail := c.rng.Float64() < c.FailureRate
+	c.mu.Unlock()
+
+	if delay > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+	if fail {
+		return ErrChaosInjected
+	}
+	return nil
+}
+
+// ChaosRepository decorates a TaskRepository with chaos injection on
+// every call.
+type ChaosRepository struct {
+	next  TaskRepository
+	chaos *Chaos
+}
+
+// NewChaosRepository wraps next with the injector.
+func NewChaosRepository(next TaskRepository, chaos *Chaos) *ChaosRepository {
+	return &ChaosRepository{next: next, chaos: chaos}
+}
+
+// Unwrap returns the decorated repository so optional capabilities
+// remain reachable via repositoryAs.
+func (r *ChaosRepository) Unwrap() TaskRepository {
+	return r.next
+}
+
+// Save implements TaskRepository.
+func (r *ChaosRepository) Save(ctx context.Context, task *Task) error {
+	if err := r.chaos.strike(ctx); err != nil {
+		return err
+	}
+	return r.next.Save(ctx, task)
+}
+
+// GetByID implements TaskRepository.
+func (r *ChaosRepository) GetByID(ctx context.Context, id string) (*Task, error) {
+	if err := r.chaos.strike(ctx); err != nil {
+		return nil, err
+	}
+	return r.next.GetByID(ctx, id)
+}
+
+// UpdateStatus implements TaskRepository.
+func (r *ChaosRepository) UpdateStatus(ctx context.Context, id string, status TaskStatus) error {
+	if err := r.chaos.strike(ctx); err != nil {
+		return err
+	}
+	return r.next.UpdateStatus(ctx, id, status)
+}
+
+// ListPending implements TaskRepository.
+func (r *ChaosRepository) ListPending(ctx context.Context, limit int) ([]*Task, error) {
+	if err := r.chaos.strike(ctx); err != nil {
+		return nil, err
+	}
+	return r.next.ListPending(ctx, limit)
+}
+
+// ChaosPublisher decorates a Publisher with chaos injection on every
+// publish.
+type ChaosPublisher struct {
+	next  Publisher
+	chaos *Chaos
+}
+
+// NewChaosPublisher wraps next with the injector.
+func NewChaosPublisher(next Publisher, chaos *Chaos) *ChaosPublisher {
+	return &ChaosPublisher{next: next, chaos: chaos}
+}
+
+// Publish implements Publisher.
+func (p *ChaosPublisher) Publish(ctx context.Context, topic string, payload []byte) error {
+	if err := p.chaos.strike(ctx); err != nil {
+		return err
+	}
+	return p.next.Publish(ctx, topic, payload)
+}
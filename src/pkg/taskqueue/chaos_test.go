@@ -0,0 +1,75 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChaosStrikeFailsAtConfiguredRate(t *testing.T) {
+	chaos := NewChaos(1, 1, 0)
+	assert.ErrorIs(t, chaos.strike(context.Background()), ErrChaosInjected)
+
+	chaos = NewChaos(1, 0, 0)
+	assert.NoError(t, chaos.strike(context.Background()))
+}
+
+func TestChaosStrikeHonorsCancellation(t *testing.T) {
+	chaos := NewChaos(1, 0, time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, chaos.strike(ctx), context.Canceled)
+}
+
+func TestChaosDecoratorsPassThrough(t *testing.T) {
+	chaos := NewChaos(1, 0, 0)
+	repo := NewChaosRepository(newMockRepository(), chaos)
+	pub := NewChaosPublisher(newMockPublisher(), chaos)
+
+	ctx := context.Background()
+	require.NoError(t, repo.Save(ctx, &Task{ID: "t1", Type: "email", Status: TaskStatusPending}))
+	require.NoError(t, pub.Publish(ctx, "topic", []byte("payload")))
+
+	// Optional capabilities survive the wrapping.
+	_, ok := repositoryAs[TaskLister](TaskRepository(repo))
+	assert.True(t, ok)
+}
+
+// TestShutdownUnderChaos is the shutdown stress target: a worker whose
+// repository and publisher randomly stall and fail must still drain and
+// stop within its deadline, with every submitted task accounted for.
+func TestShutdownUnderChaos(t *testing.T) {
+	if testing.Short() {
+		t.Skip("chaos stress test skipped in short mode")
+	}
+
+	chaos := NewChaos(42, 0.2, 2*time.Millisecond)
+	repo := newMockRepository()
+	w := NewWorker(WorkerConfig{WorkerCount: 4, BufferSize: 256},
+		NewChaosRepository(repo, chaos), NewChaosPublisher(newMockPublisher(), chaos))
+	w.Executors().Register("email", ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		return &TaskResult{Output: "sent"}, nil
+	}))
+	w.Start()
+
+	submitted := 0
+	for range 200 {
+		if err := w.SubmitTask(context.Background(), &Task{Type: "email"}); err == nil {
+			submitted++
+		} else {
+			assert.ErrorIs(t, err, ErrChaosInjected)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, w.Shutdown(ctx))
+
+	stats := w.Stats()
+	assert.EqualValues(t, submitted, stats.Submitted)
+	assert.EqualValues(t, submitted, stats.Completed+stats.Failed,
+		"every submitted task must be processed before shutdown returns")
+}
@@ -0,0 +1,160 @@
+package taskqueue
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Codec serializes tasks and results for transport and storage. The
+// worker publishes result messages through its configured codec, and
+// repository adapters that persist to external stores are expected to
+// run payloads through the same codec, so a deployment switches formats
+// in one place. JSON is the default; the gob, protobuf and msgpack
+// codecs shipped alongside it suit deployments wanting compact binary
+// payloads. Further implementations register via RegisterCodec.
+type Codec interface {
+	// Name identifies the codec in configuration and registry lookups.
+	Name() string
+
+	EncodeTask(task *Task) ([]byte, error)
+	DecodeTask(data []byte) (*Task, error)
+	EncodeResult(result *TaskResult) ([]byte, error)
+	DecodeResult(data []byte) (*TaskResult, error)
+}
+
+// codecMu guards the codec registry, so codec modules may register from
+// init while workers are starting.
+var codecMu sync.RWMutex
+
+// codecs tracks the codecs added via RegisterCodec, keyed by name.
+var codecs = map[string]Codec{
+	"json":     JSONCodec{},
+	"gob":      GobCodec{},
+	"protobuf": ProtobufCodec{},
+	"msgpack":  MsgpackCodec{},
+}
+
+// RegisterCodec adds a codec to the process-wide registry under its
+// name, replacing any previous registration.
+func RegisterCodec(codec Codec) {
+	codecMu.Lock()
+	defer codecMu.Unlock()
+	codecs[codec.Name()] = codec
+}
+
+// CodecByName returns the registered codec, or an error naming the
+// unknown codec so configuration typos surface at startup.
+func CodecByName(name string) (Codec, error) {
+	codecMu.RLock()
+	defer codecMu.RUnlock()
+	codec, ok := codecs[name]
+	if !ok {
+		return nil, fmt.Errorf("taskqueue: unknown codec %q", name)
+	}
+	return codec, nil
+}
+
+// JSONCodec is the default text codec. Tasks decode through DecodeTask,
+// so documents from untrusted producers get the same validation as the
+// network API.
+type JSONCodec struct{}
+
+// Name implements Codec.
+func (JSONCodec) Name() string { return "json" }
+
+// EncodeTask implements Codec using the pooled TaskEncoder path.
+func (JSONCodec) EncodeTask(task *Task) ([]byte, error) {
+	return NewTaskEncoder().Encode(task)
+}
+
+// DecodeTask implements Codec.
+func (JSONCodec) DecodeTask(data []byte) (*Task, error) {
+	return DecodeTask(data)
+}
+
+// EncodeResult implements Codec with every result field included.
+func (JSONCodec) EncodeResult(result *TaskResult) ([]byte, error) {
+	return DefaultResultEncoder().Encode(result)
+}
+
+// DecodeResult implements Codec.
+func (JSONCodec) DecodeResult(data []byte) (*TaskResult, error) {
+	var result TaskResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("taskqueue: decode result: %w", err)
+	}
+	return &result, nil
+}
+
+// GobCodec is the compact binary codec shipped with the core, for
+// deployments that want smaller payloads without pulling in a schema
+// compiler. Both ends must run this package, which queue fleets do by
+// construction.
+type GobCodec struct{}
+
+// gobTypes registers the concrete types task data carries after JSON
+// ingestion, since gob cannot encode interface values it has not seen.
+var gobTypes = sync.OnceFunc(func() {
+	gob.Register(map[string]any{})
+	gob.Register([]any{})
+	gob.Register(json.Number(""))
+})
+
+// Name implements Codec.
+func (GobCodec) Name() string { return "gob" }
+
+// EncodeTask implements Codec.
+func (GobCodec) EncodeTask(task *Task) ([]byte, error) {
+	if task == nil {
+		return nil, ErrNilTask
+	}
+	return gobEncode(task)
+}
+
+// DecodeTask implements Codec.
+func (GobCodec) DecodeTask(data []byte) (*Task, error) {
+	var task Task
+	if err := gobDecode(data, &task); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTask, err)
+	}
+	return &task, nil
+}
+
+// EncodeResult implements Codec.
+func (GobCodec) EncodeResult(result *TaskResult) ([]byte, error) {
+	return gobEncode(result)
+}
+
+// DecodeResult implements Codec.
+func (GobCodec) DecodeResult(data []byte) (*TaskResult, error) {
+	var result TaskResult
+	if err := gobDecode(data, &result); err != nil {
+		return nil, fmt.Errorf("taskqueue: decode result: %w", err)
+	}
+	return &result, nil
+}
+
+// gobEncode serializes the value in a pooled buffer, returning a copy
+// that outlives the buffer's recycling.
+func gobEncode(value any) ([]byte, error) {
+	gobTypes()
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer bufferPool.Put(buf)
+
+	if err := gob.NewEncoder(buf).Encode(value); err != nil {
+		return nil, fmt.Errorf("taskqueue: gob encode: %w", err)
+	}
+	out := make([]byte, buf.Len())
+	copy(out, buf.Bytes())
+	return out, nil
+}
+
+// gobDecode deserializes the value.
+func gobDecode(data []byte, value any) error {
+	gobTypes()
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(value)
+}
@@ -0,0 +1,130 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodecRoundTrips(t *testing.T) {
+	task := NewTask("email", map[string]any{"to": "ops@example.com"})
+	task.MaxRetries = 3
+	result := &TaskResult{
+		TaskID:      task.ID,
+		TaskType:    "email",
+		Success:     true,
+		Output:      "sent",
+		Duration:    time.Second,
+		CompletedAt: time.Date(2026, 8, 28, 12, 0, 0, 0, time.UTC),
+	}
+
+	for _, name := range []string{"json", "gob", "protobuf", "msgpack"} {
+		t.Run(name, func(t *testing.T) {
+			codec, err := CodecByName(name)
+			require.NoError(t, err)
+			assert.Equal(t, name, codec.Name())
+
+			data, err := codec.EncodeTask(task)
+			require.NoError(t, err)
+			decodedTask, err := codec.DecodeTask(data)
+			require.NoError(t, err)
+			assert.Equal(t, task.ID, decodedTask.ID)
+			assert.Equal(t, "ops@example.com", decodedTask.Data["to"])
+			assert.Equal(t, 3, decodedTask.MaxRetries)
+
+			data, err = codec.EncodeResult(result)
+			require.NoError(t, err)
+			decodedResult, err := codec.DecodeResult(data)
+			require.NoError(t, err)
+			assert.Equal(t, result, decodedResult)
+		})
+	}
+}
+
+func TestProtobufCodecPreservesTimeoutAndDeadline(t *testing.T) {
+	task := NewTask("email", nil)
+	task.Timeout = 30 * time.Second
+	task.Deadline = time.Date(2026, 9, 1, 8, 0, 0, 0, time.UTC)
+
+	data, err := ProtobufCodec{}.EncodeTask(task)
+	require.NoError(t, err)
+	decoded, err := ProtobufCodec{}.DecodeTask(data)
+	require.NoError(t, err)
+	assert.Equal(t, task.Timeout, decoded.Timeout)
+	assert.True(t, task.Deadline.Equal(decoded.Deadline))
+}
+
+func TestMsgpackCodecNestedData(t *testing.T) {
+	task := NewTask("report", map[string]any{
+		"title":    "weekly",
+		"pages":    int64(12),
+		"ratio":    0.75,
+		"sections": []any{"intro", "body"},
+		"owner":    map[string]any{"team": "ops", "oncall": true},
+		"draft":    nil,
+	})
+
+	data, err := MsgpackCodec{}.EncodeTask(task)
+	require.NoError(t, err)
+	decoded, err := MsgpackCodec{}.DecodeTask(data)
+	require.NoError(t, err)
+	assert.Equal(t, task.Data, decoded.Data)
+
+	_, err = MsgpackCodec{}.DecodeTask([]byte{0x81, 0xff})
+	assert.ErrorIs(t, err, ErrInvalidTask)
+}
+
+func TestCodecByNameRejectsUnknown(t *testing.T) {
+	_, err := CodecByName("avro")
+	assert.ErrorContains(t, err, `unknown codec "avro"`)
+}
+
+func TestRegisterCodecOverrides(t *testing.T) {
+	RegisterCodec(namedCodec{JSONCodec{}, "custom"})
+	t.Cleanup(func() {
+		codecMu.Lock()
+		delete(codecs, "custom")
+		codecMu.Unlock()
+	})
+
+	codec, err := CodecByName("custom")
+	require.NoError(t, err)
+	assert.Equal(t, "custom", codec.Name())
+}
+
+// namedCodec wraps a codec under another registry name.
+type namedCodec struct {
+	Codec
+	name string
+}
+
+func (c namedCodec) Name() string { return c.name }
+
+func TestWorkerPublishesThroughCodec(t *testing.T) {
+	codec := GobCodec{}
+	publisher := newMockPublisher()
+	w := NewWorker(WorkerConfig{WorkerCount: 1, Codec: codec}, newMockRepository(), publisher)
+	w.Executors().Register("email", ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		return &TaskResult{Output: "sent"}, nil
+	}))
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	task := NewTask("email", nil)
+	require.NoError(t, w.SubmitTask(context.Background(), task))
+	require.Eventually(t, func() bool {
+		return publishedOn(publisher, ResultTopic("")) == 1
+	}, 2*time.Second, 5*time.Millisecond)
+
+	publisher.mu.Lock()
+	message := publisher.messages[ResultTopic("")][0]
+	publisher.mu.Unlock()
+	decoded, err := codec.DecodeResult(message)
+	require.NoError(t, err)
+	assert.Equal(t, task.ID, decoded.TaskID)
+	assert.Equal(t, "sent", decoded.Output)
+	assert.True(t, decoded.Success)
+}
@@ -0,0 +1,43 @@
+// Package compat re-exports the stable taskqueue API surface under a
+// single import path. Plugin authors who build against this package are
+// covered by the compatibility guarantees documented in the core package:
+// nothing here is ever removed or changed incompatibly within a major
+// version.
+package compat
+
+import "github.com/kodflow/taskqueue/pkg/taskqueue"
+
+// Core entities.
+type (
+	Task              = taskqueue.Task
+	TaskStatus        = taskqueue.TaskStatus
+	TaskResult        = taskqueue.TaskResult
+	CreateTaskRequest = taskqueue.CreateTaskRequest
+)
+
+// Stable contracts implemented by adapters and plugins.
+type (
+	TaskRepository = taskqueue.TaskRepository
+	Publisher      = taskqueue.Publisher
+	Executor       = taskqueue.Executor
+	ExecutorFunc   = taskqueue.ExecutorFunc
+)
+
+// Task lifecycle states.
+const (
+	TaskStatusPending    = taskqueue.TaskStatusPending
+	TaskStatusProcessing = taskqueue.TaskStatusProcessing
+	TaskStatusCompleted  = taskqueue.TaskStatusCompleted
+	TaskStatusFailed     = taskqueue.TaskStatusFailed
+)
+
+// Sentinel errors, matched with errors.Is.
+var (
+	ErrNilTask       = taskqueue.ErrNilTask
+	ErrInvalidTask   = taskqueue.ErrInvalidTask
+	ErrInvalidStatus = taskqueue.ErrInvalidStatus
+	ErrQueueFull     = taskqueue.ErrQueueFull
+	ErrWorkerStopped = taskqueue.ErrWorkerStopped
+	ErrTaskNotFound  = taskqueue.ErrTaskNotFound
+	ErrNoExecutor    = taskqueue.ErrNoExecutor
+)
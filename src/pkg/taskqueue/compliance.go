@@ -0,0 +1,9 @@
+package taskqueue
+
+// Compile-time interface compliance checks for the types exported by the
+// core package. New implementations should add themselves here (or in
+// their own package) so a contract break fails the build instead of a
+// downstream consumer.
+var (
+	_ Executor = ExecutorFunc(nil)
+)
@@ -0,0 +1,93 @@
+package taskqueue
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// payloadPool recycles the scratch buffers of the publishing hot path,
+// mirroring what builderPool does for the task encoder.
+var payloadPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// DefaultCompressMinSize is the payload size below which compression is
+// skipped; tiny messages grow rather than shrink under gzip.
+const DefaultCompressMinSize = 256
+
+// CompressingPublisher wraps a Publisher, gzip-compressing payloads at
+// or above a size threshold with pooled writers and buffers so the
+// publishing hot path stays allocation-light. Consumers distinguish
+// compressed messages by the gzip magic bytes; DecompressPayload handles
+// both forms.
+type CompressingPublisher struct {
+	next    Publisher
+	minSize int
+	writers sync.Pool
+}
+
+// NewCompressingPublisher wraps next, compressing payloads of at least
+// minSize bytes. A non-positive minSize uses DefaultCompressMinSize.
+func NewCompressingPublisher(next Publisher, minSize int) *CompressingPublisher {
+	if minSize <= 0 {
+		minSize = DefaultCompressMinSize
+	}
+	return &CompressingPublisher{
+		next:    next,
+		minSize: minSize,
+		writers: sync.Pool{
+			New: func() any { return gzip.NewWriter(io.Discard) },
+		},
+	}
+}
+
+// Publish implements Publisher, compressing the payload when it is large
+// enough to benefit.
+func (p *CompressingPublisher) Publish(ctx context.Context, topic string, payload []byte) error {
+	if len(payload) < p.minSize {
+		return p.next.Publish(ctx, topic, payload)
+	}
+
+	buf := payloadPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer payloadPool.Put(buf)
+
+	zw := p.writers.Get().(*gzip.Writer)
+	zw.Reset(buf)
+	if _, err := zw.Write(payload); err != nil {
+		p.writers.Put(zw)
+		return fmt.Errorf("taskqueue: compress payload: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		p.writers.Put(zw)
+		return fmt.Errorf("taskqueue: compress payload: %w", err)
+	}
+	p.writers.Put(zw)
+
+	compressed := make([]byte, buf.Len())
+	copy(compressed, buf.Bytes())
+	return p.next.Publish(ctx, topic, compressed)
+}
+
+// DecompressPayload returns the payload decompressed when it carries the
+// gzip magic bytes, and unchanged otherwise, so subscribers can consume
+// mixed streams.
+func DecompressPayload(payload []byte) ([]byte, error) {
+	if len(payload) < 2 || payload[0] != 0x1f || payload[1] != 0x8b {
+		return payload, nil
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("taskqueue: decompress payload: %w", err)
+	}
+	defer zr.Close()
+	decompressed, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("taskqueue: decompress payload: %w", err)
+	}
+	return decompressed, nil
+}
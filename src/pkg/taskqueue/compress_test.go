@@ -0,0 +1,44 @@
+package taskqueue
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressingPublisherRoundTripsLargePayloads(t *testing.T) {
+	sink := newMockPublisher()
+	publisher := NewCompressingPublisher(sink, 16)
+
+	payload := bytes.Repeat([]byte("result data "), 100)
+	require.NoError(t, publisher.Publish(context.Background(), "task.results", payload))
+
+	published := sink.messages["task.results"][0]
+	assert.Less(t, len(published), len(payload), "payload should shrink")
+	assert.Equal(t, []byte{0x1f, 0x8b}, published[:2], "gzip magic bytes")
+
+	decompressed, err := DecompressPayload(published)
+	require.NoError(t, err)
+	assert.Equal(t, payload, decompressed)
+}
+
+func TestCompressingPublisherSkipsSmallPayloads(t *testing.T) {
+	sink := newMockPublisher()
+	publisher := NewCompressingPublisher(sink, 0)
+
+	payload := []byte(`{"task_id":"t1"}`)
+	require.NoError(t, publisher.Publish(context.Background(), "task.results", payload))
+	assert.Equal(t, payload, sink.messages["task.results"][0])
+
+	passthrough, err := DecompressPayload(payload)
+	require.NoError(t, err)
+	assert.Equal(t, payload, passthrough)
+}
+
+func TestDecompressPayloadRejectsCorruptGzip(t *testing.T) {
+	_, err := DecompressPayload([]byte{0x1f, 0x8b, 0x00})
+	assert.Error(t, err)
+}
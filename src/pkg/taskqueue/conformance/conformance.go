@@ -0,0 +1,228 @@
+// Package conformance ships reusable contract test suites for the core
+// interfaces. Adapter authors run them against their own repository and
+// publisher implementations, so contract semantics — not-found errors,
+// status transitions, pending limits, message isolation — are verified
+// the same way the in-tree adapters are, instead of each adapter
+// re-deriving the rules from doc comments. It also exports maintained
+// mock implementations for tests that just need a working dependency.
+package conformance
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue"
+)
+
+// RepositoryFactory builds a fresh, empty repository for one subtest.
+type RepositoryFactory func(t *testing.T) taskqueue.TaskRepository
+
+// PublisherFactory builds a fresh publisher for one subtest, along with
+// a function returning the messages published to a topic so far.
+type PublisherFactory func(t *testing.T) (taskqueue.Publisher, func(topic string) [][]byte)
+
+// RunRepositoryTests verifies a TaskRepository implementation against
+// the contract documented on the interface.
+func RunRepositoryTests(t *testing.T, factory RepositoryFactory) {
+	t.Run("SaveAndGetByID", func(t *testing.T) {
+		repo := factory(t)
+		task := taskqueue.NewTask("email", map[string]any{"to": "ops@example.com"})
+		require.NoError(t, repo.Save(context.Background(), task))
+
+		got, err := repo.GetByID(context.Background(), task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, task.ID, got.ID)
+		assert.Equal(t, "email", got.Type)
+	})
+
+	t.Run("SaveOverwritesByID", func(t *testing.T) {
+		repo := factory(t)
+		task := taskqueue.NewTask("email", nil)
+		require.NoError(t, repo.Save(context.Background(), task))
+		task.Priority = 7
+		require.NoError(t, repo.Save(context.Background(), task))
+
+		got, err := repo.GetByID(context.Background(), task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 7, got.Priority)
+	})
+
+	t.Run("GetByIDUnknownIsNotFound", func(t *testing.T) {
+		repo := factory(t)
+		_, err := repo.GetByID(context.Background(), "missing")
+		assert.ErrorIs(t, err, taskqueue.ErrTaskNotFound)
+	})
+
+	t.Run("UpdateStatus", func(t *testing.T) {
+		repo := factory(t)
+		task := taskqueue.NewTask("email", nil)
+		require.NoError(t, repo.Save(context.Background(), task))
+		require.NoError(t, repo.UpdateStatus(context.Background(), task.ID, taskqueue.TaskStatusProcessing))
+
+		got, err := repo.GetByID(context.Background(), task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, taskqueue.TaskStatusProcessing, got.Status)
+	})
+
+	t.Run("UpdateStatusUnknownIsNotFound", func(t *testing.T) {
+		repo := factory(t)
+		err := repo.UpdateStatus(context.Background(), "missing", taskqueue.TaskStatusProcessing)
+		assert.ErrorIs(t, err, taskqueue.ErrTaskNotFound)
+	})
+
+	t.Run("ListPendingHonoursLimit", func(t *testing.T) {
+		repo := factory(t)
+		for i := 0; i < 3; i++ {
+			require.NoError(t, repo.Save(context.Background(), taskqueue.NewTask("email", nil)))
+		}
+		done := taskqueue.NewTask("email", nil)
+		require.NoError(t, repo.Save(context.Background(), done))
+		require.NoError(t, repo.UpdateStatus(context.Background(), done.ID, taskqueue.TaskStatusProcessing))
+		require.NoError(t, repo.UpdateStatus(context.Background(), done.ID, taskqueue.TaskStatusCompleted))
+
+		pending, err := repo.ListPending(context.Background(), 2)
+		require.NoError(t, err)
+		assert.Len(t, pending, 2)
+
+		pending, err = repo.ListPending(context.Background(), 10)
+		require.NoError(t, err)
+		assert.Len(t, pending, 3, "completed tasks are not pending")
+		for _, task := range pending {
+			assert.Equal(t, taskqueue.TaskStatusPending, task.Status)
+		}
+	})
+}
+
+// RunPublisherTests verifies a Publisher implementation against the
+// contract documented on the interface.
+func RunPublisherTests(t *testing.T, factory PublisherFactory) {
+	t.Run("DeliversToTopic", func(t *testing.T) {
+		publisher, messagesOn := factory(t)
+		require.NoError(t, publisher.Publish(context.Background(), "results", []byte(`{"ok":true}`)))
+
+		messages := messagesOn("results")
+		require.Len(t, messages, 1)
+		assert.Equal(t, `{"ok":true}`, string(messages[0]))
+	})
+
+	t.Run("TopicsAreIsolated", func(t *testing.T) {
+		publisher, messagesOn := factory(t)
+		require.NoError(t, publisher.Publish(context.Background(), "a", []byte("1")))
+		require.NoError(t, publisher.Publish(context.Background(), "b", []byte("2")))
+
+		assert.Len(t, messagesOn("a"), 1)
+		assert.Len(t, messagesOn("b"), 1)
+		assert.Empty(t, messagesOn("c"))
+	})
+
+	t.Run("PreservesOrderPerTopic", func(t *testing.T) {
+		publisher, messagesOn := factory(t)
+		for _, payload := range []string{"1", "2", "3"} {
+			require.NoError(t, publisher.Publish(context.Background(), "ordered", []byte(payload)))
+		}
+
+		messages := messagesOn("ordered")
+		require.Len(t, messages, 3)
+		for i, payload := range []string{"1", "2", "3"} {
+			assert.Equal(t, payload, string(messages[i]))
+		}
+	})
+}
+
+// MockRepository is an exported in-memory TaskRepository for adapter and
+// service tests. It passes RunRepositoryTests and is safe for
+// concurrent use.
+type MockRepository struct {
+	mu    sync.Mutex
+	tasks map[string]*taskqueue.Task
+}
+
+// NewMockRepository returns an empty mock repository.
+func NewMockRepository() *MockRepository {
+	return &MockRepository{tasks: make(map[string]*taskqueue.Task)}
+}
+
+// Save implements TaskRepository.
+func (r *MockRepository) Save(_ context.Context, task *taskqueue.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clone := *task
+	r.tasks[task.ID] = &clone
+	return nil
+}
+
+// GetByID implements TaskRepository.
+func (r *MockRepository) GetByID(_ context.Context, id string) (*taskqueue.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	task, ok := r.tasks[id]
+	if !ok {
+		return nil, taskqueue.ErrTaskNotFound
+	}
+	clone := *task
+	return &clone, nil
+}
+
+// UpdateStatus implements TaskRepository.
+func (r *MockRepository) UpdateStatus(_ context.Context, id string, status taskqueue.TaskStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	task, ok := r.tasks[id]
+	if !ok {
+		return taskqueue.ErrTaskNotFound
+	}
+	task.Status = status
+	return nil
+}
+
+// ListPending implements TaskRepository.
+func (r *MockRepository) ListPending(_ context.Context, limit int) ([]*taskqueue.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var pending []*taskqueue.Task
+	for _, task := range r.tasks {
+		if task.Status != taskqueue.TaskStatusPending {
+			continue
+		}
+		if limit > 0 && len(pending) >= limit {
+			break
+		}
+		clone := *task
+		pending = append(pending, &clone)
+	}
+	return pending, nil
+}
+
+// MockPublisher is an exported in-memory Publisher for adapter and
+// service tests. It passes RunPublisherTests and is safe for concurrent
+// use.
+type MockPublisher struct {
+	mu       sync.Mutex
+	messages map[string][][]byte
+}
+
+// NewMockPublisher returns an empty mock publisher.
+func NewMockPublisher() *MockPublisher {
+	return &MockPublisher{messages: make(map[string][][]byte)}
+}
+
+// Publish implements Publisher.
+func (p *MockPublisher) Publish(_ context.Context, topic string, message []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	copied := make([]byte, len(message))
+	copy(copied, message)
+	p.messages[topic] = append(p.messages[topic], copied)
+	return nil
+}
+
+// Messages returns a copy of everything published to the topic so far.
+func (p *MockPublisher) Messages(topic string) [][]byte {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([][]byte(nil), p.messages[topic]...)
+}
@@ -0,0 +1,27 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue"
+	"github.com/kodflow/taskqueue/pkg/taskqueue/adapters"
+)
+
+func TestMockRepositoryConforms(t *testing.T) {
+	RunRepositoryTests(t, func(*testing.T) taskqueue.TaskRepository {
+		return NewMockRepository()
+	})
+}
+
+func TestMemoryRepositoryConforms(t *testing.T) {
+	RunRepositoryTests(t, func(*testing.T) taskqueue.TaskRepository {
+		return adapters.NewMemoryRepository()
+	})
+}
+
+func TestMockPublisherConforms(t *testing.T) {
+	RunPublisherTests(t, func(*testing.T) (taskqueue.Publisher, func(string) [][]byte) {
+		publisher := NewMockPublisher()
+		return publisher, publisher.Messages
+	})
+}
@@ -0,0 +1,196 @@
+package taskqueue
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ControlTopic is the topic worker fleets receive control commands on.
+const ControlTopic = "task.control"
+
+// DefaultCommandMaxAge is how old a control command may be before it is
+// rejected as stale, bounding the replay window.
+const DefaultCommandMaxAge = time.Minute
+
+// Control command names understood by RunControl.
+const (
+	CommandPause           = "pause"
+	CommandResume          = "resume"
+	CommandResize          = "resize"
+	CommandInvalidateCache = "invalidate-cache"
+	CommandReloadPolicies  = "reload-policies"
+)
+
+// Command is one signed control-plane instruction, broadcast to a fleet
+// over the ControlTopic so instances can be managed without direct HTTP
+// access to each of them.
+type Command struct {
+	// Name is one of the Command constants.
+	Name string `json:"name"`
+
+	// Payload carries the command's arguments, JSON-encoded.
+	Payload json.RawMessage `json:"payload,omitempty"`
+
+	// IssuedAt bounds the replay window together with
+	// ControlConfig.MaxAge.
+	IssuedAt time.Time `json:"issued_at"`
+
+	// Signature is the hex HMAC-SHA256 over the other fields.
+	Signature string `json:"signature,omitempty"`
+}
+
+// ControlConfig tunes command verification in RunControl.
+type ControlConfig struct {
+	// Key is the shared HMAC key commands must be signed with.
+	Key []byte
+
+	// MaxAge rejects commands whose IssuedAt is further than this from
+	// now. Defaults to DefaultCommandMaxAge.
+	MaxAge time.Duration
+}
+
+// commandSigningPayload returns the canonical bytes covered by a command
+// signature: every field except the signature itself.
+func commandSigningPayload(cmd *Command) ([]byte, error) {
+	payload, err := json.Marshal(struct {
+		Name     string          `json:"name"`
+		Payload  json.RawMessage `json:"payload,omitempty"`
+		IssuedAt time.Time       `json:"issued_at"`
+	}{
+		Name:     cmd.Name,
+		Payload:  cmd.Payload,
+		IssuedAt: cmd.IssuedAt.UTC(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("taskqueue: signing payload for command %s: %w", cmd.Name, err)
+	}
+	return payload, nil
+}
+
+// SignCommand computes the command's HMAC-SHA256 signature with the
+// shared key and stamps it on the command.
+func SignCommand(cmd *Command, key []byte) error {
+	payload, err := commandSigningPayload(cmd)
+	if err != nil {
+		return err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	cmd.Signature = hex.EncodeToString(mac.Sum(nil))
+	return nil
+}
+
+// verifyCommand checks the command signature against the shared key.
+func verifyCommand(cmd *Command, key []byte) error {
+	signature, err := hex.DecodeString(cmd.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: %s: malformed signature", ErrInvalidCommand, cmd.Name)
+	}
+	payload, err := commandSigningPayload(cmd)
+	if err != nil {
+		return err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return fmt.Errorf("%w: %s: signature mismatch", ErrInvalidCommand, cmd.Name)
+	}
+	return nil
+}
+
+// RunControl consumes signed fleet commands until ctx is cancelled, the
+// worker stops or the channel closes. The channel typically bridges a
+// broker subscription to the ControlTopic; run it alongside the worker
+// like the memory governor. Commands that fail verification or are stale
+// are logged and dropped, never applied.
+func (w *Worker) RunControl(ctx context.Context, config ControlConfig, commands <-chan []byte) error {
+	if len(config.Key) == 0 {
+		return fmt.Errorf("%w: control key is required", ErrInvalidCommand)
+	}
+	if config.MaxAge <= 0 {
+		config.MaxAge = DefaultCommandMaxAge
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-w.stopCh:
+			return nil
+		case raw, ok := <-commands:
+			if !ok {
+				return nil
+			}
+			if err := w.applyCommand(raw, config); err != nil {
+				w.logger.Error("taskqueue: control command rejected", "error", err)
+			}
+		}
+	}
+}
+
+// applyCommand verifies one raw command and dispatches it.
+func (w *Worker) applyCommand(raw []byte, config ControlConfig) error {
+	var cmd Command
+	if err := json.Unmarshal(raw, &cmd); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCommand, err)
+	}
+	if err := verifyCommand(&cmd, config.Key); err != nil {
+		return err
+	}
+	if age := time.Since(cmd.IssuedAt); age > config.MaxAge || age < -config.MaxAge {
+		return fmt.Errorf("%w: %s issued at %s is outside the accepted window", ErrInvalidCommand, cmd.Name, cmd.IssuedAt)
+	}
+
+	switch cmd.Name {
+	case CommandPause:
+		w.Pause()
+	case CommandResume:
+		w.Resume()
+	case CommandResize:
+		var payload struct {
+			Workers int `json:"workers"`
+		}
+		if err := json.Unmarshal(cmd.Payload, &payload); err != nil {
+			return fmt.Errorf("%w: resize payload: %w", ErrInvalidCommand, err)
+		}
+		if err := w.Resize(payload.Workers); err != nil {
+			return err
+		}
+	case CommandInvalidateCache:
+		var payload struct {
+			TaskID string `json:"task_id"`
+		}
+		if len(cmd.Payload) > 0 {
+			if err := json.Unmarshal(cmd.Payload, &payload); err != nil {
+				return fmt.Errorf("%w: invalidate-cache payload: %w", ErrInvalidCommand, err)
+			}
+		}
+		if payload.TaskID != "" {
+			w.cache.Delete(payload.TaskID)
+		} else {
+			w.cache.Range(func(id string, _ *Task) bool {
+				w.cache.Delete(id)
+				return true
+			})
+		}
+	case CommandReloadPolicies:
+		if w.config.Policies == nil {
+			return fmt.Errorf("%w: reload-policies: no policy table configured", ErrInvalidCommand)
+		}
+		policies := make(map[string]TypePolicy)
+		if err := json.Unmarshal(cmd.Payload, &policies); err != nil {
+			return fmt.Errorf("%w: reload-policies payload: %w", ErrInvalidCommand, err)
+		}
+		for taskType, policy := range policies {
+			w.config.Policies.Set(taskType, policy)
+		}
+	default:
+		return fmt.Errorf("%w: unknown command %q", ErrInvalidCommand, cmd.Name)
+	}
+	w.logger.Info("taskqueue: control command applied", "command", cmd.Name)
+	return nil
+}
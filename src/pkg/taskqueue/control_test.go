@@ -0,0 +1,133 @@
+package taskqueue
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var controlKey = []byte("fleet-secret")
+
+func signedCommand(t *testing.T, name string, payload string) []byte {
+	t.Helper()
+	cmd := Command{Name: name, IssuedAt: time.Now().UTC()}
+	if payload != "" {
+		cmd.Payload = json.RawMessage(payload)
+	}
+	require.NoError(t, SignCommand(&cmd, controlKey))
+	raw, err := json.Marshal(cmd)
+	require.NoError(t, err)
+	return raw
+}
+
+func startControlledWorker(t *testing.T, config WorkerConfig) (*Worker, chan []byte) {
+	t.Helper()
+	w := NewWorker(config, newMockRepository(), nil)
+	w.Start()
+	commands := make(chan []byte)
+	done := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		defer close(done)
+		w.RunControl(ctx, ControlConfig{Key: controlKey}, commands)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+		w.Shutdown(context.Background())
+	})
+	return w, commands
+}
+
+func TestRunControlPauseAndResume(t *testing.T) {
+	w, commands := startControlledWorker(t, WorkerConfig{WorkerCount: 1})
+
+	commands <- signedCommand(t, CommandPause, "")
+	require.Eventually(t, w.Paused, 2*time.Second, 5*time.Millisecond)
+
+	commands <- signedCommand(t, CommandResume, "")
+	require.Eventually(t, func() bool { return !w.Paused() }, 2*time.Second, 5*time.Millisecond)
+}
+
+func TestRunControlResize(t *testing.T) {
+	w, commands := startControlledWorker(t, WorkerConfig{WorkerCount: 1})
+
+	commands <- signedCommand(t, CommandResize, `{"workers":3}`)
+	require.Eventually(t, func() bool { return w.PoolSize() == 3 }, 2*time.Second, 5*time.Millisecond)
+
+	commands <- signedCommand(t, CommandResize, `{"workers":2}`)
+	require.Eventually(t, func() bool { return w.PoolSize() == 2 }, 2*time.Second, 5*time.Millisecond)
+}
+
+func TestRunControlInvalidateCache(t *testing.T) {
+	w, commands := startControlledWorker(t, WorkerConfig{WorkerCount: 1})
+	w.cache.Store(&Task{ID: "t1", Type: "email"})
+	w.cache.Store(&Task{ID: "t2", Type: "email"})
+
+	commands <- signedCommand(t, CommandInvalidateCache, `{"task_id":"t1"}`)
+	require.Eventually(t, func() bool {
+		_, ok := w.cache.Load("t1")
+		return !ok
+	}, 2*time.Second, 5*time.Millisecond)
+	_, ok := w.cache.Load("t2")
+	assert.True(t, ok, "other entries survive a targeted invalidation")
+
+	commands <- signedCommand(t, CommandInvalidateCache, "")
+	require.Eventually(t, func() bool { return w.cache.Len() == 0 }, 2*time.Second, 5*time.Millisecond)
+}
+
+func TestRunControlReloadPolicies(t *testing.T) {
+	policies := NewTypePolicies()
+	w, commands := startControlledWorker(t, WorkerConfig{WorkerCount: 1, Policies: policies})
+	_ = w
+
+	commands <- signedCommand(t, CommandReloadPolicies, `{"email":{"max_retries":5,"timeout":"30s"}}`)
+	require.Eventually(t, func() bool {
+		policy, ok := policies.Get("email")
+		return ok && policy.MaxRetries == 5 && policy.Timeout == 30*time.Second
+	}, 2*time.Second, 5*time.Millisecond)
+}
+
+func TestRunControlRejectsBadCommands(t *testing.T) {
+	w, commands := startControlledWorker(t, WorkerConfig{WorkerCount: 1})
+
+	unsigned, err := json.Marshal(Command{Name: CommandPause, IssuedAt: time.Now().UTC(), Signature: "deadbeef"})
+	require.NoError(t, err)
+	commands <- unsigned
+
+	stale := Command{Name: CommandPause, IssuedAt: time.Now().UTC().Add(-time.Hour)}
+	require.NoError(t, SignCommand(&stale, controlKey))
+	staleRaw, err := json.Marshal(stale)
+	require.NoError(t, err)
+	commands <- staleRaw
+
+	unknown := signedCommand(t, "reboot", "")
+	commands <- unknown
+
+	time.Sleep(50 * time.Millisecond)
+	assert.False(t, w.Paused(), "rejected commands must not be applied")
+}
+
+func TestRunControlRequiresKey(t *testing.T) {
+	w := NewWorker(WorkerConfig{}, newMockRepository(), nil)
+	defer w.Shutdown(context.Background())
+
+	err := w.RunControl(context.Background(), ControlConfig{}, nil)
+	assert.ErrorIs(t, err, ErrInvalidCommand)
+}
+
+func TestResizeValidation(t *testing.T) {
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), nil)
+	assert.Error(t, w.Resize(0))
+
+	require.NoError(t, w.Resize(4), "resize before Start records the count")
+	w.Start()
+	assert.Equal(t, 4, w.PoolSize())
+
+	require.NoError(t, w.Shutdown(context.Background()))
+	assert.ErrorIs(t, w.Resize(2), ErrWorkerStopped)
+}
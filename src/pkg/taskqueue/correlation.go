@@ -0,0 +1,47 @@
+package taskqueue
+
+import "context"
+
+// CorrelationIDMetadataKey is the Metadata key carrying the ID of the
+// originating request.
+const CorrelationIDMetadataKey = "taskqueue.correlation_id"
+
+// correlationKey is the context key for correlation IDs.
+type correlationKey struct{}
+
+// WithCorrelationID returns a context carrying the originating request's
+// correlation ID. SubmitTask copies it into the task metadata and the
+// result envelope, so downstream consumers can tie results back to the
+// request.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationKey{}, id)
+}
+
+// CorrelationIDFromContext returns the correlation ID carried by the
+// context, if any.
+func CorrelationIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(correlationKey{}).(string)
+	return id, ok && id != ""
+}
+
+// CorrelationID returns the task's correlation ID, or "" when the task
+// is not correlated to a request.
+func (t *Task) CorrelationID() string {
+	return t.Metadata[CorrelationIDMetadataKey]
+}
+
+// propagateCorrelation copies the submission context's correlation ID
+// into the task metadata, unless the task already carries one.
+func propagateCorrelation(ctx context.Context, task *Task) {
+	if task.CorrelationID() != "" {
+		return
+	}
+	id, ok := CorrelationIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	if task.Metadata == nil {
+		task.Metadata = make(map[string]string, 1)
+	}
+	task.Metadata[CorrelationIDMetadataKey] = id
+}
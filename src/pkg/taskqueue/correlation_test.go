@@ -0,0 +1,52 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCorrelationIDContext(t *testing.T) {
+	_, ok := CorrelationIDFromContext(context.Background())
+	assert.False(t, ok)
+
+	ctx := WithCorrelationID(context.Background(), "req-42")
+	id, ok := CorrelationIDFromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, "req-42", id)
+}
+
+func TestSubmitTaskPropagatesCorrelationID(t *testing.T) {
+	worker := NewWorker(WorkerConfig{}, newMockRepository(), nil)
+
+	task := NewTask("email", nil)
+	ctx := WithCorrelationID(context.Background(), "req-42")
+	require.NoError(t, worker.SubmitTask(ctx, task))
+	assert.Equal(t, "req-42", task.CorrelationID())
+
+	// An ID already on the task wins over the context.
+	tagged := NewTask("email", nil)
+	tagged.Metadata = map[string]string{CorrelationIDMetadataKey: "req-1"}
+	require.NoError(t, worker.SubmitTask(ctx, tagged))
+	assert.Equal(t, "req-1", tagged.CorrelationID())
+}
+
+func TestResultEnvelopeCarriesCorrelationID(t *testing.T) {
+	repo := newMockRepository()
+	publisher := newMockPublisher()
+	worker := NewWorker(WorkerConfig{WorkerCount: 1}, repo, publisher)
+	worker.Executors().Register("ping", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		return &TaskResult{}, nil
+	}))
+
+	ctx := WithCorrelationID(context.Background(), "req-42")
+	require.NoError(t, worker.SubmitTask(ctx, NewTask("ping", nil)))
+	worker.Start()
+	require.NoError(t, worker.Shutdown(context.Background()))
+
+	messages := publisher.messages[ResultTopic("")]
+	require.Len(t, messages, 1)
+	assert.Contains(t, string(messages[0]), `"correlation_id":"req-42"`)
+}
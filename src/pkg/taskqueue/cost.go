@@ -0,0 +1,102 @@
+package taskqueue
+
+import (
+	"sync"
+	"time"
+)
+
+// CostEntry accumulates the approximate cost of one task type within a
+// namespace: processing seconds times the type's weight.
+type CostEntry struct {
+	Tasks   int     `json:"tasks"`
+	Seconds float64 `json:"seconds"`
+	Cost    float64 `json:"cost"`
+}
+
+// NamespaceCost aggregates a tenant's cost across task types.
+type NamespaceCost struct {
+	Cost   float64              `json:"cost"`
+	Tasks  int                  `json:"tasks"`
+	ByType map[string]CostEntry `json:"by_type"`
+}
+
+// CostReport is the chargeback view over everything the accountant has
+// seen, keyed by namespace. Tasks without a namespace land under "".
+type CostReport struct {
+	Namespaces map[string]NamespaceCost `json:"namespaces"`
+}
+
+// CostAccountant tracks approximate cost units per task as
+// duration × per-type weight, aggregated per namespace, so internal
+// chargeback can be computed from the queue itself.
+type CostAccountant struct {
+	weights map[string]float64
+
+	mu      sync.Mutex
+	entries map[string]map[string]*CostEntry
+}
+
+// NewCostAccountant builds an accountant with per-type cost weights in
+// units per processing second. Types without a weight count at 1.0.
+func NewCostAccountant(weights map[string]float64) *CostAccountant {
+	copied := make(map[string]float64, len(weights))
+	for taskType, weight := range weights {
+		copied[taskType] = weight
+	}
+	return &CostAccountant{
+		weights: copied,
+		entries: make(map[string]map[string]*CostEntry),
+	}
+}
+
+// record accumulates one processed task.
+func (a *CostAccountant) record(task *Task, duration time.Duration) {
+	weight, ok := a.weights[task.Type]
+	if !ok {
+		weight = 1.0
+	}
+	seconds := duration.Seconds()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	namespace := task.Namespace()
+	byType, ok := a.entries[namespace]
+	if !ok {
+		byType = make(map[string]*CostEntry)
+		a.entries[namespace] = byType
+	}
+	entry, ok := byType[task.Type]
+	if !ok {
+		entry = &CostEntry{}
+		byType[task.Type] = entry
+	}
+	entry.Tasks++
+	entry.Seconds += seconds
+	entry.Cost += seconds * weight
+}
+
+// Report returns a copy of the accumulated costs.
+func (a *CostAccountant) Report() CostReport {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	report := CostReport{Namespaces: make(map[string]NamespaceCost, len(a.entries))}
+	for namespace, byType := range a.entries {
+		ns := NamespaceCost{ByType: make(map[string]CostEntry, len(byType))}
+		for taskType, entry := range byType {
+			ns.ByType[taskType] = *entry
+			ns.Cost += entry.Cost
+			ns.Tasks += entry.Tasks
+		}
+		report.Namespaces[namespace] = ns
+	}
+	return report
+}
+
+// CostReport returns the worker's accumulated cost report, or an empty
+// report when no accountant is configured.
+func (w *Worker) CostReport() CostReport {
+	if w.config.Costs == nil {
+		return CostReport{Namespaces: map[string]NamespaceCost{}}
+	}
+	return w.config.Costs.Report()
+}
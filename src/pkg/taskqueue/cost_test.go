@@ -0,0 +1,58 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCostAccountantAggregatesByNamespaceAndType(t *testing.T) {
+	accountant := NewCostAccountant(map[string]float64{"render": 4.0})
+
+	acme := NewTask("render", nil)
+	acme.Metadata = map[string]string{NamespaceMetadataKey: "acme"}
+	accountant.record(acme, 2*time.Second)
+	accountant.record(acme, time.Second)
+
+	anonymous := NewTask("email", nil)
+	accountant.record(anonymous, 500*time.Millisecond)
+
+	report := accountant.Report()
+	render := report.Namespaces["acme"].ByType["render"]
+	assert.Equal(t, 2, render.Tasks)
+	assert.InDelta(t, 3.0, render.Seconds, 0.001)
+	assert.InDelta(t, 12.0, render.Cost, 0.001)
+	assert.InDelta(t, 12.0, report.Namespaces["acme"].Cost, 0.001)
+
+	// Unweighted types cost one unit per second, under the "" namespace.
+	email := report.Namespaces[""].ByType["email"]
+	assert.Equal(t, 1, email.Tasks)
+	assert.InDelta(t, 0.5, email.Cost, 0.001)
+}
+
+func TestWorkerRecordsCosts(t *testing.T) {
+	accountant := NewCostAccountant(nil)
+	worker := NewWorker(WorkerConfig{WorkerCount: 1, Costs: accountant}, newMockRepository(), nil)
+	worker.Executors().Register("email", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		time.Sleep(10 * time.Millisecond)
+		return &TaskResult{}, nil
+	}))
+
+	ctx := context.Background()
+	require.NoError(t, worker.SubmitTask(ctx, NewTask("email", nil)))
+	worker.Start()
+	require.NoError(t, worker.Shutdown(ctx))
+
+	report := worker.CostReport()
+	entry := report.Namespaces[""].ByType["email"]
+	assert.Equal(t, 1, entry.Tasks)
+	assert.Greater(t, entry.Cost, 0.0)
+}
+
+func TestCostReportWithoutAccountant(t *testing.T) {
+	worker := NewWorker(WorkerConfig{}, newMockRepository(), nil)
+	assert.Empty(t, worker.CostReport().Namespaces)
+}
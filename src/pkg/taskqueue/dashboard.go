@@ -0,0 +1,56 @@
+package taskqueue
+
+import (
+	"sort"
+	"time"
+)
+
+// DashboardWorker is one pool goroutine as shown on the dashboard.
+type DashboardWorker struct {
+	ID            int       `json:"id"`
+	StartedAt     time.Time `json:"started_at"`
+	CurrentTaskID string    `json:"current_task_id,omitempty"`
+}
+
+// Dashboard is a point-in-time view of the whole queue: counters, status
+// index, queue depth and pool utilization, ready to serialize for the
+// admin UI or a CLI status command.
+type Dashboard struct {
+	GeneratedAt time.Time             `json:"generated_at"`
+	Stats       StatsSnapshot         `json:"stats"`
+	Statuses    map[TaskStatus]uint64 `json:"statuses"`
+	QueueDepth  int                   `json:"queue_depth"`
+	Pool        WorkerCountDecision   `json:"pool"`
+	Workers     []DashboardWorker     `json:"workers"`
+	BusyWorkers int                   `json:"busy_workers"`
+	Utilization float64               `json:"utilization"`
+}
+
+// Dashboard aggregates the status index, worker counters, registry
+// utilization and queue depth into one refreshed-on-demand document.
+func (w *Worker) Dashboard() Dashboard {
+	dashboard := Dashboard{
+		GeneratedAt: time.Now().UTC(),
+		Stats:       w.stats.Snapshot(),
+		Statuses:    w.statuses.Counts(),
+		QueueDepth:  w.queue.Len(),
+		Pool:        w.PoolDecision(),
+	}
+	for id, info := range w.registry.All() {
+		dashboard.Workers = append(dashboard.Workers, DashboardWorker{
+			ID:            id,
+			StartedAt:     info.StartedAt,
+			CurrentTaskID: info.CurrentTaskID,
+		})
+		if info.CurrentTaskID != "" {
+			dashboard.BusyWorkers++
+		}
+	}
+	sort.Slice(dashboard.Workers, func(i, j int) bool {
+		return dashboard.Workers[i].ID < dashboard.Workers[j].ID
+	})
+	if len(dashboard.Workers) > 0 {
+		dashboard.Utilization = float64(dashboard.BusyWorkers) / float64(len(dashboard.Workers))
+	}
+	return dashboard
+}
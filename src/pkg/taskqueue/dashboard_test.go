@@ -0,0 +1,44 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDashboardAggregatesQueueState(t *testing.T) {
+	worker := NewWorker(WorkerConfig{WorkerCount: 2}, newMockRepository(), nil)
+	worker.Executors().Register("email", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		return &TaskResult{}, nil
+	}))
+
+	ctx := context.Background()
+	require.NoError(t, worker.SubmitTask(ctx, NewTask("email", nil)))
+	require.NoError(t, worker.SubmitTask(ctx, NewTask("email", nil)))
+	worker.Start()
+	require.NoError(t, worker.Shutdown(ctx))
+
+	dashboard := worker.Dashboard()
+	assert.False(t, dashboard.GeneratedAt.IsZero())
+	assert.EqualValues(t, 2, dashboard.Stats.Submitted)
+	assert.EqualValues(t, 2, dashboard.Stats.Completed)
+	assert.EqualValues(t, 2, dashboard.Statuses[TaskStatusCompleted])
+	assert.Zero(t, dashboard.QueueDepth)
+	assert.Equal(t, 2, dashboard.Pool.WorkerCount)
+}
+
+func TestDashboardUtilizationTracksBusyWorkers(t *testing.T) {
+	worker := NewWorker(WorkerConfig{WorkerCount: 2}, newMockRepository(), nil)
+	worker.registry.Register(&WorkerInfo{ID: 0, StartedAt: time.Now()})
+	worker.registry.Register(&WorkerInfo{ID: 1, StartedAt: time.Now(), CurrentTaskID: "task-1"})
+
+	dashboard := worker.Dashboard()
+	require.Len(t, dashboard.Workers, 2)
+	assert.Equal(t, 0, dashboard.Workers[0].ID)
+	assert.Equal(t, "task-1", dashboard.Workers[1].CurrentTaskID)
+	assert.Equal(t, 1, dashboard.BusyWorkers)
+	assert.InDelta(t, 0.5, dashboard.Utilization, 0.001)
+}
@@ -0,0 +1,134 @@
+package taskqueue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DeadLetterTopic is the topic dead-lettered tasks are announced on.
+const DeadLetterTopic = "task.deadletter"
+
+// DeadLetter is one permanently failed task parked in the dead letter
+// queue with the failure that put it there.
+type DeadLetter struct {
+	// Task is the failed task as it was when it was parked.
+	Task *Task `json:"task"`
+
+	// Reason is the final error message.
+	Reason string `json:"reason"`
+
+	// At is when the task was parked.
+	At time.Time `json:"at"`
+}
+
+// DeadLetterQueue parks tasks that failed for good — typically after
+// exhausting MaxRetries — so they can be inspected and replayed instead
+// of being dropped.
+type DeadLetterQueue interface {
+	// Add parks one letter.
+	Add(ctx context.Context, letter DeadLetter) error
+
+	// List returns the parked letters, oldest first.
+	List(ctx context.Context) ([]DeadLetter, error)
+
+	// Take removes and returns the letter for the given task ID, or
+	// ErrTaskNotFound.
+	Take(ctx context.Context, taskID string) (DeadLetter, error)
+
+	// Purge discards every parked letter and returns how many there
+	// were.
+	Purge(ctx context.Context) (int, error)
+}
+
+// MemoryDeadLetterQueue is an in-process DeadLetterQueue.
+type MemoryDeadLetterQueue struct {
+	mu      sync.Mutex
+	letters []DeadLetter
+}
+
+// NewMemoryDeadLetterQueue builds an empty in-process dead letter queue.
+func NewMemoryDeadLetterQueue() *MemoryDeadLetterQueue {
+	return &MemoryDeadLetterQueue{}
+}
+
+// Add implements DeadLetterQueue.
+func (q *MemoryDeadLetterQueue) Add(_ context.Context, letter DeadLetter) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.letters = append(q.letters, letter)
+	return nil
+}
+
+// List implements DeadLetterQueue.
+func (q *MemoryDeadLetterQueue) List(_ context.Context) ([]DeadLetter, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	out := make([]DeadLetter, len(q.letters))
+	copy(out, q.letters)
+	return out, nil
+}
+
+// Take implements DeadLetterQueue.
+func (q *MemoryDeadLetterQueue) Take(_ context.Context, taskID string) (DeadLetter, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for n, letter := range q.letters {
+		if letter.Task.ID == taskID {
+			q.letters = append(q.letters[:n], q.letters[n+1:]...)
+			return letter, nil
+		}
+	}
+	return DeadLetter{}, ErrTaskNotFound
+}
+
+// Purge implements DeadLetterQueue.
+func (q *MemoryDeadLetterQueue) Purge(_ context.Context) (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	purged := len(q.letters)
+	q.letters = nil
+	return purged, nil
+}
+
+// deadletter parks a permanently failed task and announces it on the
+// DeadLetterTopic.
+func (w *Worker) deadletter(ctx context.Context, task *Task, cause error) {
+	letter := DeadLetter{Task: snapshotTask(task), Reason: cause.Error(), At: time.Now().UTC()}
+	if err := w.config.DeadLetters.Add(ctx, letter); err != nil {
+		w.log(ctx).Error("taskqueue: dead letter failed", "task_id", task.ID, "error", err)
+		return
+	}
+	if w.publisher != nil {
+		payload, err := json.Marshal(letter)
+		if err == nil {
+			err = w.publisher.Publish(ctx, DeadLetterTopic, payload)
+		}
+		if err != nil {
+			w.log(ctx).Error("taskqueue: publish dead letter failed", "task_id", task.ID, "error", err)
+		}
+	}
+}
+
+// RequeueDeadLetter takes the parked task out of the dead letter queue
+// and submits it again with a reset retry budget.
+func (w *Worker) RequeueDeadLetter(ctx context.Context, taskID string) error {
+	if w.config.DeadLetters == nil {
+		return ErrNoDeadLetterQueue
+	}
+	letter, err := w.config.DeadLetters.Take(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("taskqueue: take dead letter %s: %w", taskID, err)
+	}
+	task := letter.Task
+	w.statuses.Decrement(task.Status)
+	task.Status = TaskStatusPending
+	task.Retries = 0
+	if err := w.SubmitTask(ctx, task); err != nil {
+		w.statuses.Increment(task.Status)
+		return fmt.Errorf("taskqueue: requeue dead letter %s: %w", taskID, err)
+	}
+	return nil
+}
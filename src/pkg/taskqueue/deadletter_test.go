@@ -0,0 +1,107 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryDeadLetterQueueOperations(t *testing.T) {
+	dlq := NewMemoryDeadLetterQueue()
+	ctx := context.Background()
+
+	require.NoError(t, dlq.Add(ctx, DeadLetter{Task: &Task{ID: "t1"}, Reason: "boom", At: time.Now()}))
+	require.NoError(t, dlq.Add(ctx, DeadLetter{Task: &Task{ID: "t2"}, Reason: "bust", At: time.Now()}))
+
+	letters, err := dlq.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, letters, 2)
+	assert.Equal(t, "t1", letters[0].Task.ID)
+
+	letter, err := dlq.Take(ctx, "t1")
+	require.NoError(t, err)
+	assert.Equal(t, "boom", letter.Reason)
+	_, err = dlq.Take(ctx, "t1")
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+
+	purged, err := dlq.Purge(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	letters, err = dlq.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, letters)
+}
+
+func TestWorkerRoutesExhaustedTasksToDLQ(t *testing.T) {
+	dlq := NewMemoryDeadLetterQueue()
+	pub := newMockPublisher()
+	w := NewWorker(WorkerConfig{
+		WorkerCount: 1,
+		Retry:       &RetryPolicy{Strategy: BackoffConstant, Initial: time.Millisecond},
+		DeadLetters: dlq,
+	}, newMockRepository(), pub)
+
+	failed := make(chan struct{})
+	attempts := 0
+	w.Executors().Register("email", ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		attempts++
+		if attempts == 2 {
+			defer close(failed)
+		}
+		return nil, assert.AnError
+	}))
+	w.Start()
+
+	task := &Task{Type: "email", MaxRetries: 1}
+	require.NoError(t, w.SubmitTask(context.Background(), task))
+	select {
+	case <-failed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("task never exhausted its retries")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	require.NoError(t, w.Shutdown(ctx))
+
+	letters, err := dlq.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+	assert.Equal(t, task.ID, letters[0].Task.ID)
+	assert.Contains(t, letters[0].Reason, assert.AnError.Error())
+
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+	assert.Len(t, pub.messages[DeadLetterTopic], 1)
+}
+
+func TestRequeueDeadLetter(t *testing.T) {
+	dlq := NewMemoryDeadLetterQueue()
+	w := NewWorker(WorkerConfig{DeadLetters: dlq}, newMockRepository(), nil)
+	defer w.Shutdown(context.Background())
+
+	ctx := context.Background()
+	parked := &Task{ID: "t1", Type: "email", Status: TaskStatusFailed, Retries: 3, MaxRetries: 3}
+	require.NoError(t, dlq.Add(ctx, DeadLetter{Task: parked, Reason: "boom", At: time.Now()}))
+
+	require.NoError(t, w.RequeueDeadLetter(ctx, "t1"))
+
+	requeued, err := w.GetTask(ctx, "t1")
+	require.NoError(t, err)
+	assert.Equal(t, TaskStatusPending, requeued.Status)
+	assert.Zero(t, requeued.Retries)
+	letters, err := dlq.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, letters)
+
+	assert.ErrorIs(t, w.RequeueDeadLetter(ctx, "missing"), ErrTaskNotFound)
+}
+
+func TestRequeueDeadLetterWithoutQueue(t *testing.T) {
+	w := NewWorker(WorkerConfig{}, newMockRepository(), nil)
+	defer w.Shutdown(context.Background())
+
+	assert.ErrorIs(t, w.RequeueDeadLetter(context.Background(), "t1"), ErrNoDeadLetterQueue)
+}
@@ -0,0 +1,67 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessTimeoutPrefersTaskTimeout(t *testing.T) {
+	policies := NewTypePolicies()
+	policies.Set("slow", TypePolicy{Timeout: time.Minute})
+	worker := NewWorker(WorkerConfig{Policies: policies}, newMockRepository(), nil)
+
+	task := NewTask("slow", nil)
+	task.Timeout = 5 * time.Second
+	assert.Equal(t, 5*time.Second, worker.processTimeout(task), "the task's own timeout wins over the type policy")
+	assert.Equal(t, time.Minute, worker.processTimeout(NewTask("slow", nil)))
+}
+
+func TestCreateTaskRequestValidatesTimeoutAndDeadline(t *testing.T) {
+	request := CreateTaskRequest{Type: "email", Timeout: -time.Second}
+	assert.ErrorIs(t, request.Validate(), ErrInvalidTask)
+
+	request = CreateTaskRequest{Type: "email", Deadline: time.Now().Add(-time.Hour)}
+	err := request.Validate()
+	require.ErrorIs(t, err, ErrInvalidTask)
+	assert.Contains(t, err.Error(), "in the past")
+
+	request = CreateTaskRequest{Type: "email", Timeout: time.Second, Deadline: time.Now().Add(time.Hour)}
+	require.NoError(t, request.Validate())
+	task := request.ToTask()
+	assert.Equal(t, time.Second, task.Timeout)
+	assert.Equal(t, request.Deadline, task.Deadline)
+}
+
+func TestDecodeTaskRejectsNegativeTimeout(t *testing.T) {
+	_, err := DecodeTask([]byte(`{"type":"email","timeout":-1}`))
+	assert.ErrorIs(t, err, ErrInvalidTask)
+}
+
+func TestTaskTimeoutClassifiesDeadlineExceeded(t *testing.T) {
+	w := NewWorker(WorkerConfig{WorkerCount: 1, ProcessTimeout: time.Minute}, newMockRepository(), nil)
+	w.Executors().Register("slow", ExecutorFunc(func(ctx context.Context, _ *Task) (*TaskResult, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}))
+
+	results, cancel := w.SubscribeResults(4)
+	defer cancel()
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	task := NewTask("slow", nil)
+	task.Timeout = 20 * time.Millisecond
+	require.NoError(t, w.SubmitTask(context.Background(), task))
+
+	select {
+	case result := <-results:
+		assert.False(t, result.Success)
+		assert.Contains(t, result.Error, ErrTaskDeadlineExceeded.Error())
+	case <-time.After(2 * time.Second):
+		t.Fatal("no result for the timed-out task")
+	}
+}
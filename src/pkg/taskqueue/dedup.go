@@ -0,0 +1,139 @@
+package taskqueue
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DedupKeyMetadataKey is the Metadata key carrying a task's
+// deduplication key. Tasks without one deduplicate on their ID.
+const DedupKeyMetadataKey = "taskqueue.dedup_key"
+
+// Defaults applied by the deduplicator when the corresponding config
+// field is zero.
+const (
+	// DefaultDedupTTL bounds how long a dedup key blocks resubmissions.
+	DefaultDedupTTL = 10 * time.Minute
+
+	// DefaultDedupEntries caps how many keys are tracked at once.
+	DefaultDedupEntries = 8192
+)
+
+// SetDedupKey marks the task with a deduplication key, so retried
+// producers can resubmit without the task running twice.
+func (t *Task) SetDedupKey(key string) {
+	if t.Metadata == nil {
+		t.Metadata = make(map[string]string, 1)
+	}
+	t.Metadata[DedupKeyMetadataKey] = key
+}
+
+// DedupKey returns the task's deduplication key, or "" when the task
+// deduplicates on its ID alone.
+func (t *Task) DedupKey() string {
+	return t.Metadata[DedupKeyMetadataKey]
+}
+
+// DedupConfig tunes submission deduplication.
+type DedupConfig struct {
+	// TTL is how long a seen key rejects duplicates. Defaults to
+	// DefaultDedupTTL.
+	TTL time.Duration
+
+	// Key derives the dedup key from a task. Defaults to the
+	// DedupKeyMetadataKey metadata value, falling back to the task ID.
+	// Returning "" exempts the task from deduplication.
+	Key func(task *Task) string
+
+	// MaxEntries caps the tracked keys; beyond it the oldest markers are
+	// evicted. Defaults to DefaultDedupEntries.
+	MaxEntries int
+}
+
+// Deduplicator rejects tasks whose dedup key was already seen within
+// the TTL window, so producer retries and double-clicks do not execute
+// twice. SubmitTask consults the worker's deduplicator and returns
+// ErrDuplicateTask for rejected submissions, counting them in the
+// Deduplicated stat. Tracked keys are bounded by TTL and entry count,
+// like the idempotency cache.
+type Deduplicator struct {
+	ttl time.Duration
+	key func(task *Task) string
+	max int
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewDeduplicator builds a deduplicator, applying defaults for zero
+// config fields.
+func NewDeduplicator(config DedupConfig) *Deduplicator {
+	if config.TTL <= 0 {
+		config.TTL = DefaultDedupTTL
+	}
+	if config.Key == nil {
+		config.Key = defaultDedupKey
+	}
+	if config.MaxEntries <= 0 {
+		config.MaxEntries = DefaultDedupEntries
+	}
+	return &Deduplicator{
+		ttl:  config.TTL,
+		key:  config.Key,
+		max:  config.MaxEntries,
+		seen: make(map[string]time.Time),
+	}
+}
+
+// defaultDedupKey prefers the explicit metadata key over the task ID.
+func defaultDedupKey(task *Task) string {
+	if key := task.DedupKey(); key != "" {
+		return key
+	}
+	return task.ID
+}
+
+// Check records the task's dedup key and returns ErrDuplicateTask when
+// the key was already seen within the TTL window. Expired markers are
+// replaced, so a key becomes submittable again once the window passes.
+func (d *Deduplicator) Check(task *Task) error {
+	key := d.key(task)
+	if key == "" {
+		return nil
+	}
+	now := time.Now()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if at, ok := d.seen[key]; ok && now.Sub(at) < d.ttl {
+		return fmt.Errorf("%w: key %q", ErrDuplicateTask, key)
+	}
+	for len(d.seen) >= d.max {
+		if _, ok := d.seen[key]; ok {
+			break
+		}
+		oldestKey := ""
+		var oldestAt time.Time
+		for k, at := range d.seen {
+			if oldestKey == "" || at.Before(oldestAt) {
+				oldestKey, oldestAt = k, at
+			}
+		}
+		delete(d.seen, oldestKey)
+	}
+	d.seen[key] = now
+	return nil
+}
+
+// Forget releases the task's dedup key, so a submission rejected after
+// a successful Check does not block the producer's retry for the whole
+// TTL window.
+func (d *Deduplicator) Forget(task *Task) {
+	key := d.key(task)
+	if key == "" {
+		return
+	}
+	d.mu.Lock()
+	delete(d.seen, key)
+	d.mu.Unlock()
+}
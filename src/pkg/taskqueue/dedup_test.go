@@ -0,0 +1,119 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeduplicatorRejectsWithinWindow(t *testing.T) {
+	d := NewDeduplicator(DedupConfig{})
+
+	task := NewTask("email", nil)
+	require.NoError(t, d.Check(task))
+	assert.ErrorIs(t, d.Check(task), ErrDuplicateTask)
+
+	other := NewTask("email", nil)
+	assert.NoError(t, d.Check(other), "distinct IDs are not duplicates")
+}
+
+func TestDeduplicatorUsesMetadataKey(t *testing.T) {
+	d := NewDeduplicator(DedupConfig{})
+
+	first := NewTask("email", nil)
+	first.SetDedupKey("order-42")
+	require.NoError(t, d.Check(first))
+
+	second := NewTask("email", nil)
+	second.SetDedupKey("order-42")
+	assert.ErrorIs(t, d.Check(second), ErrDuplicateTask)
+}
+
+func TestDeduplicatorExpiresKeys(t *testing.T) {
+	d := NewDeduplicator(DedupConfig{TTL: 20 * time.Millisecond})
+
+	task := NewTask("email", nil)
+	task.SetDedupKey("order-42")
+	require.NoError(t, d.Check(task))
+
+	time.Sleep(30 * time.Millisecond)
+	retry := NewTask("email", nil)
+	retry.SetDedupKey("order-42")
+	assert.NoError(t, d.Check(retry), "expired keys are submittable again")
+}
+
+func TestDeduplicatorEvictsOldestBeyondMaxEntries(t *testing.T) {
+	d := NewDeduplicator(DedupConfig{MaxEntries: 2})
+
+	oldest := NewTask("email", nil)
+	oldest.SetDedupKey("order-1")
+	require.NoError(t, d.Check(oldest))
+	for _, key := range []string{"order-2", "order-3"} {
+		task := NewTask("email", nil)
+		task.SetDedupKey(key)
+		require.NoError(t, d.Check(task))
+	}
+	assert.Len(t, d.seen, 2, "the cache stays within its bound")
+
+	retry := NewTask("email", nil)
+	retry.SetDedupKey("order-1")
+	assert.NoError(t, d.Check(retry), "evicted keys are submittable again")
+}
+
+func TestDeduplicatorCustomKeyFunc(t *testing.T) {
+	d := NewDeduplicator(DedupConfig{Key: func(task *Task) string {
+		if task.Type == "email" {
+			return ""
+		}
+		return task.Type
+	}})
+
+	assert.NoError(t, d.Check(NewTask("email", nil)))
+	assert.NoError(t, d.Check(NewTask("email", nil)), "empty key exempts the task")
+	assert.NoError(t, d.Check(NewTask("sms", nil)))
+	assert.ErrorIs(t, d.Check(NewTask("sms", nil)), ErrDuplicateTask)
+}
+
+func TestSubmitTaskReleasesDedupKeyOnRejection(t *testing.T) {
+	repo := newMockRepository()
+	repo.saveErr = assert.AnError
+	w := NewWorker(WorkerConfig{WorkerCount: 1, Dedup: &DedupConfig{}}, repo, nil)
+	defer w.Shutdown(context.Background())
+
+	task := NewTask("email", nil)
+	task.SetDedupKey("order-42")
+	require.ErrorIs(t, w.SubmitTask(context.Background(), task), assert.AnError)
+
+	repo.saveErr = nil
+	retry := NewTask("email", nil)
+	retry.SetDedupKey("order-42")
+	assert.NoError(t, w.SubmitTask(context.Background(), retry),
+		"a rejected submission must not hold the key against the retry")
+}
+
+func TestSubmitTaskDeduplicates(t *testing.T) {
+	executed := 0
+	w := NewWorker(WorkerConfig{WorkerCount: 1, Dedup: &DedupConfig{}}, newMockRepository(), nil)
+	w.Executors().Register("email", ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		executed++
+		return &TaskResult{}, nil
+	}))
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	first := NewTask("email", nil)
+	first.SetDedupKey("order-42")
+	require.NoError(t, w.SubmitTask(context.Background(), first))
+
+	duplicate := NewTask("email", nil)
+	duplicate.SetDedupKey("order-42")
+	assert.ErrorIs(t, w.SubmitTask(context.Background(), duplicate), ErrDuplicateTask)
+
+	require.Eventually(t, func() bool {
+		snapshot := w.Stats()
+		return snapshot.Completed == 1 && snapshot.Deduplicated == 1
+	}, 2*time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,24 @@
+// Package taskqueue provides an embeddable background task queue with
+// pluggable persistence and result publishing. A Worker pulls submitted
+// tasks from an in-memory buffer, dispatches them to registered executors
+// and publishes results to a message topic.
+//
+// # API stability
+//
+// The exported surface follows the compatibility guarantees below so
+// plugin and adapter authors can build against it:
+//
+//   - The interfaces TaskRepository, Publisher and Executor are stable
+//     contracts. New methods are added through new, narrower interfaces
+//     rather than by widening these.
+//   - Task, TaskResult, CreateTaskRequest and the TaskStatus constants
+//     only grow: existing fields and values keep their meaning and JSON
+//     encoding.
+//   - Sentinel errors are matched with errors.Is and never removed.
+//   - Identifiers marked Deprecated keep working for at least one minor
+//     release after the replacement ships.
+//
+// The compat subpackage re-exports this stable surface under one import
+// path; run an API differ (e.g. golang.org/x/exp/apidiff) against it to
+// detect accidental breaks.
+package taskqueue
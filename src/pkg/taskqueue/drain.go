@@ -0,0 +1,151 @@
+package taskqueue
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// WorkerState names one of the worker's three lifecycle states.
+type WorkerState string
+
+// Worker lifecycle states as reported by State.
+const (
+	// StateRunning means the worker accepts and processes submissions.
+	StateRunning WorkerState = "running"
+
+	// StateDraining means intake is closed but the pool is still working
+	// through the backlog already accepted.
+	StateDraining WorkerState = "draining"
+
+	// StateStopped means intake is closed and the pool has been (or is
+	// being) released.
+	StateStopped WorkerState = "stopped"
+)
+
+// drainPollInterval is how often an unpaced Drain re-checks the backlog
+// and publishes progress.
+const drainPollInterval = 10 * time.Millisecond
+
+// DrainEvent reports drain progress on Bus().Drain: how many tasks are
+// still buffered and whether the drain has finished.
+type DrainEvent struct {
+	Remaining int       `json:"remaining"`
+	Done      bool      `json:"done"`
+	At        time.Time `json:"at"`
+}
+
+// State returns the worker's current lifecycle state.
+func (w *Worker) State() WorkerState {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	switch {
+	case w.draining:
+		return StateDraining
+	case w.stopped:
+		return StateStopped
+	default:
+		return StateRunning
+	}
+}
+
+// IsDraining reports whether a drain is in progress.
+func (w *Worker) IsDraining() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.draining
+}
+
+// setDraining flips the draining flag under the lifecycle lock.
+func (w *Worker) setDraining(draining bool) {
+	w.mu.Lock()
+	w.draining = draining
+	w.mu.Unlock()
+}
+
+// Drain stops accepting new submissions but keeps the pool processing at
+// full speed until the buffered backlog is empty, then releases the
+// pool. Unlike Shutdown, which closes the buffers immediately and only
+// finishes tasks already in flight, Drain works through everything the
+// worker has accepted, publishing DrainEvents on the bus as the backlog
+// shrinks. Cancelling ctx abandons the drain with the backlog partially
+// processed.
+func (w *Worker) Drain(ctx context.Context) error {
+	return w.drain(ctx, drainPollInterval)
+}
+
+// DrainAtRate drains like Drain but processes the backlog at no more
+// than rate tasks per second. It is meant for controlled migrations
+// where the downstream system cannot absorb a full-speed drain.
+func (w *Worker) DrainAtRate(ctx context.Context, rate float64) error {
+	if rate <= 0 {
+		return fmt.Errorf("taskqueue: drain rate must be positive, got %v", rate)
+	}
+	interval := time.Duration(float64(time.Second) / rate)
+	// Deliberately not stopped: a worker goroutine may still be waiting
+	// on a tick when an abandoned drain returns, and an unreferenced
+	// ticker is collected anyway.
+	ticker := time.NewTicker(interval)
+	w.pace.Store(ticker)
+	defer w.pace.Store(nil)
+	return w.drain(ctx, interval)
+}
+
+// drain closes intake, moves spilled tasks back into the buffer and
+// waits for the pool to empty it, checking and reporting progress every
+// interval. The worker is draining for the duration and stopped after.
+func (w *Worker) drain(ctx context.Context, interval time.Duration) error {
+	w.setDraining(true)
+	defer w.setDraining(false)
+
+	w.mu.Lock()
+	started := w.started
+	w.mu.Unlock()
+	if !started {
+		w.Start()
+	}
+	if w.stopIntake() {
+		w.drainWg.Wait()
+	}
+
+	// Move spilled tasks back into the queue so the pool sees the full
+	// backlog, waiting out a full buffer at the drain interval.
+	if w.overflow != nil {
+		for {
+			task, ok := w.overflow.Pop()
+			if !ok {
+				break
+			}
+			for {
+				if err := w.queue.Enqueue(task); err == nil {
+					w.statuses.Increment(task.Status)
+					break
+				}
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(interval):
+				}
+			}
+		}
+	}
+
+	// Wait for the pool to work through the buffer.
+	for {
+		remaining := w.queue.Len()
+		w.bus.Drain.Publish(DrainEvent{Remaining: remaining, At: time.Now().UTC()})
+		if remaining == 0 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+	if err := w.release(ctx); err != nil {
+		return err
+	}
+	w.bus.Drain.Publish(DrainEvent{Done: true, At: time.Now().UTC()})
+	return nil
+}
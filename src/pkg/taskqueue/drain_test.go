@@ -0,0 +1,132 @@
+package taskqueue
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrainProcessesBacklogAtCappedRate(t *testing.T) {
+	worker := NewWorker(WorkerConfig{WorkerCount: 4}, newMockRepository(), nil)
+	var processed atomic.Int32
+	worker.Executors().Register("migrate", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		processed.Add(1)
+		return &TaskResult{}, nil
+	}))
+
+	ctx := context.Background()
+	const tasks = 5
+	for i := 0; i < tasks; i++ {
+		require.NoError(t, worker.SubmitTask(ctx, NewTask("migrate", nil)))
+	}
+
+	start := time.Now()
+	require.NoError(t, worker.DrainAtRate(ctx, 50))
+	elapsed := time.Since(start)
+
+	assert.EqualValues(t, tasks, processed.Load())
+	// 5 tasks at 50/s need at least ~100ms; full speed would be instant.
+	assert.GreaterOrEqual(t, elapsed, 80*time.Millisecond)
+
+	// Intake is closed once draining starts.
+	assert.ErrorIs(t, worker.SubmitTask(ctx, NewTask("migrate", nil)), ErrWorkerStopped)
+}
+
+func TestDrainRejectsNonPositiveRate(t *testing.T) {
+	worker := NewWorker(WorkerConfig{}, newMockRepository(), nil)
+	assert.Error(t, worker.DrainAtRate(context.Background(), 0))
+}
+
+func TestDrainHonoursContextCancellation(t *testing.T) {
+	worker := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), nil)
+	worker.Executors().Register("migrate", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		return &TaskResult{}, nil
+	}))
+
+	ctx := context.Background()
+	for i := 0; i < 20; i++ {
+		require.NoError(t, worker.SubmitTask(ctx, NewTask("migrate", nil)))
+	}
+
+	cancelled, cancel := context.WithTimeout(ctx, 30*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, worker.DrainAtRate(cancelled, 1), context.DeadlineExceeded)
+}
+
+func TestDrainProcessesBacklogThenStops(t *testing.T) {
+	worker := NewWorker(WorkerConfig{WorkerCount: 2}, newMockRepository(), nil)
+	var processed atomic.Int32
+	worker.Executors().Register("migrate", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		processed.Add(1)
+		return &TaskResult{}, nil
+	}))
+
+	ctx := context.Background()
+	for i := 0; i < 10; i++ {
+		require.NoError(t, worker.SubmitTask(ctx, NewTask("migrate", nil)))
+	}
+
+	assert.Equal(t, StateRunning, worker.State())
+	require.NoError(t, worker.Drain(ctx))
+
+	assert.EqualValues(t, 10, processed.Load())
+	assert.Equal(t, StateStopped, worker.State())
+	assert.False(t, worker.IsDraining())
+	assert.ErrorIs(t, worker.SubmitTask(ctx, NewTask("migrate", nil)), ErrWorkerStopped)
+}
+
+func TestDrainReportsStateWhileDraining(t *testing.T) {
+	worker := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), nil)
+	release := make(chan struct{})
+	worker.Executors().Register("migrate", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		<-release
+		return &TaskResult{}, nil
+	}))
+
+	ctx := context.Background()
+	require.NoError(t, worker.SubmitTask(ctx, NewTask("migrate", nil)))
+
+	done := make(chan error, 1)
+	go func() { done <- worker.Drain(ctx) }()
+
+	require.Eventually(t, func() bool { return worker.IsDraining() }, 2*time.Second, 5*time.Millisecond)
+	assert.Equal(t, StateDraining, worker.State())
+
+	close(release)
+	require.NoError(t, <-done)
+	assert.Equal(t, StateStopped, worker.State())
+}
+
+func TestDrainPublishesProgressEvents(t *testing.T) {
+	worker := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), nil)
+	worker.Executors().Register("migrate", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		return &TaskResult{}, nil
+	}))
+
+	events, cancel := worker.Bus().Drain.Subscribe(64, DropNewest)
+	defer cancel()
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, worker.SubmitTask(ctx, NewTask("migrate", nil)))
+	}
+	require.NoError(t, worker.Drain(ctx))
+
+	var last DrainEvent
+	for {
+		select {
+		case event := <-events:
+			last = event
+			if event.Done {
+				assert.Zero(t, event.Remaining)
+				return
+			}
+		default:
+			t.Fatalf("drain finished without a done event, last seen %+v", last)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package taskqueue
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TaskEncoder serializes tasks to JSON using pooled buffers.
+type TaskEncoder struct{}
+
+// NewTaskEncoder returns a ready-to-use encoder.
+func NewTaskEncoder() *TaskEncoder {
+	return &TaskEncoder{}
+}
+
+// Encode returns the JSON encoding of the task. The returned slice is a
+// copy and remains valid after the internal buffer is recycled.
+func (e *TaskEncoder) Encode(task *Task) ([]byte, error) {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer bufferPool.Put(buf)
+
+	if err := json.NewEncoder(buf).Encode(task); err != nil {
+		return nil, fmt.Errorf("taskqueue: encode task %s: %w", task.ID, err)
+	}
+	out := make([]byte, buf.Len())
+	copy(out, buf.Bytes())
+	return out, nil
+}
+
+// SummaryTemplate controls which task fields a summary line includes and
+// how its data preview is rendered. The zero value includes nothing;
+// DefaultSummaryTemplate matches the historical FormatTaskSummary output.
+type SummaryTemplate struct {
+	// IncludeID, IncludeType, IncludeStatus and IncludeRetries select the
+	// scalar fields of the summary. Retries only appear when non-zero.
+	IncludeID      bool
+	IncludeType    bool
+	IncludeStatus  bool
+	IncludeRetries bool
+
+	// IncludeData appends a sorted key=value preview of the task data.
+	IncludeData bool
+
+	// MaxDataLen truncates the data preview to at most this many bytes,
+	// appending "..." when cut. Zero means no limit.
+	MaxDataLen int
+
+	// Redactor masks sensitive data values in the preview. Defaults to
+	// the package default redactor.
+	Redactor *Redactor
+}
+
+// DefaultSummaryTemplate returns the template used by FormatTaskSummary.
+func DefaultSummaryTemplate() SummaryTemplate {
+	return SummaryTemplate{
+		IncludeID:      true,
+		IncludeType:    true,
+		IncludeStatus:  true,
+		IncludeRetries: true,
+		IncludeData:    true,
+	}
+}
+
+// Format renders the task through the template using a pooled builder.
+func (t SummaryTemplate) Format(task *Task) string {
+	b := builderPool.Get().(*strings.Builder)
+	b.Reset()
+	defer builderPool.Put(b)
+
+	b.WriteString("task")
+	if t.IncludeID {
+		b.WriteString(" ")
+		b.WriteString(task.ID)
+	}
+	if t.IncludeType {
+		b.WriteString(" type=")
+		b.WriteString(task.Type)
+	}
+	if t.IncludeStatus {
+		b.WriteString(" status=")
+		b.WriteString(string(task.Status))
+	}
+	if t.IncludeRetries && task.Retries > 0 {
+		fmt.Fprintf(b, " retries=%d/%d", task.Retries, task.MaxRetries)
+	}
+	if t.IncludeData && len(task.Data) > 0 {
+		b.WriteString(" data={")
+		b.WriteString(t.dataPreview(task.Data))
+		b.WriteString("}")
+	}
+	return b.String()
+}
+
+// dataPreview renders the redacted, sorted key=value pairs, truncated to
+// MaxDataLen.
+func (t SummaryTemplate) dataPreview(data map[string]any) string {
+	redactor := t.Redactor
+	if redactor == nil {
+		redactor = defaultRedactor
+	}
+	data = redactor.RedactData(data)
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	b := builderPool.Get().(*strings.Builder)
+	b.Reset()
+	defer builderPool.Put(b)
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(b, "%s=%v", key, data[key])
+	}
+	preview := b.String()
+	if t.MaxDataLen > 0 && len(preview) > t.MaxDataLen {
+		preview = preview[:t.MaxDataLen] + "..."
+	}
+	return preview
+}
+
+// FormatTaskSummary returns a one-line human-readable description of the
+// task for logs and diagnostics, rendered with DefaultSummaryTemplate.
+// Data values under sensitive keys are masked with the default redactor
+// so summaries are safe to log as-is.
+func FormatTaskSummary(task *Task) string {
+	return DefaultSummaryTemplate().Format(task)
+}
+
+// ResultEncoder controls which TaskResult fields the published result
+// message carries. TaskID and Success are always present; the zero value
+// includes nothing else. DefaultResultEncoder includes every field.
+type ResultEncoder struct {
+	IncludeTaskType      bool
+	IncludeNamespace     bool
+	IncludeCorrelationID bool
+	IncludeOutput        bool
+	IncludeError         bool
+	IncludeDuration      bool
+	IncludeCompletedAt   bool
+}
+
+// DefaultResultEncoder returns an encoder that includes every result
+// field. Workers publish with it unless WorkerConfig.ResultEncoder says
+// otherwise.
+func DefaultResultEncoder() ResultEncoder {
+	return ResultEncoder{
+		IncludeTaskType:      true,
+		IncludeNamespace:     true,
+		IncludeCorrelationID: true,
+		IncludeOutput:        true,
+		IncludeError:         true,
+		IncludeDuration:      true,
+		IncludeCompletedAt:   true,
+	}
+}
+
+// resultMessage is the wire shape of a published result. Optional fields
+// are omitted when empty, so a field excluded by the encoder and a field
+// that happens to be empty look the same to consumers.
+type resultMessage struct {
+	TaskID        string        `json:"task_id"`
+	TaskType      string        `json:"task_type,omitempty"`
+	Namespace     string        `json:"namespace,omitempty"`
+	CorrelationID string        `json:"correlation_id,omitempty"`
+	Success       bool          `json:"success"`
+	Output        string        `json:"output,omitempty"`
+	Error         string        `json:"error,omitempty"`
+	Duration      time.Duration `json:"duration,omitempty"`
+	CompletedAt   time.Time     `json:"completed_at,omitzero"`
+}
+
+// Encode renders the published message for the result, assembling it in
+// a pooled buffer to keep the publish path off the allocator. The
+// returned slice is a copy and remains valid after the buffer is
+// recycled. Strings go through encoding/json, so quoting and escaping
+// are always correct.
+func (e ResultEncoder) Encode(result *TaskResult) ([]byte, error) {
+	message := resultMessage{TaskID: result.TaskID, Success: result.Success}
+	if e.IncludeTaskType {
+		message.TaskType = result.TaskType
+	}
+	if e.IncludeNamespace {
+		message.Namespace = result.Namespace
+	}
+	if e.IncludeCorrelationID {
+		message.CorrelationID = result.CorrelationID
+	}
+	if e.IncludeOutput {
+		message.Output = result.Output
+	}
+	if e.IncludeError {
+		message.Error = result.Error
+	}
+	if e.IncludeDuration {
+		message.Duration = result.Duration
+	}
+	if e.IncludeCompletedAt {
+		message.CompletedAt = result.CompletedAt
+	}
+
+	buf := payloadPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer payloadPool.Put(buf)
+
+	if err := json.NewEncoder(buf).Encode(message); err != nil {
+		return nil, fmt.Errorf("taskqueue: encode result %s: %w", result.TaskID, err)
+	}
+	encoded := bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+	out := make([]byte, len(encoded))
+	copy(out, encoded)
+	return out, nil
+}
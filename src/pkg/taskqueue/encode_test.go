@@ -0,0 +1,123 @@
+package taskqueue
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummaryTemplateFieldSelection(t *testing.T) {
+	task := NewTask("email", map[string]any{"to": "ops@example.com"})
+	task.Retries = 2
+	task.MaxRetries = 3
+
+	tests := []struct {
+		name     string
+		template SummaryTemplate
+		want     string
+	}{
+		{
+			name:     "default matches FormatTaskSummary",
+			template: DefaultSummaryTemplate(),
+			want:     FormatTaskSummary(task),
+		},
+		{
+			name:     "type and status only",
+			template: SummaryTemplate{IncludeType: true, IncludeStatus: true},
+			want:     "task type=email status=pending",
+		},
+		{
+			name:     "without data",
+			template: SummaryTemplate{IncludeID: true, IncludeType: true, IncludeStatus: true, IncludeRetries: true},
+			want:     "task " + task.ID + " type=email status=pending retries=2/3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.template.Format(task))
+		})
+	}
+}
+
+func TestSummaryTemplateTruncatesDataPreview(t *testing.T) {
+	task := NewTask("email", map[string]any{"body": strings.Repeat("x", 100)})
+
+	template := SummaryTemplate{IncludeData: true, MaxDataLen: 16}
+	summary := template.Format(task)
+
+	assert.Equal(t, "task data={body="+strings.Repeat("x", 11)+"...}", summary)
+}
+
+func TestResultEncoderDefaultStaysStable(t *testing.T) {
+	encoder := DefaultResultEncoder()
+
+	message, err := encoder.Encode(&TaskResult{TaskID: "t1", Success: true})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"task_id":"t1","success":true}`, string(message))
+
+	message, err = encoder.Encode(&TaskResult{TaskID: "t2", Success: false, CorrelationID: "c9"})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"task_id":"t2","success":false,"correlation_id":"c9"}`, string(message))
+}
+
+func TestResultEncoderIncludesEveryField(t *testing.T) {
+	completed := time.Date(2026, 8, 28, 12, 0, 0, 0, time.UTC)
+	result := &TaskResult{
+		TaskID:        "t1",
+		TaskType:      "email",
+		Namespace:     "acme",
+		CorrelationID: "c9",
+		Success:       true,
+		Output:        "sent",
+		Error:         "partial",
+		Duration:      250 * time.Millisecond,
+		CompletedAt:   completed,
+	}
+
+	message, err := DefaultResultEncoder().Encode(result)
+	assert.NoError(t, err)
+
+	var decoded TaskResult
+	assert.NoError(t, json.Unmarshal(message, &decoded))
+	assert.Equal(t, *result, decoded)
+}
+
+func TestResultEncoderFieldSelection(t *testing.T) {
+	result := &TaskResult{
+		TaskID:   "t1",
+		TaskType: "email",
+		Success:  true,
+		Output:   "sent",
+		Duration: time.Second,
+	}
+
+	encoder := ResultEncoder{IncludeOutput: true}
+	message, err := encoder.Encode(result)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"task_id":"t1","success":true,"output":"sent"}`, string(message))
+}
+
+func TestResultEncoderEscapesStrings(t *testing.T) {
+	result := &TaskResult{TaskID: "t1", Success: false, Error: `read "payload": line 2` + "\n"}
+
+	message, err := DefaultResultEncoder().Encode(result)
+	assert.NoError(t, err)
+	assert.True(t, json.Valid(message))
+
+	var decoded TaskResult
+	assert.NoError(t, json.Unmarshal(message, &decoded))
+	assert.Equal(t, result.Error, decoded.Error)
+}
+
+func TestSummaryTemplateCustomRedactor(t *testing.T) {
+	task := NewTask("email", map[string]any{"body": "hello", "to": "x"})
+
+	template := SummaryTemplate{IncludeData: true, Redactor: NewRedactor("body")}
+	summary := template.Format(task)
+
+	assert.Contains(t, summary, "body="+RedactedPlaceholder)
+	assert.Contains(t, summary, "to=x")
+}
@@ -0,0 +1,169 @@
+package taskqueue
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+)
+
+// EnvelopeSchemaVersion is the schema version new envelopes are written
+// with. Decoders accept every earlier version and upgrade it in place,
+// so a fleet can roll producers and consumers forward independently.
+const EnvelopeSchemaVersion = 2
+
+// ProducerVersion identifies the library build stamped into new
+// envelopes, resolved from the module's build info. It is informational:
+// consumers log it to attribute misbehaving producers during a rolling
+// upgrade, while compatibility decisions use the schema version alone.
+var ProducerVersion = producerVersion()
+
+// envelope is the wire form of a transported task or result. Exactly one
+// of Task and Result is set; Payload only appears in schema version 1,
+// which carried the document under a shared key, and is moved during the
+// upgrade pass.
+type envelope struct {
+	SchemaVersion   int             `json:"schema_version"`
+	ProducerVersion string          `json:"producer_version,omitempty"`
+	Payload         json.RawMessage `json:"payload,omitempty"`
+	Task            json.RawMessage `json:"task,omitempty"`
+	Result          json.RawMessage `json:"result,omitempty"`
+}
+
+// EncodeTaskEnvelope wraps the task in a current-version envelope for
+// transport between fleet instances.
+func EncodeTaskEnvelope(task *Task) ([]byte, error) {
+	if task == nil {
+		return nil, ErrNilTask
+	}
+	document, err := json.Marshal(task)
+	if err != nil {
+		return nil, fmt.Errorf("taskqueue: encode task envelope %s: %w", task.ID, err)
+	}
+	return json.Marshal(envelope{
+		SchemaVersion:   EnvelopeSchemaVersion,
+		ProducerVersion: ProducerVersion,
+		Task:            document,
+	})
+}
+
+// EncodeResultEnvelope wraps the result in a current-version envelope
+// for transport between fleet instances.
+func EncodeResultEnvelope(result *TaskResult) ([]byte, error) {
+	document, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("taskqueue: encode result envelope %s: %w", result.TaskID, err)
+	}
+	return json.Marshal(envelope{
+		SchemaVersion:   EnvelopeSchemaVersion,
+		ProducerVersion: ProducerVersion,
+		Result:          document,
+	})
+}
+
+// DecodeTaskEnvelope parses a task envelope from an untrusted producer,
+// upgrading earlier schema versions on the way in. Documents without a
+// schema version are treated as the bare pre-envelope form and go
+// through DecodeTask unchanged, so old producers keep working during a
+// rolling upgrade.
+func DecodeTaskEnvelope(data []byte) (*Task, error) {
+	env, err := decodeEnvelope(data)
+	if err != nil {
+		return nil, err
+	}
+	if env == nil {
+		return DecodeTask(data)
+	}
+	if len(env.Task) == 0 {
+		return nil, fmt.Errorf("%w: envelope carries no task", ErrInvalidTask)
+	}
+	return DecodeTask(env.Task)
+}
+
+// DecodeResultEnvelope parses a result envelope, upgrading earlier
+// schema versions on the way in. Like DecodeTaskEnvelope it accepts the
+// bare pre-envelope document form.
+func DecodeResultEnvelope(data []byte) (*TaskResult, error) {
+	env, err := decodeEnvelope(data)
+	if err != nil {
+		return nil, err
+	}
+	document := data
+	if env != nil {
+		if len(env.Result) == 0 {
+			return nil, fmt.Errorf("%w: envelope carries no result", ErrInvalidEnvelope)
+		}
+		document = env.Result
+	}
+	var result TaskResult
+	if err := json.Unmarshal(document, &result); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEnvelope, err)
+	}
+	if result.TaskID == "" {
+		return nil, fmt.Errorf("%w: result has no task_id", ErrInvalidEnvelope)
+	}
+	return &result, nil
+}
+
+// decodeEnvelope parses the envelope frame and upgrades it to the
+// current schema version one step at a time. A nil envelope with a nil
+// error means the document predates envelopes entirely and should be
+// decoded bare.
+func decodeEnvelope(data []byte) (*envelope, error) {
+	if len(data) > MaxDecodedTaskSize {
+		return nil, fmt.Errorf("%w: document exceeds %d bytes", ErrInvalidEnvelope, MaxDecodedTaskSize)
+	}
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEnvelope, err)
+	}
+	if env.SchemaVersion == 0 {
+		return nil, nil
+	}
+	if env.SchemaVersion > EnvelopeSchemaVersion {
+		return nil, fmt.Errorf("%w: schema version %d, this build understands up to %d",
+			ErrUnsupportedEnvelope, env.SchemaVersion, EnvelopeSchemaVersion)
+	}
+	for env.SchemaVersion < EnvelopeSchemaVersion {
+		if err := envelopeUpgrades[env.SchemaVersion](&env); err != nil {
+			return nil, err
+		}
+		env.SchemaVersion++
+	}
+	return &env, nil
+}
+
+// envelopeUpgrades move an envelope one schema version forward; entries
+// are keyed by the version they upgrade from. New versions add an entry
+// here instead of branching in the decoders.
+var envelopeUpgrades = map[int]func(*envelope) error{
+	// Version 1 carried the document under a shared payload key and
+	// relied on consumers to know whether it held a task or a result.
+	// Tell them apart by shape: results always carry task_id.
+	1: func(env *envelope) error {
+		if len(env.Payload) == 0 {
+			return fmt.Errorf("%w: version 1 envelope carries no payload", ErrInvalidEnvelope)
+		}
+		var probe struct {
+			TaskID string `json:"task_id"`
+		}
+		if err := json.Unmarshal(env.Payload, &probe); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidEnvelope, err)
+		}
+		if probe.TaskID != "" {
+			env.Result = env.Payload
+		} else {
+			env.Task = env.Payload
+		}
+		env.Payload = nil
+		return nil
+	},
+}
+
+// producerVersion resolves the module version of the running build,
+// falling back to "devel" for uninstalled builds and tests.
+func producerVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return "devel"
+}
@@ -0,0 +1,118 @@
+package taskqueue
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskEnvelopeRoundTrip(t *testing.T) {
+	task := NewTask("email", map[string]any{"to": "ops@example.com"})
+
+	data, err := EncodeTaskEnvelope(task)
+	require.NoError(t, err)
+
+	var frame map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &frame))
+	assert.JSONEq(t, fmt.Sprintf("%d", EnvelopeSchemaVersion), string(frame["schema_version"]))
+	assert.Contains(t, frame, "producer_version")
+
+	decoded, err := DecodeTaskEnvelope(data)
+	require.NoError(t, err)
+	assert.Equal(t, task.ID, decoded.ID)
+	assert.Equal(t, "email", decoded.Type)
+	assert.Equal(t, "ops@example.com", decoded.Data["to"])
+}
+
+func TestResultEnvelopeRoundTrip(t *testing.T) {
+	result := &TaskResult{
+		TaskID:      "t1",
+		TaskType:    "email",
+		Success:     true,
+		Output:      "sent",
+		Duration:    time.Second,
+		CompletedAt: time.Date(2026, 8, 28, 12, 0, 0, 0, time.UTC),
+	}
+
+	data, err := EncodeResultEnvelope(result)
+	require.NoError(t, err)
+
+	decoded, err := DecodeResultEnvelope(data)
+	require.NoError(t, err)
+	assert.Equal(t, result, decoded)
+}
+
+func TestDecodeTaskEnvelopeUpgradesVersionOne(t *testing.T) {
+	task := NewTask("email", nil)
+	payload, err := json.Marshal(task)
+	require.NoError(t, err)
+	frame, err := json.Marshal(map[string]any{
+		"schema_version": 1,
+		"payload":        json.RawMessage(payload),
+	})
+	require.NoError(t, err)
+
+	decoded, err := DecodeTaskEnvelope(frame)
+	require.NoError(t, err)
+	assert.Equal(t, task.ID, decoded.ID)
+}
+
+func TestDecodeResultEnvelopeUpgradesVersionOne(t *testing.T) {
+	frame := []byte(`{"schema_version":1,"payload":{"task_id":"t1","task_type":"email","success":true,"duration":0,"completed_at":"2026-08-28T12:00:00Z"}}`)
+
+	decoded, err := DecodeResultEnvelope(frame)
+	require.NoError(t, err)
+	assert.Equal(t, "t1", decoded.TaskID)
+	assert.True(t, decoded.Success)
+}
+
+func TestDecodeTaskEnvelopeAcceptsBareDocuments(t *testing.T) {
+	decoded, err := DecodeTaskEnvelope([]byte(`{"type":"email"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "email", decoded.Type)
+	assert.Equal(t, TaskStatusPending, decoded.Status)
+}
+
+func TestDecodeResultEnvelopeAcceptsBareDocuments(t *testing.T) {
+	decoded, err := DecodeResultEnvelope([]byte(`{"task_id":"t1","success":false,"error":"boom"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "t1", decoded.TaskID)
+	assert.Equal(t, "boom", decoded.Error)
+}
+
+func TestDecodeEnvelopeRejections(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want error
+	}{
+		{
+			name: "newer schema version",
+			data: fmt.Sprintf(`{"schema_version":%d,"task":{"type":"email"}}`, EnvelopeSchemaVersion+1),
+			want: ErrUnsupportedEnvelope,
+		},
+		{
+			name: "version one without payload",
+			data: `{"schema_version":1}`,
+			want: ErrInvalidEnvelope,
+		},
+		{
+			name: "current version without task",
+			data: fmt.Sprintf(`{"schema_version":%d}`, EnvelopeSchemaVersion),
+			want: ErrInvalidTask,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeTaskEnvelope([]byte(tt.data))
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+
+	_, err := DecodeResultEnvelope([]byte(fmt.Sprintf(`{"schema_version":%d}`, EnvelopeSchemaVersion)))
+	assert.ErrorIs(t, err, ErrInvalidEnvelope)
+}
@@ -0,0 +1,109 @@
+package taskqueue
+
+import "errors"
+
+// Sentinel errors returned by the queue. Callers should match them with
+// errors.Is since they may be wrapped with additional context.
+var (
+	// ErrNilTask is returned when a nil task is submitted.
+	ErrNilTask = errors.New("taskqueue: task is nil")
+
+	// ErrInvalidTask is returned when a task fails submission validation.
+	ErrInvalidTask = errors.New("taskqueue: invalid task")
+
+	// ErrInvalidStatus is returned for a status outside the known set.
+	ErrInvalidStatus = errors.New("taskqueue: invalid task status")
+
+	// ErrQueueFull is returned when the task buffer cannot accept more work.
+	ErrQueueFull = errors.New("taskqueue: queue is full")
+
+	// ErrQueueClosed is returned when using a queue after Close.
+	ErrQueueClosed = errors.New("taskqueue: queue is closed")
+
+	// ErrWorkerStopped is returned when submitting to a stopped worker.
+	ErrWorkerStopped = errors.New("taskqueue: worker is stopped")
+
+	// ErrTaskNotFound is returned when a task ID cannot be resolved.
+	ErrTaskNotFound = errors.New("taskqueue: task not found")
+
+	// ErrNoExecutor is returned when no executor is registered for a task type.
+	ErrNoExecutor = errors.New("taskqueue: no executor registered for task type")
+
+	// ErrInvalidSignature is returned when task signature verification fails.
+	ErrInvalidSignature = errors.New("taskqueue: invalid task signature")
+
+	// ErrMemoryPressure is returned when submissions are shed because the
+	// process is close to its memory limit.
+	ErrMemoryPressure = errors.New("taskqueue: shedding under memory pressure")
+
+	// ErrResultNotFound is returned when no result is stored for a task ID.
+	ErrResultNotFound = errors.New("taskqueue: result not found")
+
+	// ErrNoResultRepository is returned when querying results on a worker
+	// configured without a result repository.
+	ErrNoResultRepository = errors.New("taskqueue: no result repository configured")
+
+	// ErrTaskTypeDisabled is recorded for tasks whose type is switched
+	// off by a feature flag at dispatch time.
+	ErrTaskTypeDisabled = errors.New("taskqueue: task type disabled by flag")
+
+	// ErrNoTaskLister is returned by bulk operations when the repository
+	// cannot list tasks beyond the pending ones.
+	ErrNoTaskLister = errors.New("taskqueue: repository does not support listing tasks")
+
+	// ErrUnknownQueue is returned when a task is routed to a named queue
+	// that was not declared in the worker config.
+	ErrUnknownQueue = errors.New("taskqueue: unknown queue")
+
+	// ErrNoSearchIndex is returned by SearchTasks on a worker configured
+	// without a search index.
+	ErrNoSearchIndex = errors.New("taskqueue: no search index configured")
+
+	// ErrInvalidFilter is wrapped by ParseFilter for expressions it
+	// cannot understand.
+	ErrInvalidFilter = errors.New("taskqueue: invalid filter expression")
+
+	// ErrChaosInjected is the failure the Chaos injector substitutes for
+	// real dependency errors in stress tests.
+	ErrChaosInjected = errors.New("taskqueue: chaos-injected failure")
+
+	// ErrInvalidStatusRule is wrapped by RegisterStatus when a custom
+	// status or its transition rules are rejected.
+	ErrInvalidStatusRule = errors.New("taskqueue: invalid status rule")
+
+	// ErrNoDeadLetterQueue is returned when replaying dead letters on a
+	// worker configured without one.
+	ErrNoDeadLetterQueue = errors.New("taskqueue: no dead letter queue configured")
+
+	// ErrQuorumNotReached is returned by ScatterGather when too many
+	// scattered tasks fail for the quorum to ever be met.
+	ErrQuorumNotReached = errors.New("taskqueue: quorum not reached")
+
+	// ErrInvalidCommand is wrapped by the control plane for commands that
+	// are malformed, stale, unknown or fail signature verification.
+	ErrInvalidCommand = errors.New("taskqueue: invalid control command")
+
+	// ErrInvalidEnvelope is wrapped by the envelope decoders for frames
+	// that are malformed or missing their payload.
+	ErrInvalidEnvelope = errors.New("taskqueue: invalid envelope")
+
+	// ErrUnsupportedEnvelope is wrapped when an envelope declares a
+	// schema version newer than this build understands.
+	ErrUnsupportedEnvelope = errors.New("taskqueue: unsupported envelope version")
+
+	// ErrInvalidPlugin is wrapped by RegisterPlugin for manifests that
+	// are incomplete or collide with an already registered plugin.
+	ErrInvalidPlugin = errors.New("taskqueue: invalid plugin manifest")
+
+	// ErrRateLimited is returned by SubmitTask when the configured rate
+	// limiter rejects the submission.
+	ErrRateLimited = errors.New("taskqueue: submission rate limited")
+
+	// ErrDuplicateTask is wrapped by SubmitTask when deduplication has
+	// seen the task's dedup key within the TTL window.
+	ErrDuplicateTask = errors.New("taskqueue: duplicate task")
+
+	// ErrTaskDeadlineExceeded classifies execution failures caused by a
+	// task's own Timeout or Deadline running out.
+	ErrTaskDeadlineExceeded = errors.New("taskqueue: task deadline exceeded")
+)
@@ -0,0 +1,54 @@
+package taskqueue
+
+import (
+	"time"
+)
+
+// EventKind labels one step of a task's lifecycle.
+type EventKind string
+
+// Lifecycle event kinds emitted by the worker.
+const (
+	EventSubmitted EventKind = "submitted"
+	EventStarted   EventKind = "started"
+	EventCompleted EventKind = "completed"
+	EventFailed    EventKind = "failed"
+	EventExpired   EventKind = "expired"
+)
+
+// TaskEvent is one lifecycle event, suitable for streaming to live
+// debugging tools.
+type TaskEvent struct {
+	Kind   EventKind  `json:"kind"`
+	TaskID string     `json:"task_id"`
+	Type   string     `json:"type"`
+	Status TaskStatus `json:"status"`
+	At     time.Time  `json:"at"`
+	Error  string     `json:"error,omitempty"`
+}
+
+// SubscribeEvents returns a channel of lifecycle events and a cancel
+// function that must be called when done. The channel is buffered; when
+// the subscriber falls behind, events are dropped rather than blocking
+// workers. It is shorthand for subscribing to Bus().Lifecycle.
+func (w *Worker) SubscribeEvents(buffer int) (<-chan TaskEvent, func()) {
+	return w.bus.Lifecycle.Subscribe(buffer, DropNewest)
+}
+
+// emitEvent publishes one lifecycle step of a task to the bus. The
+// status is passed explicitly so the submit path does not read
+// task.Status while a worker goroutine may already be transitioning the
+// task.
+func (w *Worker) emitEvent(kind EventKind, task *Task, status TaskStatus, err error) {
+	event := TaskEvent{
+		Kind:   kind,
+		TaskID: task.ID,
+		Type:   task.Type,
+		Status: status,
+		At:     time.Now().UTC(),
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	w.bus.Lifecycle.Publish(event)
+}
@@ -0,0 +1,83 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func collectEvents(t *testing.T, ch <-chan TaskEvent, n int) []TaskEvent {
+	t.Helper()
+	events := make([]TaskEvent, 0, n)
+	for len(events) < n {
+		select {
+		case event := <-ch:
+			events = append(events, event)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out after %d of %d events", len(events), n)
+		}
+	}
+	return events
+}
+
+func TestWorkerEmitsLifecycleEvents(t *testing.T) {
+	worker := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), nil)
+	worker.Executors().Register("email", ExecutorFunc(func(_ context.Context, task *Task) (*TaskResult, error) {
+		if task.Data["fail"] == true {
+			return nil, assert.AnError
+		}
+		return &TaskResult{}, nil
+	}))
+
+	events, cancel := worker.SubscribeEvents(16)
+	defer cancel()
+
+	ctx := context.Background()
+	ok := NewTask("email", nil)
+	require.NoError(t, worker.SubmitTask(ctx, ok))
+	worker.Start()
+	require.NoError(t, worker.Shutdown(ctx))
+
+	got := collectEvents(t, events, 3)
+	assert.Equal(t, EventSubmitted, got[0].Kind)
+	assert.Equal(t, EventStarted, got[1].Kind)
+	assert.Equal(t, EventCompleted, got[2].Kind)
+	assert.Equal(t, ok.ID, got[2].TaskID)
+	assert.Equal(t, "email", got[2].Type)
+	assert.False(t, got[2].At.IsZero())
+}
+
+func TestFailedEventCarriesError(t *testing.T) {
+	worker := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), nil)
+	worker.Executors().Register("email", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		return nil, assert.AnError
+	}))
+
+	events, cancel := worker.SubscribeEvents(16)
+	defer cancel()
+
+	ctx := context.Background()
+	require.NoError(t, worker.SubmitTask(ctx, NewTask("email", nil)))
+	worker.Start()
+	require.NoError(t, worker.Shutdown(ctx))
+
+	got := collectEvents(t, events, 3)
+	assert.Equal(t, EventFailed, got[2].Kind)
+	assert.Contains(t, got[2].Error, assert.AnError.Error())
+}
+
+func TestSubscribeEventsUsesTheBus(t *testing.T) {
+	bus := NewEventBus()
+	worker := NewWorker(WorkerConfig{WorkerCount: 1, Bus: bus}, newMockRepository(), nil)
+	assert.Same(t, bus, worker.Bus())
+
+	events, cancel := bus.Lifecycle.Subscribe(4, DropNewest)
+	defer cancel()
+
+	require.NoError(t, worker.SubmitTask(context.Background(), NewTask("email", nil)))
+	got := collectEvents(t, events, 1)
+	assert.Equal(t, EventSubmitted, got[0].Kind)
+}
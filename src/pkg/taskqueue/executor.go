@@ -0,0 +1,16 @@
+package taskqueue
+
+import "context"
+
+// Executor performs the work for one task type.
+type Executor interface {
+	Execute(ctx context.Context, task *Task) (*TaskResult, error)
+}
+
+// ExecutorFunc adapts a function to the Executor interface.
+type ExecutorFunc func(ctx context.Context, task *Task) (*TaskResult, error)
+
+// Execute implements Executor.
+func (f ExecutorFunc) Execute(ctx context.Context, task *Task) (*TaskResult, error) {
+	return f(ctx, task)
+}
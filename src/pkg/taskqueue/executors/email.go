@@ -0,0 +1,50 @@
+package executors
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue"
+)
+
+// EmailExecutor sends plain-text email over SMTP. Tasks carry "to",
+// "subject" and "body" in their data.
+type EmailExecutor struct {
+	// Addr is the SMTP server address, host:port.
+	Addr string
+
+	// From is the envelope sender.
+	From string
+
+	// Auth is optional SMTP authentication.
+	Auth smtp.Auth
+
+	// send allows tests to stub the SMTP call.
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewEmailExecutor returns an executor sending through the given server.
+func NewEmailExecutor(addr, from string, auth smtp.Auth) *EmailExecutor {
+	return &EmailExecutor{Addr: addr, From: from, Auth: auth, send: smtp.SendMail}
+}
+
+// Execute implements taskqueue.Executor.
+func (e *EmailExecutor) Execute(_ context.Context, task *taskqueue.Task) (*taskqueue.TaskResult, error) {
+	to, err := stringParam(task, "to")
+	if err != nil {
+		return nil, err
+	}
+	subject := optionalParam(task, "subject", "(no subject)")
+	body := optionalParam(task, "body", "")
+
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s", e.From, to, subject, body)
+	sender := e.send
+	if sender == nil {
+		sender = smtp.SendMail
+	}
+	if err := sender(e.Addr, e.Auth, e.From, []string{to}, []byte(msg)); err != nil {
+		return nil, fmt.Errorf("executors: send email to %s: %w", to, err)
+	}
+	return &taskqueue.TaskResult{Output: fmt.Sprintf("email sent to %s", to)}, nil
+}
@@ -0,0 +1,42 @@
+// Package executors ships reference executors for common task types:
+// sending email over SMTP, calling HTTP webhooks, running allow-listed
+// shell commands and moving files between object stores. They demonstrate
+// the executor registry and give new users working building blocks.
+package executors
+
+import (
+	"fmt"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue"
+)
+
+// Default task types the reference executors register under.
+const (
+	TypeEmail    = "email"
+	TypeWebhook  = "webhook"
+	TypeShell    = "shell"
+	TypeFileMove = "filemove"
+)
+
+// stringParam extracts a required string field from the task data.
+func stringParam(task *taskqueue.Task, key string) (string, error) {
+	v, ok := task.Data[key]
+	if !ok {
+		return "", fmt.Errorf("%w: missing data key %q", taskqueue.ErrInvalidTask, key)
+	}
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return "", fmt.Errorf("%w: data key %q must be a non-empty string", taskqueue.ErrInvalidTask, key)
+	}
+	return s, nil
+}
+
+// optionalParam extracts an optional string field from the task data.
+func optionalParam(task *taskqueue.Task, key, fallback string) string {
+	if v, ok := task.Data[key]; ok {
+		if s, ok := v.(string); ok && s != "" {
+			return s
+		}
+	}
+	return fallback
+}
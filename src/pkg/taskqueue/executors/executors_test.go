@@ -0,0 +1,104 @@
+package executors
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue"
+)
+
+func TestEmailExecutor(t *testing.T) {
+	var gotTo []string
+	e := NewEmailExecutor("smtp.example.com:25", "queue@example.com", nil)
+	e.send = func(_ string, _ smtp.Auth, _ string, to []string, msg []byte) error {
+		gotTo = to
+		assert.Contains(t, string(msg), "Subject: hello")
+		return nil
+	}
+
+	task := &taskqueue.Task{Type: TypeEmail, Data: map[string]any{"to": "ops@example.com", "subject": "hello"}}
+	result, err := e.Execute(context.Background(), task)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ops@example.com"}, gotTo)
+	assert.Contains(t, result.Output, "ops@example.com")
+
+	_, err = e.Execute(context.Background(), &taskqueue.Task{Type: TypeEmail, Data: map[string]any{}})
+	assert.ErrorIs(t, err, taskqueue.ErrInvalidTask)
+}
+
+func TestWebhookExecutor(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		assert.Equal(t, http.MethodPost, r.Method)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	e := NewWebhookExecutor(srv.Client())
+	result, err := e.Execute(context.Background(), &taskqueue.Task{
+		Type: TypeWebhook,
+		Data: map[string]any{"url": srv.URL, "body": `{"ok":true}`},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, result.Output, "200")
+
+	_, err = e.Execute(context.Background(), &taskqueue.Task{
+		Type: TypeWebhook,
+		Data: map[string]any{"url": srv.URL + "/fail"},
+	})
+	assert.ErrorContains(t, err, "502")
+}
+
+func TestShellExecutorAllowList(t *testing.T) {
+	e := NewShellExecutor("echo")
+
+	result, err := e.Execute(context.Background(), &taskqueue.Task{
+		Type: TypeShell,
+		Data: map[string]any{"command": "echo", "args": []any{"hello"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", result.Output)
+
+	_, err = e.Execute(context.Background(), &taskqueue.Task{
+		Type: TypeShell,
+		Data: map[string]any{"command": "rm", "args": []any{"-rf", "/"}},
+	})
+	assert.ErrorIs(t, err, taskqueue.ErrInvalidTask)
+}
+
+func TestFileMoveExecutorLocalStore(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "in.txt"), []byte("data"), 0o640))
+
+	e := NewFileMoveExecutor(NewLocalStore(root))
+	_, err := e.Execute(context.Background(), &taskqueue.Task{
+		Type: TypeFileMove,
+		Data: map[string]any{"source": "in.txt", "destination": "archive/out.txt"},
+	})
+	require.NoError(t, err)
+
+	moved, err := os.ReadFile(filepath.Join(root, "archive", "out.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(moved))
+	assert.NoFileExists(t, filepath.Join(root, "in.txt"))
+}
+
+func TestRegisterDefaults(t *testing.T) {
+	registry := taskqueue.NewExecutorRegistry()
+	RegisterDefaults(registry, nil, NewWebhookExecutor(nil), NewShellExecutor("echo"), nil)
+
+	assert.ElementsMatch(t, []string{TypeWebhook, TypeShell}, registry.Types())
+	_, err := registry.Get(TypeEmail)
+	assert.ErrorIs(t, err, taskqueue.ErrNoExecutor)
+}
@@ -0,0 +1,121 @@
+package executors
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue"
+)
+
+// ObjectStore abstracts the storage a FileMoveExecutor moves objects
+// between, so the same executor works against the local filesystem, S3 or
+// any compatible blob store without this package importing an SDK.
+type ObjectStore interface {
+	// Move relocates the object at src to dst, removing the source.
+	Move(ctx context.Context, src, dst string) error
+}
+
+// FileMoveExecutor moves a file between object-store keys. Tasks carry
+// "source" and "destination" in their data.
+type FileMoveExecutor struct {
+	Store ObjectStore
+}
+
+// NewFileMoveExecutor returns an executor operating on the given store.
+func NewFileMoveExecutor(store ObjectStore) *FileMoveExecutor {
+	return &FileMoveExecutor{Store: store}
+}
+
+// Execute implements taskqueue.Executor.
+func (e *FileMoveExecutor) Execute(ctx context.Context, task *taskqueue.Task) (*taskqueue.TaskResult, error) {
+	src, err := stringParam(task, "source")
+	if err != nil {
+		return nil, err
+	}
+	dst, err := stringParam(task, "destination")
+	if err != nil {
+		return nil, err
+	}
+	if err := e.Store.Move(ctx, src, dst); err != nil {
+		return nil, fmt.Errorf("executors: move %s to %s: %w", src, dst, err)
+	}
+	return &taskqueue.TaskResult{Output: fmt.Sprintf("moved %s to %s", src, dst)}, nil
+}
+
+// LocalStore is an ObjectStore rooted in a directory of the local
+// filesystem. Keys are slash-separated paths relative to the root;
+// traversal outside the root is rejected.
+type LocalStore struct {
+	Root string
+}
+
+// NewLocalStore returns a store rooted at dir.
+func NewLocalStore(dir string) *LocalStore {
+	return &LocalStore{Root: dir}
+}
+
+// Move implements ObjectStore.
+func (s *LocalStore) Move(_ context.Context, src, dst string) error {
+	srcPath, err := s.resolve(src)
+	if err != nil {
+		return err
+	}
+	dstPath, err := s.resolve(dst)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dstPath), 0o750); err != nil {
+		return err
+	}
+	if err := os.Rename(srcPath, dstPath); err == nil {
+		return nil
+	}
+	// Rename can fail across filesystems; fall back to copy + remove.
+	in, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dstPath)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return os.Remove(srcPath)
+}
+
+// resolve maps a key to a path under the root, rejecting traversal.
+func (s *LocalStore) resolve(key string) (string, error) {
+	clean := filepath.Clean("/" + key)
+	if strings.Contains(clean, "..") {
+		return "", fmt.Errorf("executors: invalid object key %q", key)
+	}
+	return filepath.Join(s.Root, clean), nil
+}
+
+// RegisterDefaults registers the reference executors under their default
+// task types. Nil entries are skipped.
+func RegisterDefaults(registry *taskqueue.ExecutorRegistry, email *EmailExecutor, webhook *WebhookExecutor, shell *ShellExecutor, filemove *FileMoveExecutor) {
+	if email != nil {
+		registry.Register(TypeEmail, email)
+	}
+	if webhook != nil {
+		registry.Register(TypeWebhook, webhook)
+	}
+	if shell != nil {
+		registry.Register(TypeShell, shell)
+	}
+	if filemove != nil {
+		registry.Register(TypeFileMove, filemove)
+	}
+}
@@ -0,0 +1,73 @@
+package executors
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"slices"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue"
+)
+
+// ShellExecutor runs allow-listed commands. Tasks carry "command" and
+// optional "args" ([]string or []any of strings) in their data. Commands
+// outside the allow list are rejected, since task payloads may come from
+// untrusted producers.
+type ShellExecutor struct {
+	// AllowedCommands is the closed set of runnable binaries.
+	AllowedCommands []string
+}
+
+// NewShellExecutor returns an executor restricted to the given commands.
+func NewShellExecutor(allowed ...string) *ShellExecutor {
+	return &ShellExecutor{AllowedCommands: allowed}
+}
+
+// Execute implements taskqueue.Executor.
+func (e *ShellExecutor) Execute(ctx context.Context, task *taskqueue.Task) (*taskqueue.TaskResult, error) {
+	command, err := stringParam(task, "command")
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(e.AllowedCommands, command) {
+		return nil, fmt.Errorf("%w: command %q is not allow-listed", taskqueue.ErrInvalidTask, command)
+	}
+	args, err := argsParam(task)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("executors: run %s: %w: %s", command, err, out.String())
+	}
+	return &taskqueue.TaskResult{Output: out.String()}, nil
+}
+
+// argsParam reads the optional "args" list from the task data.
+func argsParam(task *taskqueue.Task) ([]string, error) {
+	v, ok := task.Data["args"]
+	if !ok {
+		return nil, nil
+	}
+	switch args := v.(type) {
+	case []string:
+		return args, nil
+	case []any:
+		out := make([]string, 0, len(args))
+		for _, a := range args {
+			s, ok := a.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: args must be strings", taskqueue.ErrInvalidTask)
+			}
+			out = append(out, s)
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("%w: args must be a string list", taskqueue.ErrInvalidTask)
+	}
+}
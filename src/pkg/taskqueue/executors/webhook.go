@@ -0,0 +1,60 @@
+package executors
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue"
+)
+
+// WebhookExecutor calls an HTTP endpoint. Tasks carry "url" and optional
+// "method" (default POST) and "body" in their data.
+type WebhookExecutor struct {
+	// Client is the HTTP client used for calls. Defaults to
+	// http.DefaultClient.
+	Client *http.Client
+}
+
+// NewWebhookExecutor returns an executor using the given client.
+func NewWebhookExecutor(client *http.Client) *WebhookExecutor {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &WebhookExecutor{Client: client}
+}
+
+// Execute implements taskqueue.Executor.
+func (e *WebhookExecutor) Execute(ctx context.Context, task *taskqueue.Task) (*taskqueue.TaskResult, error) {
+	url, err := stringParam(task, "url")
+	if err != nil {
+		return nil, err
+	}
+	method := optionalParam(task, "method", http.MethodPost)
+	body := optionalParam(task, "body", "")
+
+	req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("executors: build webhook request: %w", err)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	client := e.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executors: call webhook %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("executors: webhook %s returned status %d", url, resp.StatusCode)
+	}
+	return &taskqueue.TaskResult{Output: fmt.Sprintf("webhook %s returned %d", url, resp.StatusCode)}, nil
+}
@@ -0,0 +1,97 @@
+package taskqueue
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+)
+
+// Experiment deterministically buckets tasks into named variants by ID
+// hash, so processing strategies can be compared under identical load.
+// The same task always lands in the same variant, including on retries.
+type Experiment struct {
+	name     string
+	variants []string
+
+	mu    sync.Mutex
+	stats map[string]*VariantStats
+}
+
+// VariantStats counts the outcomes of one variant.
+type VariantStats struct {
+	Runs     uint64 `json:"runs"`
+	Failures uint64 `json:"failures"`
+}
+
+// NewExperiment defines an experiment over the given variants. At least
+// one variant is required; assignment is uniform across them.
+func NewExperiment(name string, variants ...string) *Experiment {
+	stats := make(map[string]*VariantStats, len(variants))
+	for _, variant := range variants {
+		stats[variant] = &VariantStats{}
+	}
+	return &Experiment{name: name, variants: variants, stats: stats}
+}
+
+// Name returns the experiment name.
+func (e *Experiment) Name() string {
+	return e.name
+}
+
+// Variant returns the variant the task ID hashes into.
+func (e *Experiment) Variant(taskID string) string {
+	h := fnv.New32a()
+	h.Write([]byte(e.name))
+	h.Write([]byte(taskID))
+	return e.variants[h.Sum32()%uint32(len(e.variants))]
+}
+
+// record counts one execution outcome for the task's variant.
+func (e *Experiment) record(taskID string, success bool) {
+	variant := e.Variant(taskID)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	stats := e.stats[variant]
+	stats.Runs++
+	if !success {
+		stats.Failures++
+	}
+}
+
+// Stats returns a copy of the per-variant counters.
+func (e *Experiment) Stats() map[string]VariantStats {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	out := make(map[string]VariantStats, len(e.stats))
+	for variant, stats := range e.stats {
+		out[variant] = *stats
+	}
+	return out
+}
+
+// experimentKey keys variant assignments in the execution context, one
+// entry per experiment name.
+type experimentKey string
+
+// VariantFromContext returns the task's assigned variant for the named
+// experiment, if the worker runs that experiment.
+func VariantFromContext(ctx context.Context, experiment string) (string, bool) {
+	variant, ok := ctx.Value(experimentKey(experiment)).(string)
+	return variant, ok
+}
+
+// assignExperiments stamps the execution context with the task's variant
+// for every configured experiment.
+func (w *Worker) assignExperiments(ctx context.Context, task *Task) context.Context {
+	for _, experiment := range w.config.Experiments {
+		ctx = context.WithValue(ctx, experimentKey(experiment.Name()), experiment.Variant(task.ID))
+	}
+	return ctx
+}
+
+// recordExperiments counts the execution outcome in every experiment.
+func (w *Worker) recordExperiments(task *Task, success bool) {
+	for _, experiment := range w.config.Experiments {
+		experiment.record(task.ID, success)
+	}
+}
@@ -0,0 +1,67 @@
+package taskqueue
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExperimentVariantIsDeterministic(t *testing.T) {
+	experiment := NewExperiment("batching", "control", "batched")
+
+	variant := experiment.Variant("task-1")
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, variant, experiment.Variant("task-1"))
+	}
+
+	// Over many IDs both variants are used.
+	seen := make(map[string]int)
+	for i := 0; i < 200; i++ {
+		seen[experiment.Variant(fmt.Sprintf("task-%d", i))]++
+	}
+	assert.Positive(t, seen["control"])
+	assert.Positive(t, seen["batched"])
+}
+
+func TestExecutorSeesVariantAndStatsAreCounted(t *testing.T) {
+	experiment := NewExperiment("batching", "control", "batched")
+	worker := NewWorker(WorkerConfig{WorkerCount: 1, Experiments: []*Experiment{experiment}}, newMockRepository(), nil)
+
+	variants := make(chan string, 4)
+	worker.Executors().Register("email", ExecutorFunc(func(ctx context.Context, task *Task) (*TaskResult, error) {
+		variant, ok := VariantFromContext(ctx, "batching")
+		require.True(t, ok)
+		variants <- variant
+		if variant == "batched" {
+			return nil, assert.AnError
+		}
+		return &TaskResult{}, nil
+	}))
+
+	ctx := context.Background()
+	submitted := 0
+	for i := 0; submitted < 4 && i < 100; i++ {
+		task := NewTask("email", nil)
+		require.NoError(t, worker.SubmitTask(ctx, task))
+		submitted++
+	}
+	worker.Start()
+	require.NoError(t, worker.Shutdown(ctx))
+	close(variants)
+
+	for variant := range variants {
+		assert.Contains(t, []string{"control", "batched"}, variant)
+	}
+
+	stats := experiment.Stats()
+	var runs uint64
+	for _, vs := range stats {
+		runs += vs.Runs
+	}
+	assert.EqualValues(t, 4, runs)
+	assert.Equal(t, stats["batched"].Runs, stats["batched"].Failures)
+	assert.Zero(t, stats["control"].Failures)
+}
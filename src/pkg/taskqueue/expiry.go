@@ -0,0 +1,84 @@
+package taskqueue
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// expire moves the task to the expired status and drops it from the hot
+// cache; expired tasks are never executed.
+func (w *Worker) expire(ctx context.Context, task *Task) {
+	w.transition(ctx, task, TaskStatusExpired)
+	w.stats.expired.Add(1)
+	w.cache.Delete(task.ID)
+	w.emitEvent(EventExpired, task, TaskStatusExpired, nil)
+	w.recordGroup(task, false)
+	w.recordScatter(task, nil)
+	w.log(ctx).Info("taskqueue: task expired", "task_id", task.ID, "expires_at", task.ExpiresAt)
+}
+
+// ExpirySweeper is the retention side of task TTLs: it periodically
+// marks pending tasks whose deadline passed as expired, covering tasks
+// that expire while sitting in the repository rather than in the queue.
+type ExpirySweeper struct {
+	worker *Worker
+}
+
+// NewExpirySweeper builds a sweeper for the given worker.
+func NewExpirySweeper(worker *Worker) *ExpirySweeper {
+	return &ExpirySweeper{worker: worker}
+}
+
+// Sweep expires all pending tasks past their deadline once and returns
+// how many it expired.
+func (s *ExpirySweeper) Sweep(ctx context.Context) (int, error) {
+	pending, err := s.worker.repo.ListPending(ctx, 0)
+	if err != nil {
+		return 0, fmt.Errorf("taskqueue: expiry sweep: %w", err)
+	}
+	now := time.Now().UTC()
+	expired := 0
+	for _, task := range pending {
+		if task.IsExpired(now) {
+			s.worker.expire(ctx, task)
+			expired++
+		}
+	}
+	return expired, nil
+}
+
+// Run sweeps at the given interval until ctx is cancelled. The first
+// sweep is delayed by a deterministic per-instance jitter and, when the
+// repository implements SweepLocker, each round only sweeps after
+// winning the expiry lock, so a fleet does not scan the repository in
+// lockstep.
+func (s *ExpirySweeper) Run(ctx context.Context, interval time.Duration) {
+	if delay := sweepJitter(s.worker.instanceID, interval); delay > 0 {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			held, err := acquireSweepLock(ctx, s.worker.repo, sweepLockExpiry, s.worker.instanceID, interval)
+			if err != nil {
+				s.worker.logger.Error("taskqueue: expiry sweep lock failed", "error", err)
+				continue
+			}
+			if !held {
+				continue
+			}
+			if _, err := s.Sweep(ctx); err != nil {
+				s.worker.logger.Error("taskqueue: expiry sweep failed", "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskIsExpired(t *testing.T) {
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		want      bool
+	}{
+		{"no deadline", time.Time{}, false},
+		{"future deadline", now.Add(time.Hour), false},
+		{"past deadline", now.Add(-time.Minute), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := NewTask("email", nil)
+			task.ExpiresAt = tt.expiresAt
+			assert.Equal(t, tt.want, task.IsExpired(now))
+		})
+	}
+}
+
+func TestDispatcherSkipsExpiredTasks(t *testing.T) {
+	repo := newMockRepository()
+	worker := NewWorker(WorkerConfig{WorkerCount: 1}, repo, nil)
+	executed := false
+	worker.Executors().Register("notify", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		executed = true
+		return &TaskResult{}, nil
+	}))
+
+	ctx := context.Background()
+	task := NewTask("notify", nil)
+	task.ExpiresAt = time.Now().UTC().Add(20 * time.Millisecond)
+	require.NoError(t, worker.SubmitTask(ctx, task))
+
+	// Let the deadline pass while the task is still buffered.
+	time.Sleep(40 * time.Millisecond)
+	worker.Start()
+	require.NoError(t, worker.Shutdown(ctx))
+
+	assert.False(t, executed)
+	assert.Equal(t, TaskStatusExpired, task.Status)
+	assert.EqualValues(t, 1, worker.Stats().Expired)
+	stored, err := repo.GetByID(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, TaskStatusExpired, stored.Status)
+}
+
+func TestSubmitRejectsAlreadyExpiredTask(t *testing.T) {
+	worker := NewWorker(WorkerConfig{}, newMockRepository(), nil)
+
+	task := NewTask("notify", nil)
+	task.ExpiresAt = time.Now().UTC().Add(-time.Second)
+	assert.ErrorIs(t, worker.SubmitTask(context.Background(), task), ErrInvalidTask)
+}
+
+func TestExpirySweeperMarksPendingTasks(t *testing.T) {
+	repo := newMockRepository()
+	worker := NewWorker(WorkerConfig{}, repo, nil)
+
+	ctx := context.Background()
+	fresh := NewTask("notify", nil)
+	fresh.ExpiresAt = time.Now().UTC().Add(time.Hour)
+	stale := NewTask("notify", nil)
+	stale.ExpiresAt = time.Now().UTC().Add(10 * time.Millisecond)
+	require.NoError(t, worker.SubmitTask(ctx, fresh))
+	require.NoError(t, worker.SubmitTask(ctx, stale))
+	time.Sleep(20 * time.Millisecond)
+
+	expired, err := NewExpirySweeper(worker).Sweep(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+
+	stored, err := repo.GetByID(ctx, stale.ID)
+	require.NoError(t, err)
+	assert.Equal(t, TaskStatusExpired, stored.Status)
+	kept, err := repo.GetByID(ctx, fresh.ID)
+	require.NoError(t, err)
+	assert.Equal(t, TaskStatusPending, kept.Status)
+}
+
+func TestTaskIsExpiredByDeadline(t *testing.T) {
+	now := time.Now().UTC()
+	task := NewTask("email", nil)
+	assert.False(t, task.IsExpired(now))
+
+	task.Deadline = now.Add(time.Hour)
+	assert.False(t, task.IsExpired(now))
+
+	task.Deadline = now.Add(-time.Minute)
+	assert.True(t, task.IsExpired(now), "a lapsed execution deadline expires buffered work")
+}
+
+func TestDispatcherExpiresTaskWhoseDeadlinePassed(t *testing.T) {
+	repo := newMockRepository()
+	worker := NewWorker(WorkerConfig{WorkerCount: 1}, repo, nil)
+	executed := false
+	worker.Executors().Register("notify", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		executed = true
+		return &TaskResult{}, nil
+	}))
+
+	ctx := context.Background()
+	task := NewTask("notify", nil)
+	task.Deadline = time.Now().UTC().Add(20 * time.Millisecond)
+	require.NoError(t, worker.SubmitTask(ctx, task))
+
+	// Let the deadline pass while the task is still buffered.
+	time.Sleep(40 * time.Millisecond)
+	worker.Start()
+	require.NoError(t, worker.Shutdown(ctx))
+
+	assert.False(t, executed)
+	assert.Equal(t, TaskStatusExpired, task.Status, "a lapsed deadline expires the task instead of failing it")
+	assert.Zero(t, worker.Stats().Failed)
+}
@@ -0,0 +1,85 @@
+package taskqueue
+
+import (
+	"regexp"
+	"sort"
+	"sync"
+)
+
+// Patterns collapsed by normalizeFailureReason so error strings differing
+// only in IDs, counts or quoted values land in the same bucket.
+var (
+	quotedPattern = regexp.MustCompile(`"[^"]*"|'[^']*'`)
+	hexPattern    = regexp.MustCompile(`\b[0-9a-fA-F]{8,}\b`)
+	numberPattern = regexp.MustCompile(`\d+`)
+)
+
+// normalizeFailureReason buckets an error string by replacing volatile
+// fragments (quoted values, identifiers, numbers) with placeholders.
+func normalizeFailureReason(msg string) string {
+	msg = quotedPattern.ReplaceAllString(msg, `"…"`)
+	msg = hexPattern.ReplaceAllString(msg, "#")
+	msg = numberPattern.ReplaceAllString(msg, "#")
+	return msg
+}
+
+// ErrorCount is one bucket of the failure histogram.
+type ErrorCount struct {
+	Reason string `json:"reason"`
+	Count  int    `json:"count"`
+}
+
+// failureStats counts normalized failure reasons per task type.
+type failureStats struct {
+	mu     sync.Mutex
+	byType map[string]map[string]int
+}
+
+func newFailureStats() *failureStats {
+	return &failureStats{byType: make(map[string]map[string]int)}
+}
+
+// Record buckets one failure.
+func (s *failureStats) Record(taskType string, err error) {
+	reason := normalizeFailureReason(err.Error())
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reasons, ok := s.byType[taskType]
+	if !ok {
+		reasons = make(map[string]int)
+		s.byType[taskType] = reasons
+	}
+	reasons[reason]++
+}
+
+// Top returns the n most frequent reasons per task type, most frequent
+// first, ties broken alphabetically for stable output.
+func (s *failureStats) Top(n int) map[string][]ErrorCount {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	top := make(map[string][]ErrorCount, len(s.byType))
+	for taskType, reasons := range s.byType {
+		counts := make([]ErrorCount, 0, len(reasons))
+		for reason, count := range reasons {
+			counts = append(counts, ErrorCount{Reason: reason, Count: count})
+		}
+		sort.Slice(counts, func(i, j int) bool {
+			if counts[i].Count != counts[j].Count {
+				return counts[i].Count > counts[j].Count
+			}
+			return counts[i].Reason < counts[j].Reason
+		})
+		if n > 0 && len(counts) > n {
+			counts = counts[:n]
+		}
+		top[taskType] = counts
+	}
+	return top
+}
+
+// TopErrors returns the n most frequent normalized failure reasons per
+// task type, so the dominant failure mode is visible without scanning
+// logs.
+func (w *Worker) TopErrors(n int) map[string][]ErrorCount {
+	return w.failures.Top(n)
+}
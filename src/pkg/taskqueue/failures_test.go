@@ -0,0 +1,62 @@
+package taskqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeFailureReason(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		want string
+	}{
+		{"plain", "connection refused", "connection refused"},
+		{"numbers", "status 503 after 3 attempts", "status # after # attempts"},
+		{"hex id", "task a1b2c3d4e5f60718 not found", "task # not found"},
+		{"quoted value", `unknown host "db-7.internal"`, `unknown host "…"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, normalizeFailureReason(tt.msg))
+		})
+	}
+}
+
+func TestFailureStatsTop(t *testing.T) {
+	stats := newFailureStats()
+	for i := 0; i < 3; i++ {
+		stats.Record("email", fmt.Errorf("smtp timeout after %dms", 100+i))
+	}
+	stats.Record("email", errors.New("connection refused"))
+	stats.Record("webhook", errors.New("status 500"))
+
+	top := stats.Top(1)
+	require.Len(t, top["email"], 1)
+	assert.Equal(t, ErrorCount{Reason: "smtp timeout after #ms", Count: 3}, top["email"][0])
+	assert.Equal(t, ErrorCount{Reason: "status #", Count: 1}, top["webhook"][0])
+}
+
+func TestWorkerTopErrors(t *testing.T) {
+	worker := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), nil)
+	worker.Executors().Register("boom", ExecutorFunc(func(_ context.Context, task *Task) (*TaskResult, error) {
+		return nil, fmt.Errorf("shard %v unavailable", task.Data["shard"])
+	}))
+
+	ctx := context.Background()
+	for _, shard := range []int{1, 2, 3} {
+		require.NoError(t, worker.SubmitTask(ctx, NewTask("boom", map[string]any{"shard": shard})))
+	}
+	worker.Start()
+	require.NoError(t, worker.Shutdown(ctx))
+
+	top := worker.TopErrors(5)
+	require.Len(t, top["boom"], 1)
+	assert.Equal(t, 3, top["boom"][0].Count)
+	assert.Equal(t, "shard # unavailable", top["boom"][0].Reason)
+}
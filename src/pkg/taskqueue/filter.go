@@ -0,0 +1,154 @@
+package taskqueue
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ParseFilter turns a small filter expression into a TaskFilter, so CLIs
+// and the admin API can accept human-written selections for list and
+// bulk operations. The syntax is clauses joined by AND:
+//
+//	status = failed AND type = "email" AND created > -24h
+//
+// Fields are status and type (operator =) and created/updated (operators
+// > and <). created bounds CreatedAt via CreatedSince and CreatedUntil;
+// updated bounds UpdatedAt via Since and Until. Each time field takes at
+// most one lower and one upper bound, so ranges like created > -24h AND
+// created < -1h work. Values may be bare words or double-quoted strings;
+// times are either relative durations such as -24h or absolute RFC 3339
+// timestamps and 2006-01-02 dates. Errors wrap ErrInvalidFilter.
+func ParseFilter(input string) (TaskFilter, error) {
+	tokens, err := scanFilter(input)
+	if err != nil {
+		return TaskFilter{}, err
+	}
+
+	var filter TaskFilter
+	seen := make(map[string]bool)
+	for len(tokens) > 0 {
+		if len(tokens) < 3 {
+			return TaskFilter{}, fmt.Errorf("%w: incomplete clause near %q", ErrInvalidFilter, strings.Join(tokens, " "))
+		}
+		field, op, value := strings.ToLower(tokens[0]), tokens[1], tokens[2]
+		tokens = tokens[3:]
+		// Time fields take one clause per direction, so a lower and an
+		// upper bound on the same field form a range.
+		if seen[field+op] {
+			return TaskFilter{}, fmt.Errorf("%w: duplicate clause for %q", ErrInvalidFilter, field)
+		}
+		seen[field+op] = true
+
+		switch field {
+		case "status":
+			if op != "=" {
+				return TaskFilter{}, fmt.Errorf("%w: status only supports =", ErrInvalidFilter)
+			}
+			status := TaskStatus(strings.ToLower(value))
+			if !IsValidStatus(status) {
+				return TaskFilter{}, fmt.Errorf("%w: unknown status %q", ErrInvalidFilter, value)
+			}
+			filter.Status = status
+		case "type":
+			if op != "=" {
+				return TaskFilter{}, fmt.Errorf("%w: type only supports =", ErrInvalidFilter)
+			}
+			filter.Type = value
+		case "created":
+			at, err := parseFilterTime(value)
+			if err != nil {
+				return TaskFilter{}, err
+			}
+			switch op {
+			case ">":
+				filter.CreatedSince = at
+			case "<":
+				filter.CreatedUntil = at
+			default:
+				return TaskFilter{}, fmt.Errorf("%w: %s only supports > and <", ErrInvalidFilter, field)
+			}
+		case "updated":
+			at, err := parseFilterTime(value)
+			if err != nil {
+				return TaskFilter{}, err
+			}
+			switch op {
+			case ">":
+				filter.Since = at
+			case "<":
+				filter.Until = at
+			default:
+				return TaskFilter{}, fmt.Errorf("%w: %s only supports > and <", ErrInvalidFilter, field)
+			}
+		default:
+			return TaskFilter{}, fmt.Errorf("%w: unknown field %q", ErrInvalidFilter, field)
+		}
+
+		if len(tokens) > 0 {
+			if !strings.EqualFold(tokens[0], "and") {
+				return TaskFilter{}, fmt.Errorf("%w: expected AND, got %q", ErrInvalidFilter, tokens[0])
+			}
+			tokens = tokens[1:]
+			if len(tokens) == 0 {
+				return TaskFilter{}, fmt.Errorf("%w: dangling AND", ErrInvalidFilter)
+			}
+		}
+	}
+	return filter, nil
+}
+
+// parseFilterTime resolves a filter time value: a relative duration such
+// as -24h against the current clock, or an absolute timestamp.
+func parseFilterTime(value string) (time.Time, error) {
+	if strings.HasPrefix(value, "-") || strings.HasPrefix(value, "+") {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: bad duration %q", ErrInvalidFilter, value)
+		}
+		return time.Now().Add(d), nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, time.RFC3339, "2006-01-02"} {
+		if at, err := time.Parse(layout, value); err == nil {
+			return at, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: bad time %q", ErrInvalidFilter, value)
+}
+
+// scanFilter splits the expression into word, quoted-string and operator
+// tokens.
+func scanFilter(input string) ([]string, error) {
+	var tokens []string
+	runes := []rune(input)
+	for n := 0; n < len(runes); {
+		r := runes[n]
+		switch {
+		case unicode.IsSpace(r):
+			n++
+		case r == '=' || r == '>' || r == '<':
+			tokens = append(tokens, string(r))
+			n++
+		case r == '"':
+			end := n + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("%w: unterminated string", ErrInvalidFilter)
+			}
+			tokens = append(tokens, string(runes[n+1:end]))
+			n = end + 1
+		default:
+			end := n
+			for end < len(runes) && !unicode.IsSpace(runes[end]) &&
+				runes[end] != '=' && runes[end] != '>' && runes[end] != '<' && runes[end] != '"' {
+				end++
+			}
+			tokens = append(tokens, string(runes[n:end]))
+			n = end
+		}
+	}
+	return tokens, nil
+}
@@ -0,0 +1,115 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		check func(t *testing.T, f TaskFilter)
+	}{
+		{
+			name:  "status and quoted type",
+			input: `status = failed AND type = "email"`,
+			check: func(t *testing.T, f TaskFilter) {
+				assert.Equal(t, TaskStatusFailed, f.Status)
+				assert.Equal(t, "email", f.Type)
+			},
+		},
+		{
+			name:  "relative time window",
+			input: "created > -24h",
+			check: func(t *testing.T, f TaskFilter) {
+				assert.WithinDuration(t, time.Now().Add(-24*time.Hour), f.CreatedSince, time.Minute)
+			},
+		},
+		{
+			name:  "created range",
+			input: "created > -24h AND created < -1h",
+			check: func(t *testing.T, f TaskFilter) {
+				assert.WithinDuration(t, time.Now().Add(-24*time.Hour), f.CreatedSince, time.Minute)
+				assert.WithinDuration(t, time.Now().Add(-time.Hour), f.CreatedUntil, time.Minute)
+			},
+		},
+		{
+			name:  "absolute until",
+			input: "updated < 2026-01-02",
+			check: func(t *testing.T, f TaskFilter) {
+				assert.Equal(t, 2026, f.Until.Year())
+			},
+		},
+		{
+			name:  "empty matches everything",
+			input: "",
+			check: func(t *testing.T, f TaskFilter) {
+				assert.Equal(t, TaskFilter{}, f)
+			},
+		},
+		{
+			name:  "lowercase and keyword",
+			input: "status = pending and type = report",
+			check: func(t *testing.T, f TaskFilter) {
+				assert.Equal(t, TaskStatusPending, f.Status)
+				assert.Equal(t, "report", f.Type)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := ParseFilter(tt.input)
+			require.NoError(t, err)
+			tt.check(t, filter)
+		})
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"unknown field", "priority = high"},
+		{"unknown status", "status = sleeping"},
+		{"wrong operator", "status > failed"},
+		{"incomplete clause", "status ="},
+		{"missing AND", `status = failed type = email`},
+		{"dangling AND", "status = failed AND"},
+		{"duplicate clause", "type = a AND type = b"},
+		{"duplicate bound", "created > -24h AND created > -1h"},
+		{"bad duration", "created > -24x"},
+		{"bad time", "created > yesterday"},
+		{"unterminated string", `type = "email`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFilter(tt.input)
+			assert.ErrorIs(t, err, ErrInvalidFilter)
+		})
+	}
+}
+
+func TestParseFilterDrivesListTasks(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockRepository()
+	worker := NewWorker(WorkerConfig{}, repo, nil)
+	defer worker.Shutdown(ctx)
+
+	require.NoError(t, repo.Save(ctx, &Task{ID: "f1", Type: "email", Status: TaskStatusFailed}))
+	require.NoError(t, repo.Save(ctx, &Task{ID: "p1", Type: "email", Status: TaskStatusPending}))
+
+	filter, err := ParseFilter("status = failed AND type = email")
+	require.NoError(t, err)
+	tasks, err := worker.ListTasks(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "f1", tasks[0].ID)
+}
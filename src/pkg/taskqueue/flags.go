@@ -0,0 +1,72 @@
+package taskqueue
+
+import (
+	"fmt"
+	"sync"
+)
+
+// FlagProvider answers runtime feature-flag queries, bridging to an
+// external flag system so task behavior can change without a restart.
+// Implementations must be safe for concurrent use; dispatch consults
+// them on every task.
+type FlagProvider interface {
+	// Enabled returns the flag's value and whether the flag is defined.
+	// Undefined flags leave the default behavior in place.
+	Enabled(name string) (value bool, ok bool)
+}
+
+// Flag names consulted by the worker. Subsystems added later follow the
+// same "taskqueue.<subsystem>.<subject>" shape.
+const disableFlagPrefix = "taskqueue.disable."
+
+// DisableFlag returns the flag name that disables dispatch of a task
+// type.
+func DisableFlag(taskType string) string {
+	return disableFlagPrefix + taskType
+}
+
+// StaticFlags is an in-process FlagProvider backed by a mutable map,
+// useful for tests and deployments without an external flag system.
+type StaticFlags struct {
+	mu    sync.RWMutex
+	flags map[string]bool
+}
+
+// NewStaticFlags returns a provider with the given initial flags.
+func NewStaticFlags(flags map[string]bool) *StaticFlags {
+	copied := make(map[string]bool, len(flags))
+	for name, value := range flags {
+		copied[name] = value
+	}
+	return &StaticFlags{flags: copied}
+}
+
+// Set defines or updates a flag at runtime.
+func (f *StaticFlags) Set(name string, value bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.flags[name] = value
+}
+
+// Enabled implements FlagProvider.
+func (f *StaticFlags) Enabled(name string) (bool, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	value, ok := f.flags[name]
+	return value, ok
+}
+
+// typeDisabled reports whether dispatch of the task type is currently
+// switched off by a flag.
+func (w *Worker) typeDisabled(taskType string) bool {
+	if w.config.Flags == nil {
+		return false
+	}
+	disabled, ok := w.config.Flags.Enabled(DisableFlag(taskType))
+	return ok && disabled
+}
+
+// errTypeDisabled builds the dispatch error for a disabled type.
+func errTypeDisabled(taskType string) error {
+	return fmt.Errorf("%w: %q", ErrTaskTypeDisabled, taskType)
+}
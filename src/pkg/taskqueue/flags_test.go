@@ -0,0 +1,65 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticFlags(t *testing.T) {
+	flags := NewStaticFlags(map[string]bool{"a": true})
+
+	value, ok := flags.Enabled("a")
+	assert.True(t, ok)
+	assert.True(t, value)
+
+	_, ok = flags.Enabled("missing")
+	assert.False(t, ok)
+
+	flags.Set("a", false)
+	value, ok = flags.Enabled("a")
+	assert.True(t, ok)
+	assert.False(t, value)
+}
+
+func TestDispatchHonoursDisableFlag(t *testing.T) {
+	flags := NewStaticFlags(map[string]bool{DisableFlag("email"): true})
+	results := newMockResultRepository()
+	worker := NewWorker(WorkerConfig{WorkerCount: 1, Flags: flags, Results: results}, newMockRepository(), nil)
+	executed := false
+	worker.Executors().Register("email", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		executed = true
+		return &TaskResult{}, nil
+	}))
+
+	ctx := context.Background()
+	disabled := NewTask("email", nil)
+	require.NoError(t, worker.SubmitTask(ctx, disabled))
+	worker.Start()
+	require.NoError(t, worker.Shutdown(ctx))
+
+	assert.False(t, executed)
+	assert.Equal(t, TaskStatusFailed, disabled.Status)
+	result, err := worker.GetResult(ctx, disabled.ID)
+	require.NoError(t, err)
+	assert.ErrorContains(t, errTypeDisabled("email"), "disabled")
+	assert.Contains(t, result.Error, "disabled")
+}
+
+func TestDispatchRunsWhenFlagCleared(t *testing.T) {
+	flags := NewStaticFlags(nil)
+	worker := NewWorker(WorkerConfig{WorkerCount: 1, Flags: flags}, newMockRepository(), nil)
+	executed := false
+	worker.Executors().Register("email", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		executed = true
+		return &TaskResult{}, nil
+	}))
+
+	ctx := context.Background()
+	require.NoError(t, worker.SubmitTask(ctx, NewTask("email", nil)))
+	worker.Start()
+	require.NoError(t, worker.Shutdown(ctx))
+	assert.True(t, executed)
+}
@@ -0,0 +1,76 @@
+package taskqueue
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func FuzzDecodeTask(f *testing.F) {
+	f.Add([]byte(`{"type":"email","data":{"to":"ops@example.com"}}`))
+	f.Add([]byte(`{"id":"t1","type":"report","status":"pending","priority":3}`))
+	f.Add([]byte(`{"type":"email","status":"sleeping"}`))
+	f.Add([]byte(`{`))
+	f.Add([]byte(``))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		task, err := DecodeTask(data)
+		if err != nil {
+			return
+		}
+		// Every accepted task must satisfy the core invariants.
+		if task.ID == "" {
+			t.Error("accepted task without ID")
+		}
+		if task.Type == "" {
+			t.Error("accepted task without type")
+		}
+		if !IsValidStatus(task.Status) {
+			t.Errorf("accepted task with status %q", task.Status)
+		}
+		if task.Priority < 0 || task.Retries < 0 || task.MaxRetries < 0 {
+			t.Error("accepted task with negative counters")
+		}
+	})
+}
+
+func FuzzValidateRequest(f *testing.F) {
+	f.Add([]byte(`{"type":"email"}`))
+	f.Add([]byte(`{"type":"","priority":-1}`))
+	f.Add([]byte(`{"type":"report","max_retries":3,"data":{"k":"v"}}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var request CreateTaskRequest
+		if err := json.Unmarshal(data, &request); err != nil {
+			return
+		}
+		if err := request.Validate(); err != nil {
+			return
+		}
+		// A validated request must convert into a submittable task.
+		task := request.ToTask()
+		if task.Type == "" || task.Status != TaskStatusPending {
+			t.Errorf("validated request produced invalid task: %+v", task)
+		}
+	})
+}
+
+func FuzzFilterExpression(f *testing.F) {
+	f.Add(`status = failed AND type = "email"`)
+	f.Add(`created > -24h AND updated < 2026-01-02`)
+	f.Add(`type = "unterminated`)
+	f.Add(`AND AND AND`)
+	f.Add(``)
+
+	f.Fuzz(func(t *testing.T, input string) {
+		filter, err := ParseFilter(input)
+		if err != nil {
+			return
+		}
+		// Accepted filters must be usable: Matches must not panic and a
+		// present status must be a real one.
+		if filter.Status != "" && !IsValidStatus(filter.Status) {
+			t.Errorf("accepted filter with status %q", filter.Status)
+		}
+		filter.Matches(&Task{Type: "email", Status: TaskStatusPending})
+	})
+}
@@ -0,0 +1,128 @@
+package taskqueue
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"runtime"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+)
+
+// shedTopic is the topic memory shed events are published to.
+const shedTopic = "task.shed"
+
+// MemoryGovernorConfig tunes a MemoryGovernor. Every field has a usable
+// default.
+type MemoryGovernorConfig struct {
+	// Margin is the fraction of the memory limit at which intake starts
+	// shedding. Defaults to 0.9 (shed within the last 10% of headroom).
+	Margin float64
+
+	// Limit overrides the memory limit in bytes. Defaults to GOMEMLIMIT
+	// (via debug.SetMemoryLimit); when no limit is set the governor
+	// never sheds.
+	Limit int64
+
+	// Publisher, when set, receives ShedEvent messages on topic
+	// "task.shed" whenever shedding starts or stops.
+	Publisher Publisher
+
+	// Bus, when set, additionally publishes transitions to its Shed
+	// topic for in-process subscribers.
+	Bus *EventBus
+
+	// Logger receives shed transitions. Defaults to slog.Default.
+	Logger *slog.Logger
+}
+
+// ShedEvent describes one shedding transition.
+type ShedEvent struct {
+	Shedding  bool      `json:"shedding"`
+	HeapAlloc uint64    `json:"heap_alloc"`
+	Limit     int64     `json:"limit"`
+	At        time.Time `json:"at"`
+}
+
+// MemoryGovernor monitors runtime memory against GOMEMLIMIT and flags
+// when submissions should be shed, so payload-heavy bursts degrade into
+// rejections instead of OOM kills. Workers configured with a governor
+// reject submissions with ErrMemoryPressure while it sheds.
+type MemoryGovernor struct {
+	config   MemoryGovernorConfig
+	shedding atomic.Bool
+}
+
+// NewMemoryGovernor builds a governor, applying defaults for zero config
+// fields.
+func NewMemoryGovernor(config MemoryGovernorConfig) *MemoryGovernor {
+	if config.Margin <= 0 || config.Margin > 1 {
+		config.Margin = 0.9
+	}
+	if config.Limit == 0 {
+		config.Limit = debug.SetMemoryLimit(-1)
+	}
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+	return &MemoryGovernor{config: config}
+}
+
+// Shedding reports whether intake should currently be shed.
+func (g *MemoryGovernor) Shedding() bool {
+	return g.shedding.Load()
+}
+
+// Check samples memory once, updates the shedding state and publishes a
+// ShedEvent on transitions. It returns the new state.
+func (g *MemoryGovernor) Check(ctx context.Context) bool {
+	if g.config.Limit <= 0 || g.config.Limit == int64(^uint64(0)>>1) {
+		// No GOMEMLIMIT configured; nothing to govern against.
+		return false
+	}
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	shed := mem.HeapAlloc >= uint64(float64(g.config.Limit)*g.config.Margin)
+	if g.shedding.Swap(shed) == shed {
+		return shed
+	}
+
+	event := ShedEvent{Shedding: shed, HeapAlloc: mem.HeapAlloc, Limit: g.config.Limit, At: time.Now().UTC()}
+	if shed {
+		g.config.Logger.Warn("taskqueue: memory pressure, shedding intake", "heap_alloc", mem.HeapAlloc, "limit", g.config.Limit)
+	} else {
+		g.config.Logger.Info("taskqueue: memory pressure cleared, resuming intake", "heap_alloc", mem.HeapAlloc, "limit", g.config.Limit)
+	}
+	if g.config.Bus != nil {
+		g.config.Bus.Shed.Publish(event)
+	}
+	if g.config.Publisher != nil {
+		payload, err := json.Marshal(event)
+		if err == nil {
+			if err := g.config.Publisher.Publish(ctx, shedTopic, payload); err != nil {
+				g.config.Logger.Error("taskqueue: publish shed event failed", "error", err)
+			}
+		}
+	}
+	return shed
+}
+
+// Run checks at the given interval until ctx is cancelled. A second or
+// less is a reasonable interval; defaults to one second when zero.
+func (g *MemoryGovernor) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			g.Check(ctx)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package taskqueue
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryGovernorShedsAndRecovers(t *testing.T) {
+	publisher := newMockPublisher()
+	governor := NewMemoryGovernor(MemoryGovernorConfig{
+		// A 1-byte limit guarantees heap usage is above the margin.
+		Limit:     1,
+		Publisher: publisher,
+	})
+
+	ctx := context.Background()
+	assert.True(t, governor.Check(ctx))
+	assert.True(t, governor.Shedding())
+
+	// Repeated checks in the same state publish nothing new.
+	governor.Check(ctx)
+	require.Len(t, publisher.messages[shedTopic], 1)
+
+	var event ShedEvent
+	require.NoError(t, json.Unmarshal(publisher.messages[shedTopic][0], &event))
+	assert.True(t, event.Shedding)
+	assert.Positive(t, event.HeapAlloc)
+
+	// Raising the limit clears the pressure and publishes the recovery.
+	governor.config.Limit = 1 << 50
+	assert.False(t, governor.Check(ctx))
+	assert.False(t, governor.Shedding())
+	assert.Len(t, publisher.messages[shedTopic], 2)
+}
+
+func TestMemoryGovernorWithoutLimitNeverSheds(t *testing.T) {
+	governor := NewMemoryGovernor(MemoryGovernorConfig{Limit: -1})
+
+	assert.False(t, governor.Check(context.Background()))
+	assert.False(t, governor.Shedding())
+}
+
+func TestWorkerShedsUnderMemoryPressure(t *testing.T) {
+	governor := NewMemoryGovernor(MemoryGovernorConfig{Limit: 1})
+	governor.Check(context.Background())
+	require.True(t, governor.Shedding())
+
+	worker := NewWorker(WorkerConfig{Governor: governor}, newMockRepository(), nil)
+
+	err := worker.SubmitTask(context.Background(), NewTask("email", nil))
+	assert.ErrorIs(t, err, ErrMemoryPressure)
+	assert.EqualValues(t, 1, worker.Stats().Rejected)
+}
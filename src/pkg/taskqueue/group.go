@@ -0,0 +1,138 @@
+package taskqueue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// GroupMetadataKey records which task group a task belongs to.
+const GroupMetadataKey = "taskqueue.group"
+
+// GroupResult is the single aggregated completion event of a task group.
+type GroupResult struct {
+	// GroupID identifies the group.
+	GroupID string `json:"group_id"`
+
+	// Total is how many tasks the group holds.
+	Total int `json:"total"`
+
+	// Completed is how many finished successfully.
+	Completed int `json:"completed"`
+
+	// Failed is how many ended failed or expired.
+	Failed int `json:"failed"`
+}
+
+// AllDone reports whether every task in the group succeeded.
+func (r GroupResult) AllDone() bool {
+	return r.Failed == 0 && r.Completed == r.Total
+}
+
+// GroupProgress is a point-in-time view of a running group.
+type GroupProgress struct {
+	Total     int  `json:"total"`
+	Completed int  `json:"completed"`
+	Failed    int  `json:"failed"`
+	Done      bool `json:"done"`
+}
+
+// groupState tracks one group's counters until the last task lands.
+type groupState struct {
+	mu        sync.Mutex
+	id        string
+	total     int
+	completed int
+	failed    int
+	fired     bool
+	done      chan GroupResult
+}
+
+// finishLocked fires the aggregated event once every task has landed.
+// Callers hold the mutex.
+func (g *groupState) finishLocked() {
+	if g.fired || g.completed+g.failed < g.total {
+		return
+	}
+	g.fired = true
+	g.done <- GroupResult{GroupID: g.id, Total: g.total, Completed: g.completed, Failed: g.failed}
+	close(g.done)
+}
+
+// SubmitGroup submits the tasks as one group — a WaitGroup across the
+// queue — and returns the group ID plus a channel delivering the single
+// aggregated completion event once every task has completed, failed or
+// expired. Progress is queryable meanwhile via GroupProgress. When a
+// submission fails the group shrinks to the tasks already accepted and
+// the error is returned alongside the still-usable channel.
+func (w *Worker) SubmitGroup(ctx context.Context, tasks []*Task) (string, <-chan GroupResult, error) {
+	if len(tasks) == 0 {
+		return "", nil, fmt.Errorf("%w: empty group", ErrInvalidTask)
+	}
+	id := newTaskID()
+	state := &groupState{id: id, total: len(tasks), done: make(chan GroupResult, 1)}
+	w.groups.Store(id, state)
+
+	for n, task := range tasks {
+		if task.Metadata == nil {
+			task.Metadata = make(map[string]string, 1)
+		}
+		task.Metadata[GroupMetadataKey] = id
+		if err := w.SubmitTask(ctx, task); err != nil {
+			state.mu.Lock()
+			state.total = n
+			state.finishLocked()
+			fired := state.fired
+			state.mu.Unlock()
+			if fired {
+				w.groups.Delete(id)
+			}
+			return id, state.done, fmt.Errorf("taskqueue: submit group task: %w", err)
+		}
+	}
+	return id, state.done, nil
+}
+
+// GroupProgress reports the current counters of the group, and whether
+// such a group exists. A group whose aggregated event already fired is
+// forgotten; its result lives on the channel SubmitGroup returned.
+func (w *Worker) GroupProgress(groupID string) (GroupProgress, bool) {
+	state, ok := w.groups.Load(groupID)
+	if !ok {
+		return GroupProgress{}, false
+	}
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	return GroupProgress{
+		Total:     state.total,
+		Completed: state.completed,
+		Failed:    state.failed,
+		Done:      state.fired,
+	}, true
+}
+
+// recordGroup counts one landed task against its group, firing the
+// aggregated event when it is the last and dropping the finished group
+// so the worker does not accumulate state for the process lifetime.
+func (w *Worker) recordGroup(task *Task, success bool) {
+	id := task.Metadata[GroupMetadataKey]
+	if id == "" {
+		return
+	}
+	state, ok := w.groups.Load(id)
+	if !ok {
+		return
+	}
+	state.mu.Lock()
+	if success {
+		state.completed++
+	} else {
+		state.failed++
+	}
+	state.finishLocked()
+	fired := state.fired
+	state.mu.Unlock()
+	if fired {
+		w.groups.Delete(id)
+	}
+}
@@ -0,0 +1,110 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitGroupAggregatesCompletion(t *testing.T) {
+	w := NewWorker(WorkerConfig{WorkerCount: 2}, newMockRepository(), nil)
+	w.Executors().Register("email", ExecutorFunc(func(_ context.Context, task *Task) (*TaskResult, error) {
+		if task.Data["fail"] == true {
+			return nil, assert.AnError
+		}
+		return &TaskResult{Output: "sent"}, nil
+	}))
+	w.Start()
+
+	tasks := []*Task{
+		NewTask("email", nil),
+		NewTask("email", nil),
+		NewTask("email", map[string]any{"fail": true}),
+	}
+	groupID, done, err := w.SubmitGroup(context.Background(), tasks)
+	require.NoError(t, err)
+	require.NotEmpty(t, groupID)
+
+	select {
+	case result := <-done:
+		assert.Equal(t, groupID, result.GroupID)
+		assert.Equal(t, 3, result.Total)
+		assert.Equal(t, 2, result.Completed)
+		assert.Equal(t, 1, result.Failed)
+		assert.False(t, result.AllDone())
+	case <-time.After(2 * time.Second):
+		t.Fatal("group never completed")
+	}
+
+	assert.Eventually(t, func() bool {
+		_, ok := w.GroupProgress(groupID)
+		return !ok
+	}, 2*time.Second, 5*time.Millisecond, "a finished group is forgotten, its result is on the channel")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	require.NoError(t, w.Shutdown(ctx))
+}
+
+func TestSubmitGroupAllDone(t *testing.T) {
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), nil)
+	w.Executors().Register("email", ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		return &TaskResult{Output: "sent"}, nil
+	}))
+	w.Start()
+
+	_, done, err := w.SubmitGroup(context.Background(), []*Task{NewTask("email", nil), NewTask("email", nil)})
+	require.NoError(t, err)
+
+	select {
+	case result := <-done:
+		assert.True(t, result.AllDone())
+	case <-time.After(2 * time.Second):
+		t.Fatal("group never completed")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	require.NoError(t, w.Shutdown(ctx))
+}
+
+func TestSubmitGroupProgressWhileRunning(t *testing.T) {
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), nil)
+	release := make(chan struct{})
+	w.Executors().Register("email", ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		<-release
+		return &TaskResult{Output: "sent"}, nil
+	}))
+	w.Start()
+
+	groupID, done, err := w.SubmitGroup(context.Background(), []*Task{NewTask("email", nil), NewTask("email", nil)})
+	require.NoError(t, err)
+
+	progress, ok := w.GroupProgress(groupID)
+	require.True(t, ok)
+	assert.Equal(t, 2, progress.Total)
+	assert.False(t, progress.Done)
+
+	close(release)
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("group never completed")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	require.NoError(t, w.Shutdown(ctx))
+}
+
+func TestSubmitGroupRejectsEmptyAndUnknownProgress(t *testing.T) {
+	w := NewWorker(WorkerConfig{}, newMockRepository(), nil)
+	defer w.Shutdown(context.Background())
+
+	_, _, err := w.SubmitGroup(context.Background(), nil)
+	assert.ErrorIs(t, err, ErrInvalidTask)
+
+	_, ok := w.GroupProgress("missing")
+	assert.False(t, ok)
+}
@@ -0,0 +1,287 @@
+package grpcapi
+
+import (
+	"github.com/kodflow/taskqueue/pkg/taskqueue/protowire"
+)
+
+// This file holds hand-written, wire-compatible bindings for the
+// messages in taskqueue.proto, built on the protowire helpers. They
+// encode and decode the proto3 binary format directly so the service
+// speaks the real protocol without pulling the protobuf runtime into
+// the module; protoc-generated code for other languages interoperates
+// with them unchanged. Field numbers must stay in lockstep with
+// taskqueue.proto.
+
+// pbSubmitTaskRequest mirrors taskqueue.v1.SubmitTaskRequest.
+type pbSubmitTaskRequest struct {
+	Type       string
+	Data       []byte
+	Priority   int32
+	MaxRetries int32
+}
+
+func (m *pbSubmitTaskRequest) marshal() []byte {
+	var b []byte
+	b = protowire.AppendStringField(b, 1, m.Type)
+	b = protowire.AppendBytesField(b, 2, m.Data)
+	b = protowire.AppendVarintField(b, 3, uint64(int64(m.Priority)))
+	b = protowire.AppendVarintField(b, 4, uint64(int64(m.MaxRetries)))
+	return b
+}
+
+func (m *pbSubmitTaskRequest) unmarshal(data []byte) error {
+	return protowire.Parse(data, func(f protowire.Field) error {
+		switch f.Num {
+		case 1:
+			m.Type = string(f.Bytes)
+		case 2:
+			m.Data = append([]byte(nil), f.Bytes...)
+		case 3:
+			m.Priority = int32(f.Varint)
+		case 4:
+			m.MaxRetries = int32(f.Varint)
+		}
+		return nil
+	})
+}
+
+// pbSubmitTaskResponse mirrors taskqueue.v1.SubmitTaskResponse.
+type pbSubmitTaskResponse struct {
+	ID     string
+	Status string
+}
+
+func (m *pbSubmitTaskResponse) marshal() []byte {
+	var b []byte
+	b = protowire.AppendStringField(b, 1, m.ID)
+	b = protowire.AppendStringField(b, 2, m.Status)
+	return b
+}
+
+func (m *pbSubmitTaskResponse) unmarshal(data []byte) error {
+	return protowire.Parse(data, func(f protowire.Field) error {
+		switch f.Num {
+		case 1:
+			m.ID = string(f.Bytes)
+		case 2:
+			m.Status = string(f.Bytes)
+		}
+		return nil
+	})
+}
+
+// pbGetTaskRequest mirrors taskqueue.v1.GetTaskRequest.
+type pbGetTaskRequest struct {
+	ID string
+}
+
+func (m *pbGetTaskRequest) marshal() []byte {
+	return protowire.AppendStringField(nil, 1, m.ID)
+}
+
+func (m *pbGetTaskRequest) unmarshal(data []byte) error {
+	return protowire.Parse(data, func(f protowire.Field) error {
+		if f.Num == 1 {
+			m.ID = string(f.Bytes)
+		}
+		return nil
+	})
+}
+
+// pbTask mirrors taskqueue.v1.Task.
+type pbTask struct {
+	ID         string
+	Type       string
+	Data       []byte
+	Status     string
+	Priority   int32
+	Retries    int32
+	MaxRetries int32
+	Metadata   map[string]string
+	CreatedAt  string
+	UpdatedAt  string
+	ExpiresAt  string
+	TimeoutNs  int64
+	Deadline   string
+}
+
+func (m *pbTask) marshal() []byte {
+	var b []byte
+	b = protowire.AppendStringField(b, 1, m.ID)
+	b = protowire.AppendStringField(b, 2, m.Type)
+	b = protowire.AppendBytesField(b, 3, m.Data)
+	b = protowire.AppendStringField(b, 4, m.Status)
+	b = protowire.AppendVarintField(b, 5, uint64(int64(m.Priority)))
+	b = protowire.AppendVarintField(b, 6, uint64(int64(m.Retries)))
+	b = protowire.AppendVarintField(b, 7, uint64(int64(m.MaxRetries)))
+	b = protowire.AppendMapField(b, 8, m.Metadata)
+	b = protowire.AppendStringField(b, 9, m.CreatedAt)
+	b = protowire.AppendStringField(b, 10, m.UpdatedAt)
+	b = protowire.AppendStringField(b, 11, m.ExpiresAt)
+	b = protowire.AppendVarintField(b, 12, uint64(m.TimeoutNs))
+	b = protowire.AppendStringField(b, 13, m.Deadline)
+	return b
+}
+
+func (m *pbTask) unmarshal(data []byte) error {
+	return protowire.Parse(data, func(f protowire.Field) error {
+		switch f.Num {
+		case 1:
+			m.ID = string(f.Bytes)
+		case 2:
+			m.Type = string(f.Bytes)
+		case 3:
+			m.Data = append([]byte(nil), f.Bytes...)
+		case 4:
+			m.Status = string(f.Bytes)
+		case 5:
+			m.Priority = int32(f.Varint)
+		case 6:
+			m.Retries = int32(f.Varint)
+		case 7:
+			m.MaxRetries = int32(f.Varint)
+		case 8:
+			key, value, err := protowire.ParseMapEntry(f.Bytes)
+			if err != nil {
+				return err
+			}
+			if m.Metadata == nil {
+				m.Metadata = make(map[string]string)
+			}
+			m.Metadata[key] = value
+		case 9:
+			m.CreatedAt = string(f.Bytes)
+		case 10:
+			m.UpdatedAt = string(f.Bytes)
+		case 11:
+			m.ExpiresAt = string(f.Bytes)
+		case 12:
+			m.TimeoutNs = int64(f.Varint)
+		case 13:
+			m.Deadline = string(f.Bytes)
+		}
+		return nil
+	})
+}
+
+// pbStreamResultsRequest mirrors taskqueue.v1.StreamResultsRequest.
+type pbStreamResultsRequest struct {
+	TaskType string
+}
+
+func (m *pbStreamResultsRequest) marshal() []byte {
+	return protowire.AppendStringField(nil, 1, m.TaskType)
+}
+
+func (m *pbStreamResultsRequest) unmarshal(data []byte) error {
+	return protowire.Parse(data, func(f protowire.Field) error {
+		if f.Num == 1 {
+			m.TaskType = string(f.Bytes)
+		}
+		return nil
+	})
+}
+
+// pbTaskResult mirrors taskqueue.v1.TaskResult.
+type pbTaskResult struct {
+	TaskID        string
+	TaskType      string
+	Namespace     string
+	CorrelationID string
+	Success       bool
+	Output        string
+	Error         string
+	DurationNs    int64
+	CompletedAt   string
+}
+
+func (m *pbTaskResult) marshal() []byte {
+	var b []byte
+	b = protowire.AppendStringField(b, 1, m.TaskID)
+	b = protowire.AppendStringField(b, 2, m.TaskType)
+	b = protowire.AppendStringField(b, 3, m.Namespace)
+	b = protowire.AppendStringField(b, 4, m.CorrelationID)
+	if m.Success {
+		b = protowire.AppendVarintField(b, 5, 1)
+	}
+	b = protowire.AppendStringField(b, 6, m.Output)
+	b = protowire.AppendStringField(b, 7, m.Error)
+	b = protowire.AppendVarintField(b, 8, uint64(m.DurationNs))
+	b = protowire.AppendStringField(b, 9, m.CompletedAt)
+	return b
+}
+
+func (m *pbTaskResult) unmarshal(data []byte) error {
+	return protowire.Parse(data, func(f protowire.Field) error {
+		switch f.Num {
+		case 1:
+			m.TaskID = string(f.Bytes)
+		case 2:
+			m.TaskType = string(f.Bytes)
+		case 3:
+			m.Namespace = string(f.Bytes)
+		case 4:
+			m.CorrelationID = string(f.Bytes)
+		case 5:
+			m.Success = f.Varint != 0
+		case 6:
+			m.Output = string(f.Bytes)
+		case 7:
+			m.Error = string(f.Bytes)
+		case 8:
+			m.DurationNs = int64(f.Varint)
+		case 9:
+			m.CompletedAt = string(f.Bytes)
+		}
+		return nil
+	})
+}
+
+// pbStats mirrors taskqueue.v1.Stats.
+type pbStats struct {
+	Submitted  uint64
+	Completed  uint64
+	Failed     uint64
+	Rejected   uint64
+	Spilled    uint64
+	Expired    uint64
+	Retried    uint64
+	Speculated uint64
+}
+
+func (m *pbStats) marshal() []byte {
+	var b []byte
+	b = protowire.AppendVarintField(b, 1, m.Submitted)
+	b = protowire.AppendVarintField(b, 2, m.Completed)
+	b = protowire.AppendVarintField(b, 3, m.Failed)
+	b = protowire.AppendVarintField(b, 4, m.Rejected)
+	b = protowire.AppendVarintField(b, 5, m.Spilled)
+	b = protowire.AppendVarintField(b, 6, m.Expired)
+	b = protowire.AppendVarintField(b, 7, m.Retried)
+	b = protowire.AppendVarintField(b, 8, m.Speculated)
+	return b
+}
+
+func (m *pbStats) unmarshal(data []byte) error {
+	return protowire.Parse(data, func(f protowire.Field) error {
+		switch f.Num {
+		case 1:
+			m.Submitted = f.Varint
+		case 2:
+			m.Completed = f.Varint
+		case 3:
+			m.Failed = f.Varint
+		case 4:
+			m.Rejected = f.Varint
+		case 5:
+			m.Spilled = f.Varint
+		case 6:
+			m.Expired = f.Varint
+		case 7:
+			m.Retried = f.Varint
+		case 8:
+			m.Speculated = f.Varint
+		}
+		return nil
+	})
+}
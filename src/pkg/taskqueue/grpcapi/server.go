@@ -0,0 +1,301 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue"
+	"github.com/kodflow/taskqueue/pkg/taskqueue/protowire"
+)
+
+// grpcContentType is the content type of gRPC-over-HTTP/2 requests and
+// responses.
+const grpcContentType = "application/grpc"
+
+// maxFrameSize bounds a single received message frame, mirroring the
+// default receive limit of mainstream gRPC implementations.
+const maxFrameSize = 4 << 20
+
+// gRPC status codes the transport emits, from the canonical code table.
+const (
+	codeOK              = 0
+	codeInvalidArgument = 3
+	codeNotFound        = 5
+	codeUnimplemented   = 12
+	codeInternal        = 13
+)
+
+// Server serves the TaskQueue gRPC service from taskqueue.proto as an
+// http.Handler, speaking the gRPC wire protocol — length-prefixed proto3
+// frames with the status in HTTP trailers — over the standard library's
+// HTTP/2 support. Mount it on a TLS server, or on a plain one with
+// Protocols.SetUnencryptedHTTP2 enabled, and any gRPC client generated
+// from taskqueue.proto can call it.
+type Server struct {
+	service *Service
+}
+
+// NewServer wraps the service.
+func NewServer(service *Service) *Server {
+	return &Server{service: service}
+}
+
+// ServeHTTP dispatches one RPC.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.ProtoMajor != 2 || r.Method != http.MethodPost ||
+		!strings.HasPrefix(r.Header.Get("Content-Type"), grpcContentType) {
+		http.Error(w, "grpcapi: gRPC requires an HTTP/2 POST", http.StatusUnsupportedMediaType)
+		return
+	}
+	w.Header().Set("Content-Type", grpcContentType)
+
+	switch r.URL.Path {
+	case "/taskqueue.v1.TaskQueue/SubmitTask":
+		s.unary(w, r, s.submitTask)
+	case "/taskqueue.v1.TaskQueue/GetTask":
+		s.unary(w, r, s.getTask)
+	case "/taskqueue.v1.TaskQueue/GetStats":
+		s.unary(w, r, s.getStats)
+	case "/taskqueue.v1.TaskQueue/StreamResults":
+		s.streamResults(w, r)
+	default:
+		writeStatus(w, codeUnimplemented, fmt.Sprintf("unknown method %s", r.URL.Path))
+	}
+}
+
+// unary reads the single request frame, runs the handler and answers
+// with the single response frame plus the status trailer.
+func (s *Server) unary(w http.ResponseWriter, r *http.Request, handle func(ctx context.Context, payload []byte) ([]byte, error)) {
+	payload, err := readFrame(r.Body)
+	if err != nil {
+		writeStatus(w, codeInvalidArgument, err.Error())
+		return
+	}
+	response, err := handle(r.Context(), payload)
+	if err != nil {
+		writeStatus(w, statusCode(err), err.Error())
+		return
+	}
+	writeFrame(w, response)
+	writeStatus(w, codeOK, "")
+}
+
+// submitTask handles the SubmitTask RPC.
+func (s *Server) submitTask(ctx context.Context, payload []byte) ([]byte, error) {
+	var request pbSubmitTaskRequest
+	if err := request.unmarshal(payload); err != nil {
+		return nil, err
+	}
+	create := &taskqueue.CreateTaskRequest{
+		Type:       request.Type,
+		Priority:   int(request.Priority),
+		MaxRetries: int(request.MaxRetries),
+	}
+	if len(request.Data) > 0 {
+		if err := json.Unmarshal(request.Data, &create.Data); err != nil {
+			return nil, fmt.Errorf("%w: bad data payload: %w", taskqueue.ErrInvalidTask, err)
+		}
+	}
+	task, err := s.service.SubmitTask(ctx, create)
+	if err != nil {
+		return nil, err
+	}
+	response := pbSubmitTaskResponse{ID: task.ID, Status: string(task.Status)}
+	return response.marshal(), nil
+}
+
+// getTask handles the GetTask RPC.
+func (s *Server) getTask(ctx context.Context, payload []byte) ([]byte, error) {
+	var request pbGetTaskRequest
+	if err := request.unmarshal(payload); err != nil {
+		return nil, err
+	}
+	task, err := s.service.GetTask(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := taskToPB(task)
+	if err != nil {
+		return nil, err
+	}
+	return encoded.marshal(), nil
+}
+
+// getStats handles the GetStats RPC.
+func (s *Server) getStats(ctx context.Context, _ []byte) ([]byte, error) {
+	stats, err := s.service.GetStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response := pbStats{
+		Submitted:  stats.Submitted,
+		Completed:  stats.Completed,
+		Failed:     stats.Failed,
+		Rejected:   stats.Rejected,
+		Spilled:    stats.Spilled,
+		Expired:    stats.Expired,
+		Retried:    stats.Retried,
+		Speculated: stats.Speculated,
+	}
+	return response.marshal(), nil
+}
+
+// streamResults handles the StreamResults RPC, flushing each result
+// frame as it lands so subscribers see them live.
+func (s *Server) streamResults(w http.ResponseWriter, r *http.Request) {
+	payload, err := readFrame(r.Body)
+	if err != nil {
+		writeStatus(w, codeInvalidArgument, err.Error())
+		return
+	}
+	var request pbStreamResultsRequest
+	if err := request.unmarshal(payload); err != nil {
+		writeStatus(w, statusCode(err), err.Error())
+		return
+	}
+
+	// Flush the headers before the first result so the client sees the
+	// stream open immediately instead of blocking on them.
+	flusher, _ := w.(http.Flusher)
+	w.WriteHeader(http.StatusOK)
+	if flusher != nil {
+		flusher.Flush()
+	}
+	stream := &httpResultStream{ctx: r.Context(), w: w, flusher: flusher}
+	if err := s.service.StreamResults(&StreamResultsRequest{TaskType: request.TaskType}, stream); err != nil {
+		writeStatus(w, statusCode(err), err.Error())
+		return
+	}
+	writeStatus(w, codeOK, "")
+}
+
+// httpResultStream adapts the response writer to ResultStream.
+type httpResultStream struct {
+	ctx     context.Context
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+func (s *httpResultStream) Context() context.Context { return s.ctx }
+
+func (s *httpResultStream) Send(result *taskqueue.TaskResult) error {
+	encoded := pbTaskResult{
+		TaskID:        result.TaskID,
+		TaskType:      result.TaskType,
+		Namespace:     result.Namespace,
+		CorrelationID: result.CorrelationID,
+		Success:       result.Success,
+		Output:        result.Output,
+		Error:         result.Error,
+		DurationNs:    int64(result.Duration),
+		CompletedAt:   encodeTime(result.CompletedAt),
+	}
+	if err := writeFrame(s.w, encoded.marshal()); err != nil {
+		return err
+	}
+	if s.flusher != nil {
+		s.flusher.Flush()
+	}
+	return nil
+}
+
+// taskToPB converts a domain task to its proto message, encoding the
+// data map as JSON per the proto contract.
+func taskToPB(task *taskqueue.Task) (*pbTask, error) {
+	encoded := &pbTask{
+		ID:         task.ID,
+		Type:       task.Type,
+		Status:     string(task.Status),
+		Priority:   int32(task.Priority),
+		Retries:    int32(task.Retries),
+		MaxRetries: int32(task.MaxRetries),
+		Metadata:   task.Metadata,
+		CreatedAt:  encodeTime(task.CreatedAt),
+		UpdatedAt:  encodeTime(task.UpdatedAt),
+		ExpiresAt:  encodeTime(task.ExpiresAt),
+		TimeoutNs:  int64(task.Timeout),
+		Deadline:   encodeTime(task.Deadline),
+	}
+	if task.Data != nil {
+		data, err := json.Marshal(task.Data)
+		if err != nil {
+			return nil, fmt.Errorf("grpcapi: encode task data: %w", err)
+		}
+		encoded.Data = data
+	}
+	return encoded, nil
+}
+
+// encodeTime formats a timestamp field, writing the empty string for the
+// zero time.
+func encodeTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// statusCode maps a handler error to its gRPC status code.
+func statusCode(err error) int {
+	switch {
+	case errors.Is(err, taskqueue.ErrTaskNotFound):
+		return codeNotFound
+	case errors.Is(err, taskqueue.ErrInvalidTask), errors.Is(err, protowire.ErrMalformed):
+		return codeInvalidArgument
+	default:
+		return codeInternal
+	}
+}
+
+// writeStatus records the grpc-status trailer, and grpc-message when the
+// call failed. The trailer-prefix mechanism lets the handler set them
+// after the body without declaring them up front.
+func writeStatus(w http.ResponseWriter, code int, message string) {
+	w.Header().Set(http.TrailerPrefix+"Grpc-Status", strconv.Itoa(code))
+	if message != "" {
+		w.Header().Set(http.TrailerPrefix+"Grpc-Message", url.PathEscape(message))
+	}
+}
+
+// writeFrame writes one length-prefixed, uncompressed message frame.
+func writeFrame(w io.Writer, payload []byte) error {
+	var header [5]byte
+	binary.BigEndian.PutUint32(header[1:], uint32(len(payload)))
+	if _, err := w.Write(header[:]); err != nil {
+		return fmt.Errorf("grpcapi: write frame: %w", err)
+	}
+	if _, err := w.Write(payload); err != nil {
+		return fmt.Errorf("grpcapi: write frame: %w", err)
+	}
+	return nil
+}
+
+// readFrame reads one length-prefixed message frame, rejecting
+// compressed and oversized ones.
+func readFrame(r io.Reader) ([]byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, fmt.Errorf("grpcapi: read frame: %w", err)
+	}
+	if header[0] != 0 {
+		return nil, errors.New("grpcapi: compressed frames are not supported")
+	}
+	size := binary.BigEndian.Uint32(header[1:])
+	if size > maxFrameSize {
+		return nil, fmt.Errorf("grpcapi: frame of %d bytes exceeds the %d byte limit", size, maxFrameSize)
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, fmt.Errorf("grpcapi: read frame: %w", err)
+	}
+	return payload, nil
+}
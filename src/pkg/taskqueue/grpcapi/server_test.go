@@ -0,0 +1,144 @@
+package grpcapi
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue"
+	"github.com/kodflow/taskqueue/pkg/taskqueue/protowire"
+)
+
+func TestPBTaskRoundTrip(t *testing.T) {
+	original := pbTask{
+		ID:         "t1",
+		Type:       "email",
+		Data:       []byte(`{"to":"ops"}`),
+		Status:     "pending",
+		Priority:   3,
+		Retries:    1,
+		MaxRetries: 5,
+		Metadata:   map[string]string{"tenant": "acme", "region": "eu"},
+		CreatedAt:  "2026-08-28T10:00:00Z",
+		UpdatedAt:  "2026-08-28T10:05:00Z",
+	}
+
+	var decoded pbTask
+	require.NoError(t, decoded.unmarshal(original.marshal()))
+	assert.Equal(t, original, decoded)
+}
+
+func TestPBUnmarshalRejectsMalformedInput(t *testing.T) {
+	var task pbTask
+	assert.ErrorIs(t, task.unmarshal([]byte{0x0a, 0xff}), protowire.ErrMalformed)
+}
+
+// newTestServer serves the gRPC transport over HTTP/2 and returns a
+// client speaking it.
+func newTestServer(t *testing.T) (*httptest.Server, *taskqueue.Worker) {
+	t.Helper()
+	service, worker := newTestService(t)
+	ts := httptest.NewUnstartedServer(NewServer(service))
+	ts.EnableHTTP2 = true
+	ts.StartTLS()
+	t.Cleanup(ts.Close)
+	return ts, worker
+}
+
+// call performs one unary RPC, returning the response payload and the
+// grpc-status trailer.
+func call(t *testing.T, ts *httptest.Server, method string, payload []byte) ([]byte, string) {
+	t.Helper()
+	var body bytes.Buffer
+	require.NoError(t, writeFrame(&body, payload))
+	request, err := http.NewRequest(http.MethodPost, ts.URL+method, &body)
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", grpcContentType)
+
+	response, err := ts.Client().Do(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	require.Equal(t, 2, response.ProtoMajor, "gRPC requires HTTP/2")
+
+	raw, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	status := response.Trailer.Get("Grpc-Status")
+	if len(raw) == 0 {
+		return nil, status
+	}
+	frame, err := readFrame(bytes.NewReader(raw))
+	require.NoError(t, err)
+	return frame, status
+}
+
+func TestServerSubmitAndGetTaskOverHTTP2(t *testing.T) {
+	ts, _ := newTestServer(t)
+
+	request := pbSubmitTaskRequest{Type: "email", Data: []byte(`{"to":"ops"}`), Priority: 2}
+	payload, status := call(t, ts, "/taskqueue.v1.TaskQueue/SubmitTask", request.marshal())
+	require.Equal(t, "0", status)
+
+	var submitted pbSubmitTaskResponse
+	require.NoError(t, submitted.unmarshal(payload))
+	require.NotEmpty(t, submitted.ID)
+	assert.Equal(t, "pending", submitted.Status)
+
+	payload, status = call(t, ts, "/taskqueue.v1.TaskQueue/GetTask", (&pbGetTaskRequest{ID: submitted.ID}).marshal())
+	require.Equal(t, "0", status)
+
+	var fetched pbTask
+	require.NoError(t, fetched.unmarshal(payload))
+	assert.Equal(t, submitted.ID, fetched.ID)
+	assert.Equal(t, "email", fetched.Type)
+	assert.Equal(t, int32(2), fetched.Priority)
+	assert.JSONEq(t, `{"to":"ops"}`, string(fetched.Data))
+}
+
+func TestServerStatusCodes(t *testing.T) {
+	ts, _ := newTestServer(t)
+
+	_, status := call(t, ts, "/taskqueue.v1.TaskQueue/GetTask", (&pbGetTaskRequest{ID: "missing"}).marshal())
+	assert.Equal(t, "5", status, "unknown task maps to NOT_FOUND")
+
+	_, status = call(t, ts, "/taskqueue.v1.TaskQueue/SubmitTask", (&pbSubmitTaskRequest{}).marshal())
+	assert.Equal(t, "3", status, "validation failure maps to INVALID_ARGUMENT")
+
+	_, status = call(t, ts, "/taskqueue.v1.TaskQueue/Reboot", nil)
+	assert.Equal(t, "12", status, "unknown method maps to UNIMPLEMENTED")
+}
+
+func TestServerStreamResultsOverHTTP2(t *testing.T) {
+	ts, worker := newTestServer(t)
+	worker.Start()
+
+	var body bytes.Buffer
+	require.NoError(t, writeFrame(&body, (&pbStreamResultsRequest{TaskType: "email"}).marshal()))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, ts.URL+"/taskqueue.v1.TaskQueue/StreamResults", &body)
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", grpcContentType)
+
+	response, err := ts.Client().Do(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	// Give the stream a moment to subscribe before the result lands.
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, worker.SubmitTask(context.Background(), taskqueue.NewTask("email", nil)))
+
+	frame, err := readFrame(response.Body)
+	require.NoError(t, err)
+	var result pbTaskResult
+	require.NoError(t, result.unmarshal(frame))
+	assert.Equal(t, "email", result.TaskType)
+	assert.Equal(t, "sent", result.Output)
+	assert.True(t, result.Success)
+}
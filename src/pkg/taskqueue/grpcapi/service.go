@@ -0,0 +1,95 @@
+// Package grpcapi implements the TaskQueue gRPC service defined in
+// taskqueue.proto. The Service methods mirror the four RPCs and delegate
+// to the Worker; Server wraps them in the gRPC wire protocol —
+// hand-written proto3 bindings in pb.go plus length-prefixed frames over
+// the standard library's HTTP/2 — so clients generated from the proto
+// can call the service without this module depending on grpc-go.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue"
+)
+
+// StreamResultsBuffer is how many results a StreamResults subscriber may
+// fall behind before newer results are dropped for it.
+const StreamResultsBuffer = 256
+
+// StreamResultsRequest selects which results to stream.
+type StreamResultsRequest struct {
+	// TaskType restricts the stream to one task type; empty streams
+	// every type.
+	TaskType string
+}
+
+// ResultStream is the server side of the StreamResults RPC, shaped like
+// the generated grpc.ServerStream wrapper.
+type ResultStream interface {
+	Context() context.Context
+	Send(*taskqueue.TaskResult) error
+}
+
+// Service implements the TaskQueue RPCs against one worker.
+type Service struct {
+	worker *taskqueue.Worker
+}
+
+// NewService wraps the worker.
+func NewService(worker *taskqueue.Worker) *Service {
+	return &Service{worker: worker}
+}
+
+// SubmitTask validates and enqueues one task, answering with its
+// generated ID.
+func (s *Service) SubmitTask(ctx context.Context, request *taskqueue.CreateTaskRequest) (*taskqueue.Task, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	task := request.ToTask()
+	if err := s.worker.SubmitTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("grpcapi: submit task: %w", err)
+	}
+	return task, nil
+}
+
+// GetTask resolves one task by ID.
+func (s *Service) GetTask(ctx context.Context, id string) (*taskqueue.Task, error) {
+	task, err := s.worker.GetTask(ctx, id)
+	if errors.Is(err, taskqueue.ErrTaskNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("grpcapi: get task: %w", err)
+	}
+	return task, nil
+}
+
+// StreamResults sends task results to the stream as they land, until the
+// stream's context is cancelled. Subscribers that fall behind miss
+// results instead of slowing the workers down.
+func (s *Service) StreamResults(request *StreamResultsRequest, stream ResultStream) error {
+	results, cancel := s.worker.Bus().Results.Subscribe(StreamResultsBuffer, taskqueue.DropNewest)
+	defer cancel()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case result := <-results:
+			if request.TaskType != "" && result.TaskType != request.TaskType {
+				continue
+			}
+			if err := stream.Send(result); err != nil {
+				return fmt.Errorf("grpcapi: send result: %w", err)
+			}
+		}
+	}
+}
+
+// GetStats returns a snapshot of the worker counters.
+func (s *Service) GetStats(context.Context) (taskqueue.StatsSnapshot, error) {
+	return s.worker.Stats(), nil
+}
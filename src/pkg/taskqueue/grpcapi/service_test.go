@@ -0,0 +1,122 @@
+package grpcapi
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue"
+	"github.com/kodflow/taskqueue/pkg/taskqueue/adapters"
+)
+
+// fakeStream collects sent results until its context is cancelled.
+type fakeStream struct {
+	ctx context.Context
+
+	mu   sync.Mutex
+	sent []*taskqueue.TaskResult
+}
+
+func (s *fakeStream) Context() context.Context { return s.ctx }
+
+func (s *fakeStream) Send(result *taskqueue.TaskResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, result)
+	return nil
+}
+
+func (s *fakeStream) results() []*taskqueue.TaskResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*taskqueue.TaskResult(nil), s.sent...)
+}
+
+func newTestService(t *testing.T) (*Service, *taskqueue.Worker) {
+	t.Helper()
+	worker := taskqueue.NewWorker(taskqueue.WorkerConfig{WorkerCount: 1}, adapters.NewMemoryRepository(), nil)
+	worker.Executors().Register("email", taskqueue.ExecutorFunc(func(_ context.Context, _ *taskqueue.Task) (*taskqueue.TaskResult, error) {
+		return &taskqueue.TaskResult{Output: "sent"}, nil
+	}))
+	t.Cleanup(func() { worker.Shutdown(context.Background()) })
+	return NewService(worker), worker
+}
+
+func TestSubmitAndGetTask(t *testing.T) {
+	service, _ := newTestService(t)
+
+	task, err := service.SubmitTask(context.Background(), &taskqueue.CreateTaskRequest{Type: "email", Priority: 2})
+	require.NoError(t, err)
+	require.NotEmpty(t, task.ID)
+
+	fetched, err := service.GetTask(context.Background(), task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "email", fetched.Type)
+	assert.Equal(t, 2, fetched.Priority)
+}
+
+func TestSubmitTaskValidates(t *testing.T) {
+	service, _ := newTestService(t)
+
+	_, err := service.SubmitTask(context.Background(), &taskqueue.CreateTaskRequest{})
+	assert.ErrorIs(t, err, taskqueue.ErrInvalidTask)
+}
+
+func TestGetTaskNotFound(t *testing.T) {
+	service, _ := newTestService(t)
+
+	_, err := service.GetTask(context.Background(), "missing")
+	assert.ErrorIs(t, err, taskqueue.ErrTaskNotFound)
+}
+
+func TestStreamResultsDeliversAndFilters(t *testing.T) {
+	service, worker := newTestService(t)
+	worker.Executors().Register("sms", taskqueue.ExecutorFunc(func(_ context.Context, _ *taskqueue.Task) (*taskqueue.TaskResult, error) {
+		return &taskqueue.TaskResult{Output: "texted"}, nil
+	}))
+	worker.Start()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &fakeStream{ctx: ctx}
+	streamDone := make(chan error, 1)
+	go func() { streamDone <- service.StreamResults(&StreamResultsRequest{TaskType: "email"}, stream) }()
+
+	// Give the stream a moment to subscribe before results start landing.
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, worker.SubmitTask(context.Background(), taskqueue.NewTask("email", nil)))
+	require.NoError(t, worker.SubmitTask(context.Background(), taskqueue.NewTask("sms", nil)))
+
+	require.Eventually(t, func() bool {
+		return len(stream.results()) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "email", stream.results()[0].TaskType)
+	assert.Equal(t, "sent", stream.results()[0].Output)
+
+	cancel()
+	select {
+	case err := <-streamDone:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("stream never returned after context cancellation")
+	}
+}
+
+func TestGetStats(t *testing.T) {
+	service, worker := newTestService(t)
+	worker.Start()
+
+	_, err := service.SubmitTask(context.Background(), &taskqueue.CreateTaskRequest{Type: "email"})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		stats, err := service.GetStats(context.Background())
+		return err == nil && stats.Completed == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	stats, err := service.GetStats(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), stats.Submitted)
+}
@@ -0,0 +1,101 @@
+package taskqueue
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// HeartbeatTopic is the control topic worker liveness envelopes are
+// published to.
+const HeartbeatTopic = "task.heartbeat"
+
+// DefaultHeartbeatInterval is the publication period when
+// WorkerConfig.Heartbeat is set without an explicit interval.
+const DefaultHeartbeatInterval = 30 * time.Second
+
+// Heartbeat is one liveness envelope of a worker instance, sized for
+// fleet dashboards: who is alive, for how long, and what they have
+// processed.
+type Heartbeat struct {
+	// InstanceID identifies this worker process across the fleet.
+	InstanceID string `json:"instance_id"`
+
+	// StartedAt is when the worker pool started.
+	StartedAt time.Time `json:"started_at"`
+
+	// At is when the heartbeat was taken.
+	At time.Time `json:"at"`
+
+	// UptimeSeconds is how long the pool has been running.
+	UptimeSeconds int64 `json:"uptime_seconds"`
+
+	// Workers is the size of the processing pool.
+	Workers int `json:"workers"`
+
+	// QueueDepth is the number of buffered tasks at publication time.
+	QueueDepth int `json:"queue_depth"`
+
+	// Stats summarizes the worker counters.
+	Stats StatsSnapshot `json:"stats"`
+}
+
+// HeartbeatConfig enables periodic liveness publication.
+type HeartbeatConfig struct {
+	// Interval is the publication period. Defaults to
+	// DefaultHeartbeatInterval.
+	Interval time.Duration
+
+	// InstanceID identifies this process in the envelopes. Defaults to a
+	// generated ID.
+	InstanceID string
+}
+
+// InstanceID returns the identifier this worker publishes in heartbeats,
+// or "" when heartbeats are disabled.
+func (w *Worker) InstanceID() string {
+	return w.instanceID
+}
+
+// heartbeatLoop publishes a liveness envelope every interval until the
+// worker stops, sending one final envelope on the way out so dashboards
+// see the stop promptly rather than waiting for a timeout.
+func (w *Worker) heartbeatLoop(startedAt time.Time, interval time.Duration) {
+	defer w.drainWg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stopCh:
+			w.publishHeartbeat(startedAt)
+			return
+		case <-ticker.C:
+			w.publishHeartbeat(startedAt)
+		}
+	}
+}
+
+// publishHeartbeat sends one envelope to the heartbeat topic.
+func (w *Worker) publishHeartbeat(startedAt time.Time) {
+	if w.publisher == nil {
+		return
+	}
+	now := time.Now().UTC()
+	beat := Heartbeat{
+		InstanceID:    w.instanceID,
+		StartedAt:     startedAt,
+		At:            now,
+		UptimeSeconds: int64(now.Sub(startedAt).Seconds()),
+		Workers:       w.config.WorkerCount,
+		QueueDepth:    w.QueueDepth(),
+		Stats:         w.Stats(),
+	}
+	payload, err := json.Marshal(beat)
+	if err != nil {
+		w.logger.Error("taskqueue: encode heartbeat failed", "error", err)
+		return
+	}
+	if err := w.publisher.Publish(context.Background(), HeartbeatTopic, payload); err != nil {
+		w.logger.Error("taskqueue: publish heartbeat failed", "instance_id", w.instanceID, "error", err)
+	}
+}
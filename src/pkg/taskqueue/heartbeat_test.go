@@ -0,0 +1,60 @@
+package taskqueue
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func heartbeatsOf(p *mockPublisher) [][]byte {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([][]byte(nil), p.messages[HeartbeatTopic]...)
+}
+
+func TestHeartbeatPublishesEnvelopes(t *testing.T) {
+	publisher := newMockPublisher()
+	w := NewWorker(WorkerConfig{
+		WorkerCount: 1,
+		Heartbeat:   &HeartbeatConfig{Interval: 10 * time.Millisecond, InstanceID: "node-1"},
+	}, newMockRepository(), publisher)
+	w.Start()
+
+	require.Eventually(t, func() bool {
+		return len(heartbeatsOf(publisher)) >= 2
+	}, 2*time.Second, 5*time.Millisecond)
+
+	beats := heartbeatsOf(publisher)
+	var beat Heartbeat
+	require.NoError(t, json.Unmarshal(beats[len(beats)-1], &beat))
+	assert.Equal(t, "node-1", beat.InstanceID)
+	assert.Equal(t, 1, beat.Workers)
+	assert.False(t, beat.StartedAt.IsZero())
+	assert.False(t, beat.At.Before(beat.StartedAt))
+
+	require.NoError(t, w.Shutdown(context.Background()))
+	final := len(heartbeatsOf(publisher))
+	assert.GreaterOrEqual(t, final, len(beats)+1, "shutdown should flush one final heartbeat")
+}
+
+func TestHeartbeatDisabledByDefault(t *testing.T) {
+	publisher := newMockPublisher()
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), publisher)
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	assert.Empty(t, w.InstanceID())
+	time.Sleep(20 * time.Millisecond)
+	assert.Empty(t, heartbeatsOf(publisher))
+}
+
+func TestHeartbeatGeneratesInstanceID(t *testing.T) {
+	w := NewWorker(WorkerConfig{Heartbeat: &HeartbeatConfig{}}, newMockRepository(), newMockPublisher())
+	defer w.Shutdown(context.Background())
+
+	assert.NotEmpty(t, w.InstanceID())
+}
@@ -0,0 +1,121 @@
+package taskqueue
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultHistoryDepth is how many entries TaskHistory keeps per task
+// before discarding the oldest.
+const DefaultHistoryDepth = 100
+
+// HistoryEntry is one recorded change to a task: either a full snapshot
+// from a Save or a bare status transition.
+type HistoryEntry struct {
+	// At is when the change was recorded.
+	At time.Time `json:"at"`
+
+	// Status is the task status after the change.
+	Status TaskStatus `json:"status"`
+
+	// Snapshot is the full task as saved, nil for status-only entries.
+	Snapshot *Task `json:"snapshot,omitempty"`
+}
+
+// TaskHistory records every repository write per task so past states can
+// be reconstructed for post-incident analysis. It implements
+// RepositoryListener; wire it through WorkerConfig.RepositoryListeners
+// to capture the worker's writes. Each task keeps at most depth entries,
+// oldest first out.
+type TaskHistory struct {
+	mu      sync.RWMutex
+	depth   int
+	entries map[string][]HistoryEntry
+}
+
+// NewTaskHistory builds a history log keeping depth entries per task
+// (DefaultHistoryDepth when depth is not positive).
+func NewTaskHistory(depth int) *TaskHistory {
+	if depth <= 0 {
+		depth = DefaultHistoryDepth
+	}
+	return &TaskHistory{depth: depth, entries: make(map[string][]HistoryEntry)}
+}
+
+// TaskSaved implements RepositoryListener.
+func (h *TaskHistory) TaskSaved(_ context.Context, task *Task) {
+	h.record(task.ID, HistoryEntry{At: time.Now(), Status: task.Status, Snapshot: snapshotTask(task)})
+}
+
+// TaskStatusChanged implements RepositoryListener.
+func (h *TaskHistory) TaskStatusChanged(_ context.Context, id string, status TaskStatus) {
+	h.record(id, HistoryEntry{At: time.Now(), Status: status})
+}
+
+// record appends one entry, trimming to the configured depth.
+func (h *TaskHistory) record(id string, entry HistoryEntry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	entries := append(h.entries[id], entry)
+	if len(entries) > h.depth {
+		entries = entries[len(entries)-h.depth:]
+	}
+	h.entries[id] = entries
+}
+
+// History returns the recorded entries for the task, oldest first.
+func (h *TaskHistory) History(id string) []HistoryEntry {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	entries := h.entries[id]
+	out := make([]HistoryEntry, len(entries))
+	copy(out, entries)
+	return out
+}
+
+// AsOf reconstructs the task's state at the given instant: the last full
+// snapshot at or before it, with any later status transitions up to it
+// applied. It returns ErrTaskNotFound when no snapshot that old exists —
+// including when the task's history has been trimmed past that point.
+func (h *TaskHistory) AsOf(id string, at time.Time) (*Task, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	var task *Task
+	for _, entry := range h.entries[id] {
+		if entry.At.After(at) {
+			break
+		}
+		if entry.Snapshot != nil {
+			task = snapshotTask(entry.Snapshot)
+			continue
+		}
+		if task != nil {
+			task.Status = entry.Status
+			task.UpdatedAt = entry.At
+		}
+	}
+	if task == nil {
+		return nil, ErrTaskNotFound
+	}
+	return task, nil
+}
+
+// snapshotTask deep-copies a task so history entries stay immutable.
+func snapshotTask(task *Task) *Task {
+	clone := *task
+	if task.Data != nil {
+		clone.Data = make(map[string]any, len(task.Data))
+		for key, value := range task.Data {
+			clone.Data[key] = value
+		}
+	}
+	if task.Metadata != nil {
+		clone.Metadata = make(map[string]string, len(task.Metadata))
+		for key, value := range task.Metadata {
+			clone.Metadata[key] = value
+		}
+	}
+	return &clone
+}
@@ -0,0 +1,94 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskHistoryRecordsWrites(t *testing.T) {
+	history := NewTaskHistory(0)
+	ctx := context.Background()
+
+	task := &Task{ID: "t1", Type: "email", Status: TaskStatusPending}
+	history.TaskSaved(ctx, task)
+	history.TaskStatusChanged(ctx, "t1", TaskStatusProcessing)
+	history.TaskStatusChanged(ctx, "t1", TaskStatusCompleted)
+
+	entries := history.History("t1")
+	require.Len(t, entries, 3)
+	assert.NotNil(t, entries[0].Snapshot)
+	assert.Equal(t, TaskStatusCompleted, entries[2].Status)
+}
+
+func TestTaskHistoryAsOf(t *testing.T) {
+	history := NewTaskHistory(0)
+	ctx := context.Background()
+
+	task := &Task{ID: "t1", Type: "email", Status: TaskStatusPending}
+	history.TaskSaved(ctx, task)
+	afterSave := time.Now()
+	time.Sleep(5 * time.Millisecond)
+	history.TaskStatusChanged(ctx, "t1", TaskStatusProcessing)
+	afterStart := time.Now()
+	time.Sleep(5 * time.Millisecond)
+	history.TaskStatusChanged(ctx, "t1", TaskStatusFailed)
+
+	got, err := history.AsOf("t1", afterSave)
+	require.NoError(t, err)
+	assert.Equal(t, TaskStatusPending, got.Status)
+
+	got, err = history.AsOf("t1", afterStart)
+	require.NoError(t, err)
+	assert.Equal(t, TaskStatusProcessing, got.Status)
+
+	got, err = history.AsOf("t1", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, TaskStatusFailed, got.Status)
+
+	_, err = history.AsOf("t1", afterSave.Add(-time.Hour))
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+}
+
+func TestTaskHistorySnapshotsAreImmutable(t *testing.T) {
+	history := NewTaskHistory(0)
+	task := &Task{ID: "t1", Type: "email", Status: TaskStatusPending, Data: map[string]any{"k": "v"}}
+	history.TaskSaved(context.Background(), task)
+
+	task.Data["k"] = "mutated"
+	got, err := history.AsOf("t1", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, "v", got.Data["k"])
+}
+
+func TestTaskHistoryTrimsToDepth(t *testing.T) {
+	history := NewTaskHistory(2)
+	ctx := context.Background()
+	history.TaskSaved(ctx, &Task{ID: "t1", Type: "email", Status: TaskStatusPending})
+	history.TaskStatusChanged(ctx, "t1", TaskStatusProcessing)
+	history.TaskStatusChanged(ctx, "t1", TaskStatusCompleted)
+
+	entries := history.History("t1")
+	require.Len(t, entries, 2)
+	// The snapshot was trimmed away, so reconstruction is honest about it.
+	_, err := history.AsOf("t1", time.Now())
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+}
+
+func TestTaskHistoryThroughWorker(t *testing.T) {
+	history := NewTaskHistory(0)
+	worker := NewWorker(WorkerConfig{
+		RepositoryListeners: []RepositoryListener{history},
+	}, newMockRepository(), nil)
+	defer worker.Shutdown(context.Background())
+
+	task := NewTask("email", nil)
+	require.NoError(t, worker.SubmitTask(context.Background(), task))
+
+	got, err := history.AsOf(task.ID, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, TaskStatusPending, got.Status)
+}
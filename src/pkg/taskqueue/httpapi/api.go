@@ -0,0 +1,148 @@
+// Package httpapi exposes the task queue as a small JSON-over-HTTP API
+// for producers: task submission, task inspection and worker statistics.
+// It complements the server package, whose endpoint is aimed at
+// operators rather than producers.
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue"
+)
+
+// Server serves the task API and ties its graceful stop to the worker:
+// Shutdown first stops accepting requests, then drains the worker.
+type Server struct {
+	worker *taskqueue.Worker
+	http   *http.Server
+}
+
+// NewServer wraps the worker in an API server listening on addr.
+func NewServer(addr string, worker *taskqueue.Worker) *Server {
+	return &Server{
+		worker: worker,
+		http: &http.Server{
+			Addr:              addr,
+			Handler:           Handler(worker),
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+	}
+}
+
+// ListenAndServe blocks serving the API until Shutdown is called.
+func (s *Server) ListenAndServe() error {
+	return s.http.ListenAndServe()
+}
+
+// Shutdown gracefully stops the HTTP listener and then the worker, so
+// accepted submissions are still processed before the pool releases.
+func (s *Server) Shutdown(ctx context.Context) error {
+	if err := s.http.Shutdown(ctx); err != nil {
+		return err
+	}
+	return s.worker.Shutdown(ctx)
+}
+
+// Handler returns the API routes wired to the worker.
+func Handler(worker *taskqueue.Worker) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /tasks", createTask(worker))
+	mux.HandleFunc("GET /tasks/{id}", getTask(worker))
+	mux.HandleFunc("GET /tasks", listTasks(worker))
+	mux.HandleFunc("GET /stats", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, worker.Stats())
+	})
+	return mux
+}
+
+// createTask decodes, validates and submits one task, answering with its
+// generated ID.
+func createTask(worker *taskqueue.Worker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var request taskqueue.CreateTaskRequest
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, taskqueue.MaxDecodedTaskSize))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := request.Validate(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		task := request.ToTask()
+		if err := worker.SubmitTask(r.Context(), task); err != nil {
+			http.Error(w, err.Error(), submissionStatus(err))
+			return
+		}
+		writeJSON(w, http.StatusCreated, map[string]any{"id": task.ID, "status": taskqueue.TaskStatusPending})
+	}
+}
+
+// getTask resolves one task by ID.
+func getTask(worker *taskqueue.Worker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		task, err := worker.GetTask(r.Context(), r.PathValue("id"))
+		if errors.Is(err, taskqueue.ErrTaskNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, task)
+	}
+}
+
+// listTasks lists stored tasks, optionally restricted with ?status=.
+func listTasks(worker *taskqueue.Worker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var filter taskqueue.TaskFilter
+		if raw := r.URL.Query().Get("status"); raw != "" {
+			status := taskqueue.TaskStatus(raw)
+			if !taskqueue.IsValidStatus(status) {
+				http.Error(w, "unknown status", http.StatusBadRequest)
+				return
+			}
+			filter.Status = status
+		}
+		tasks, err := worker.ListTasks(r.Context(), filter)
+		if errors.Is(err, taskqueue.ErrNoTaskLister) {
+			http.Error(w, err.Error(), http.StatusNotImplemented)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, tasks)
+	}
+}
+
+// submissionStatus maps a SubmitTask error to its HTTP status.
+func submissionStatus(err error) int {
+	switch {
+	case errors.Is(err, taskqueue.ErrInvalidTask), errors.Is(err, taskqueue.ErrNilTask):
+		return http.StatusBadRequest
+	case errors.Is(err, taskqueue.ErrQueueFull), errors.Is(err, taskqueue.ErrMemoryPressure), errors.Is(err, taskqueue.ErrRateLimited):
+		return http.StatusTooManyRequests
+	case errors.Is(err, taskqueue.ErrDuplicateTask):
+		return http.StatusConflict
+	case errors.Is(err, taskqueue.ErrWorkerStopped):
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// writeJSON answers with a JSON body.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,127 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue"
+	"github.com/kodflow/taskqueue/pkg/taskqueue/adapters"
+)
+
+func newTestWorker(t *testing.T) *taskqueue.Worker {
+	t.Helper()
+	w := taskqueue.NewWorker(taskqueue.WorkerConfig{WorkerCount: 1}, adapters.NewMemoryRepository(), nil)
+	w.Executors().Register("email", taskqueue.ExecutorFunc(func(_ context.Context, _ *taskqueue.Task) (*taskqueue.TaskResult, error) {
+		return &taskqueue.TaskResult{Output: "sent"}, nil
+	}))
+	t.Cleanup(func() { w.Shutdown(context.Background()) })
+	return w
+}
+
+func TestCreateAndGetTask(t *testing.T) {
+	worker := newTestWorker(t)
+	handler := Handler(worker)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(`{"type":"email","priority":3}`)))
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	var created struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	require.NotEmpty(t, created.ID)
+	assert.Equal(t, "pending", created.Status)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tasks/"+created.ID, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var task taskqueue.Task
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &task))
+	assert.Equal(t, "email", task.Type)
+	assert.Equal(t, 3, task.Priority)
+}
+
+func TestCreateTaskValidation(t *testing.T) {
+	handler := Handler(newTestWorker(t))
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "missing type", body: `{"priority":1}`},
+		{name: "negative priority", body: `{"type":"email","priority":-1}`},
+		{name: "unknown field", body: `{"type":"email","bogus":true}`},
+		{name: "malformed json", body: `{`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(tt.body)))
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+func TestGetTaskNotFound(t *testing.T) {
+	handler := Handler(newTestWorker(t))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tasks/missing", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestListTasksByStatus(t *testing.T) {
+	worker := newTestWorker(t)
+	handler := Handler(worker)
+	worker.Start()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(`{"type":"email"}`)))
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	require.Eventually(t, func() bool {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tasks?status=completed", nil))
+		if rec.Code != http.StatusOK {
+			return false
+		}
+		var tasks []*taskqueue.Task
+		return json.Unmarshal(rec.Body.Bytes(), &tasks) == nil && len(tasks) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tasks?status=bogus", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestStats(t *testing.T) {
+	handler := Handler(newTestWorker(t))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+}
+
+func TestServerShutdownStopsWorker(t *testing.T) {
+	worker := taskqueue.NewWorker(taskqueue.WorkerConfig{WorkerCount: 1}, adapters.NewMemoryRepository(), nil)
+	worker.Start()
+	server := NewServer("127.0.0.1:0", worker)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	require.NoError(t, server.Shutdown(ctx))
+
+	err := worker.SubmitTask(context.Background(), taskqueue.NewTask("email", nil))
+	assert.ErrorIs(t, err, taskqueue.ErrWorkerStopped)
+}
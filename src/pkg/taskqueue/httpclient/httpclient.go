@@ -0,0 +1,152 @@
+// Package httpclient provides a shared, tunable HTTP client factory for
+// executors. It configures connection pooling, timeouts, bounded retries
+// for idempotent requests and optional metrics, so each executor does not
+// build its own default client.
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue/metrics"
+)
+
+// Defaults applied by New when the corresponding config field is zero.
+const (
+	DefaultTimeout             = 30 * time.Second
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 10
+	DefaultIdleConnTimeout     = 90 * time.Second
+	DefaultRetryBackoff        = 250 * time.Millisecond
+)
+
+// Config tunes the shared client.
+type Config struct {
+	// Timeout bounds one request including retries.
+	Timeout time.Duration
+
+	// MaxIdleConns and MaxIdleConnsPerHost size the connection pool.
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+
+	// IdleConnTimeout closes idle pooled connections.
+	IdleConnTimeout time.Duration
+
+	// MaxRetries is the number of additional attempts for idempotent
+	// requests that fail with a transport error or a 5xx response.
+	MaxRetries int
+
+	// RetryBackoff is the base delay between attempts, doubled each try.
+	RetryBackoff time.Duration
+
+	// Metrics, when set, receives request counters and durations under
+	// the "httpclient." prefix.
+	Metrics *metrics.Collector
+}
+
+// New returns an *http.Client built from the config.
+func New(cfg Config) *http.Client {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.MaxIdleConns <= 0 {
+		cfg.MaxIdleConns = DefaultMaxIdleConns
+	}
+	if cfg.MaxIdleConnsPerHost <= 0 {
+		cfg.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	}
+	if cfg.IdleConnTimeout <= 0 {
+		cfg.IdleConnTimeout = DefaultIdleConnTimeout
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = DefaultRetryBackoff
+	}
+
+	transport := &http.Transport{
+		MaxIdleConns:        cfg.MaxIdleConns,
+		MaxIdleConnsPerHost: cfg.MaxIdleConnsPerHost,
+		IdleConnTimeout:     cfg.IdleConnTimeout,
+		Proxy:               http.ProxyFromEnvironment,
+	}
+	return &http.Client{
+		Timeout: cfg.Timeout,
+		Transport: &roundTripper{
+			next:       transport,
+			maxRetries: cfg.MaxRetries,
+			backoff:    cfg.RetryBackoff,
+			metrics:    cfg.Metrics,
+		},
+	}
+}
+
+// roundTripper adds metrics and bounded retries to a transport.
+type roundTripper struct {
+	next       http.RoundTripper
+	maxRetries int
+	backoff    time.Duration
+	metrics    *metrics.Collector
+}
+
+// RoundTrip implements http.RoundTripper.
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := rt.roundTripWithRetry(req)
+	if rt.metrics != nil {
+		rt.metrics.Observe("httpclient.request", time.Since(start))
+		switch {
+		case err != nil:
+			rt.metrics.Inc("httpclient.errors")
+		case resp.StatusCode >= 500:
+			rt.metrics.Inc("httpclient.responses_5xx")
+		default:
+			rt.metrics.Inc("httpclient.responses_ok")
+		}
+	}
+	return resp, err
+}
+
+func (rt *roundTripper) roundTripWithRetry(req *http.Request) (*http.Response, error) {
+	retries := rt.maxRetries
+	if !idempotent(req.Method) || req.Body != nil {
+		retries = 0
+	}
+	var lastErr error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			if rt.metrics != nil {
+				rt.metrics.Inc("httpclient.retries")
+			}
+			select {
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			case <-time.After(rt.backoff << (attempt - 1)):
+			}
+		}
+		resp, err := rt.next.RoundTrip(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if resp.StatusCode >= 500 && attempt < retries {
+			resp.Body.Close()
+			lastErr = fmt.Errorf("httpclient: status %d", resp.StatusCode)
+			continue
+		}
+		return resp, nil
+	}
+	if lastErr == nil {
+		lastErr = errors.New("httpclient: no attempts made")
+	}
+	return nil, lastErr
+}
+
+// idempotent reports whether the method is safe to retry.
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
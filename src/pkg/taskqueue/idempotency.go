@@ -0,0 +1,151 @@
+package taskqueue
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// IdempotencyKeyMetadataKey carries the caller-chosen key that makes a
+// task idempotent across resubmissions.
+const IdempotencyKeyMetadataKey = "taskqueue.idempotency_key"
+
+// Defaults applied by the idempotency cache when the corresponding
+// config field is zero.
+const (
+	DefaultIdempotencyTTL     = 10 * time.Minute
+	DefaultIdempotencyEntries = 1024
+)
+
+// IdempotencyConfig bounds the result cache behind idempotency keys.
+type IdempotencyConfig struct {
+	// TTL is how long a completed result answers for its key. Defaults
+	// to DefaultIdempotencyTTL.
+	TTL time.Duration
+
+	// MaxEntries caps the cache; beyond it the oldest entries are
+	// evicted. Defaults to DefaultIdempotencyEntries.
+	MaxEntries int
+}
+
+// SetIdempotencyKey marks the task idempotent: while a completed result
+// for the same key is cached, resubmissions replay it instead of
+// executing again.
+func (t *Task) SetIdempotencyKey(key string) {
+	if t.Metadata == nil {
+		t.Metadata = make(map[string]string, 1)
+	}
+	t.Metadata[IdempotencyKeyMetadataKey] = key
+}
+
+// IdempotencyKey returns the task's idempotency key, or "".
+func (t *Task) IdempotencyKey() string {
+	return t.Metadata[IdempotencyKeyMetadataKey]
+}
+
+// idempotencyEntry is one cached result with its insertion time.
+type idempotencyEntry struct {
+	result   *TaskResult
+	storedAt time.Time
+}
+
+// idempotencyCache holds completed results keyed by idempotency key,
+// bounded by TTL and entry count.
+type idempotencyCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	max     int
+	entries map[string]idempotencyEntry
+}
+
+// newIdempotencyCache builds a cache with defaults applied.
+func newIdempotencyCache(config IdempotencyConfig) *idempotencyCache {
+	if config.TTL <= 0 {
+		config.TTL = DefaultIdempotencyTTL
+	}
+	if config.MaxEntries <= 0 {
+		config.MaxEntries = DefaultIdempotencyEntries
+	}
+	return &idempotencyCache{
+		ttl:     config.TTL,
+		max:     config.MaxEntries,
+		entries: make(map[string]idempotencyEntry),
+	}
+}
+
+// get returns the live cached result for the key, expiring it lazily.
+func (c *idempotencyCache) get(key string) (*TaskResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Since(entry.storedAt) > c.ttl {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.result, true
+}
+
+// put stores a completed result, evicting the oldest entries beyond the
+// size bound.
+func (c *idempotencyCache) put(key string, result *TaskResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.entries) >= c.max {
+		if _, ok := c.entries[key]; ok {
+			break
+		}
+		oldestKey := ""
+		var oldestAt time.Time
+		for k, entry := range c.entries {
+			if oldestKey == "" || entry.storedAt.Before(oldestAt) {
+				oldestKey, oldestAt = k, entry.storedAt
+			}
+		}
+		delete(c.entries, oldestKey)
+	}
+	c.entries[key] = idempotencyEntry{result: result, storedAt: time.Now()}
+}
+
+// replayIdempotent finishes a task from the cached result of its
+// idempotency key, reporting whether it did. The replayed result keeps
+// the original output and duration but carries the current task's ID.
+func (w *Worker) replayIdempotent(ctx context.Context, task *Task) (TaskStatus, bool) {
+	if w.idempotent == nil {
+		return "", false
+	}
+	key := task.IdempotencyKey()
+	if key == "" {
+		return "", false
+	}
+	cached, ok := w.idempotent.get(key)
+	if !ok {
+		return "", false
+	}
+
+	result := *cached
+	result.TaskID = task.ID
+	w.transition(ctx, task, TaskStatusProcessing)
+	w.transition(ctx, task, TaskStatusCompleted)
+	w.stats.completed.Add(1)
+	w.emitEvent(EventCompleted, task, TaskStatusCompleted, nil)
+	w.storeResult(ctx, &result)
+	w.publishResult(ctx, &result)
+	w.recordGroup(task, true)
+	w.recordScatter(task, &result)
+	w.log(ctx).Info("taskqueue: replayed cached result", "task_id", task.ID, "idempotency_key", key)
+	return TaskStatusCompleted, true
+}
+
+// rememberIdempotent caches a completed result under the task's
+// idempotency key, if it has one.
+func (w *Worker) rememberIdempotent(task *Task, result *TaskResult) {
+	if w.idempotent == nil {
+		return
+	}
+	if key := task.IdempotencyKey(); key != "" {
+		w.idempotent.put(key, result)
+	}
+}
@@ -0,0 +1,99 @@
+package taskqueue
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newIdempotentWorker(t *testing.T, config IdempotencyConfig) (*Worker, *atomic.Int64) {
+	t.Helper()
+	var executed atomic.Int64
+	w := NewWorker(WorkerConfig{
+		WorkerCount: 1,
+		Results:     newMockResultRepository(),
+		Idempotency: &config,
+	}, newMockRepository(), nil)
+	w.Executors().Register("charge", ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		return &TaskResult{Output: strconv.FormatInt(executed.Add(1), 10)}, nil
+	}))
+	w.Start()
+	t.Cleanup(func() { w.Shutdown(context.Background()) })
+	return w, &executed
+}
+
+func submitWithKey(t *testing.T, w *Worker, key string) *TaskResult {
+	t.Helper()
+	task := NewTask("charge", nil)
+	task.SetIdempotencyKey(key)
+	require.NoError(t, w.SubmitTask(context.Background(), task))
+	var result *TaskResult
+	require.Eventually(t, func() bool {
+		var err error
+		result, err = w.GetResult(context.Background(), task.ID)
+		return err == nil
+	}, 2*time.Second, 5*time.Millisecond)
+	return result
+}
+
+func TestIdempotencyReplaysCachedResult(t *testing.T) {
+	w, executed := newIdempotentWorker(t, IdempotencyConfig{})
+
+	first := submitWithKey(t, w, "order-42")
+	second := submitWithKey(t, w, "order-42")
+
+	assert.Equal(t, first.Output, second.Output)
+	assert.Equal(t, int64(1), executed.Load(), "second submission should replay, not execute")
+
+	third := submitWithKey(t, w, "order-43")
+	assert.NotEqual(t, first.Output, third.Output)
+	assert.Equal(t, int64(2), executed.Load())
+}
+
+func TestIdempotencyTTLExpiry(t *testing.T) {
+	w, executed := newIdempotentWorker(t, IdempotencyConfig{TTL: 20 * time.Millisecond})
+
+	submitWithKey(t, w, "order-42")
+	time.Sleep(40 * time.Millisecond)
+	submitWithKey(t, w, "order-42")
+
+	assert.Equal(t, int64(2), executed.Load(), "expired entry should execute again")
+}
+
+func TestIdempotencyCapacityEvictsOldest(t *testing.T) {
+	w, executed := newIdempotentWorker(t, IdempotencyConfig{MaxEntries: 1})
+
+	submitWithKey(t, w, "order-1")
+	submitWithKey(t, w, "order-2")
+	submitWithKey(t, w, "order-1")
+
+	assert.Equal(t, int64(3), executed.Load(), "evicted key should execute again")
+}
+
+func TestIdempotencyDisabledWithoutConfig(t *testing.T) {
+	var executed atomic.Int64
+	w := NewWorker(WorkerConfig{WorkerCount: 1, Results: newMockResultRepository()}, newMockRepository(), nil)
+	w.Executors().Register("charge", ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		executed.Add(1)
+		return &TaskResult{Output: "ok"}, nil
+	}))
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	submitWithKey(t, w, "order-42")
+	submitWithKey(t, w, "order-42")
+	assert.Equal(t, int64(2), executed.Load())
+}
+
+func TestIdempotencyKeyMetadata(t *testing.T) {
+	task := NewTask("charge", nil)
+	assert.Empty(t, task.IdempotencyKey())
+	task.SetIdempotencyKey("order-42")
+	assert.Equal(t, "order-42", task.IdempotencyKey())
+	assert.Equal(t, "order-42", task.Metadata[IdempotencyKeyMetadataKey])
+}
@@ -0,0 +1,43 @@
+// Package iterx provides small generic collection helpers used by the
+// task queue and its callers. It has no dependency on the core package.
+package iterx
+
+// Map applies fn to every element of in and returns the results.
+func Map[T, U any](in []T, fn func(T) U) []U {
+	out := make([]U, len(in))
+	for i, v := range in {
+		out[i] = fn(v)
+	}
+	return out
+}
+
+// Filter returns the elements of in for which keep returns true.
+func Filter[T any](in []T, keep func(T) bool) []T {
+	out := make([]T, 0, len(in))
+	for _, v := range in {
+		if keep(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Chunk splits in into slices of at most size elements. A size below one
+// yields a single chunk.
+func Chunk[T any](in []T, size int) [][]T {
+	if size < 1 {
+		if len(in) == 0 {
+			return nil
+		}
+		return [][]T{in}
+	}
+	var out [][]T
+	for len(in) > size {
+		out = append(out, in[:size])
+		in = in[size:]
+	}
+	if len(in) > 0 {
+		out = append(out, in)
+	}
+	return out
+}
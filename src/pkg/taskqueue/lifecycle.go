@@ -0,0 +1,201 @@
+package taskqueue
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Start launches the processing goroutines. It is a no-op if the worker is
+// already running.
+func (w *Worker) Start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.started || w.stopped {
+		return
+	}
+	w.started = true
+	w.wirePlugins()
+	if w.config.WorkerCount <= 0 {
+		w.decision = decideWorkerCount(w.executors.All())
+		w.config.WorkerCount = w.decision.WorkerCount
+	}
+	for i := 0; i < w.config.WorkerCount; i++ {
+		w.wg.Add(1)
+		go w.run(w.nextWorkerID, w.named[DefaultQueueName])
+		w.nextWorkerID++
+	}
+	w.poolSize = w.config.WorkerCount
+	for _, nq := range w.named {
+		for i := 0; i < nq.workers; i++ {
+			w.wg.Add(1)
+			go w.run(w.nextWorkerID, nq)
+			w.nextWorkerID++
+		}
+	}
+	if w.overflow != nil {
+		w.drainWg.Add(1)
+		go w.drainOverflow()
+	}
+	if w.config.Heartbeat != nil {
+		interval := w.config.Heartbeat.Interval
+		if interval <= 0 {
+			interval = DefaultHeartbeatInterval
+		}
+		w.drainWg.Add(1)
+		go w.heartbeatLoop(time.Now().UTC(), interval)
+	}
+	w.logger.Info("taskqueue: worker started", "workers", w.config.WorkerCount, "buffer", w.config.BufferSize, "reason", w.decision.Reason)
+}
+
+// run is the main loop of one worker goroutine, bound to one queue.
+func (w *Worker) run(id int, nq *namedQueue) {
+	defer w.wg.Done()
+	info := &WorkerInfo{ID: id, StartedAt: time.Now().UTC()}
+	w.registry.Register(info)
+	defer w.registry.Unregister(id)
+
+	for {
+		if nq.name == DefaultQueueName && w.takeShrink() {
+			return
+		}
+		task, err := nq.queue.Dequeue(context.Background())
+		if err != nil {
+			return
+		}
+		if ticker := w.pace.Load(); ticker != nil {
+			<-ticker.C
+		}
+		// A paused pool holds dequeued tasks here until Resume; shutdown
+		// releases the gate so the backlog still drains.
+		if gate := w.gate.Load(); gate != nil {
+			select {
+			case <-*gate:
+			case <-w.stopCh:
+			}
+		}
+		if w.redirect(task, nq) {
+			continue
+		}
+		info.CurrentTaskID = task.ID
+		switch w.processTask(task) {
+		case TaskStatusCompleted:
+			nq.stats.completed.Add(1)
+		case TaskStatusFailed:
+			nq.stats.failed.Add(1)
+		case TaskStatusExpired:
+			nq.stats.expired.Add(1)
+		}
+		info.CurrentTaskID = ""
+	}
+}
+
+// Resize adjusts the default-queue pool to n workers. Growth starts new
+// goroutines immediately; shrinking is cooperative and takes effect as
+// workers come back for their next task, so in-flight work is never
+// interrupted. Before Start it only records the new count.
+func (w *Worker) Resize(n int) error {
+	if n <= 0 {
+		return fmt.Errorf("taskqueue: resize: worker count must be positive, got %d", n)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stopped {
+		return ErrWorkerStopped
+	}
+	if !w.started {
+		w.config.WorkerCount = n
+		return nil
+	}
+	effective := w.poolSize - w.shrink
+	switch {
+	case n > effective:
+		grow := n - effective
+		if cancelled := min(w.shrink, grow); cancelled > 0 {
+			w.shrink -= cancelled
+			grow -= cancelled
+		}
+		for i := 0; i < grow; i++ {
+			w.wg.Add(1)
+			go w.run(w.nextWorkerID, w.named[DefaultQueueName])
+			w.nextWorkerID++
+		}
+		w.poolSize += grow
+	case n < effective:
+		w.shrink += effective - n
+	}
+	w.logger.Info("taskqueue: pool resized", "workers", n)
+	return nil
+}
+
+// PoolSize returns the target size of the default-queue pool, counting
+// pending cooperative shrinks as already applied.
+func (w *Worker) PoolSize() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.poolSize - w.shrink
+}
+
+// takeShrink consumes one pending shrink token, telling the calling
+// worker goroutine to exit.
+func (w *Worker) takeShrink() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.shrink == 0 {
+		return false
+	}
+	w.shrink--
+	w.poolSize--
+	return true
+}
+
+// Shutdown stops intake, waits for in-flight tasks to finish and releases
+// the pool. It is safe to call multiple times.
+func (w *Worker) Shutdown(ctx context.Context) error {
+	if !w.stopIntake() {
+		return nil
+	}
+	w.drainWg.Wait()
+	return w.release(ctx)
+}
+
+// stopIntake marks the worker stopped so submissions are rejected,
+// returning false when it already was.
+func (w *Worker) stopIntake() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stopped {
+		return false
+	}
+	w.stopped = true
+	close(w.stopCh)
+	return true
+}
+
+// release closes the buffers and waits for the pool to finish the
+// remaining backlog.
+func (w *Worker) release(ctx context.Context) error {
+	if w.overflow != nil {
+		if err := w.overflow.Close(); err != nil {
+			w.logger.Error("taskqueue: close overflow queue", "error", err)
+		}
+	}
+	for _, nq := range w.named {
+		if err := nq.queue.Close(); err != nil {
+			w.logger.Error("taskqueue: close queue", "queue", nq.name, "error", err)
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		w.logger.Info("taskqueue: worker stopped")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,73 @@
+package taskqueue
+
+import "context"
+
+// RepositoryListener observes successful repository writes so secondary
+// structures — status indexes, caches, search — can stay in sync without
+// polling. Callbacks run synchronously after the write succeeds, on the
+// writer's goroutine; delivery is therefore at-least-once: a crash
+// between the write and the callback means the change is only seen on
+// the next write touching the same task. Listeners must tolerate
+// duplicate notifications and should not block.
+type RepositoryListener interface {
+	// TaskSaved is called after a task was persisted via Save.
+	TaskSaved(ctx context.Context, task *Task)
+
+	// TaskStatusChanged is called after a status transition was
+	// persisted via UpdateStatus.
+	TaskStatusChanged(ctx context.Context, id string, status TaskStatus)
+}
+
+// listenedRepository notifies listeners after successful writes.
+type listenedRepository struct {
+	next      TaskRepository
+	listeners []RepositoryListener
+}
+
+// ListenRepository decorates the repository so every successful Save and
+// UpdateStatus is reported to the listeners, in order. Reads pass
+// through untouched.
+func ListenRepository(next TaskRepository, listeners ...RepositoryListener) TaskRepository {
+	if len(listeners) == 0 {
+		return next
+	}
+	return &listenedRepository{next: next, listeners: listeners}
+}
+
+// Unwrap returns the decorated repository so optional capabilities
+// remain reachable via repositoryAs.
+func (r *listenedRepository) Unwrap() TaskRepository {
+	return r.next
+}
+
+// Save implements TaskRepository.
+func (r *listenedRepository) Save(ctx context.Context, task *Task) error {
+	if err := r.next.Save(ctx, task); err != nil {
+		return err
+	}
+	for _, listener := range r.listeners {
+		listener.TaskSaved(ctx, task)
+	}
+	return nil
+}
+
+// GetByID implements TaskRepository.
+func (r *listenedRepository) GetByID(ctx context.Context, id string) (*Task, error) {
+	return r.next.GetByID(ctx, id)
+}
+
+// ListPending implements TaskRepository.
+func (r *listenedRepository) ListPending(ctx context.Context, limit int) ([]*Task, error) {
+	return r.next.ListPending(ctx, limit)
+}
+
+// UpdateStatus implements TaskRepository.
+func (r *listenedRepository) UpdateStatus(ctx context.Context, id string, status TaskStatus) error {
+	if err := r.next.UpdateStatus(ctx, id, status); err != nil {
+		return err
+	}
+	for _, listener := range r.listeners {
+		listener.TaskStatusChanged(ctx, id, status)
+	}
+	return nil
+}
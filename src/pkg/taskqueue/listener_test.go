@@ -0,0 +1,74 @@
+package taskqueue
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingListener captures repository notifications.
+type recordingListener struct {
+	mu       sync.Mutex
+	saved    []string
+	statuses map[string]TaskStatus
+}
+
+func newRecordingListener() *recordingListener {
+	return &recordingListener{statuses: make(map[string]TaskStatus)}
+}
+
+func (l *recordingListener) TaskSaved(_ context.Context, task *Task) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.saved = append(l.saved, task.ID)
+}
+
+func (l *recordingListener) TaskStatusChanged(_ context.Context, id string, status TaskStatus) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.statuses[id] = status
+}
+
+func TestListenRepositoryNotifiesWrites(t *testing.T) {
+	listener := newRecordingListener()
+	repo := ListenRepository(newMockRepository(), listener)
+
+	ctx := context.Background()
+	require.NoError(t, repo.Save(ctx, &Task{ID: "t1", Type: "email", Status: TaskStatusPending}))
+	require.NoError(t, repo.UpdateStatus(ctx, "t1", TaskStatusCompleted))
+
+	assert.Equal(t, []string{"t1"}, listener.saved)
+	assert.Equal(t, TaskStatusCompleted, listener.statuses["t1"])
+}
+
+func TestListenRepositorySkipsFailedWrites(t *testing.T) {
+	listener := newRecordingListener()
+	repo := ListenRepository(newMockRepository(), listener)
+
+	err := repo.UpdateStatus(context.Background(), "missing", TaskStatusCompleted)
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+	assert.Empty(t, listener.statuses)
+}
+
+func TestListenRepositoryWithoutListeners(t *testing.T) {
+	inner := newMockRepository()
+	assert.Same(t, TaskRepository(inner), ListenRepository(inner))
+}
+
+func TestWorkerWiresRepositoryListeners(t *testing.T) {
+	listener := newRecordingListener()
+	worker := NewWorker(WorkerConfig{
+		RepositoryListeners: []RepositoryListener{listener},
+	}, newMockRepository(), nil)
+	defer worker.Shutdown(context.Background())
+
+	task := NewTask("email", nil)
+	require.NoError(t, worker.SubmitTask(context.Background(), task))
+
+	listener.mu.Lock()
+	defer listener.mu.Unlock()
+	assert.Contains(t, listener.saved, task.ID)
+}
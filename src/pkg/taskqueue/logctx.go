@@ -0,0 +1,82 @@
+package taskqueue
+
+import (
+	"context"
+	"log/slog"
+)
+
+// loggerKey is the context key for request-scoped loggers.
+type loggerKey struct{}
+
+// userKey is the context key for user IDs.
+type userKey struct{}
+
+// WithLogger returns a context carrying the logger. Code further down
+// the call chain retrieves it with LoggerFromContext, so handlers and
+// executors write through the same pre-annotated logger as the worker.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// WithUserID returns a context carrying the acting user's ID, picked up
+// by LoggerFromContext the same way correlation and trace IDs are.
+func WithUserID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, userKey{}, id)
+}
+
+// UserIDFromContext returns the user ID carried by the context, if any.
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(userKey{}).(string)
+	return id, ok && id != ""
+}
+
+// LoggerFromContext returns the logger carried by the context. Contexts
+// without one fall back to the default slog logger annotated with the
+// correlation, trace and user IDs the context carries, so log
+// correlation works even outside a worker.
+func LoggerFromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return annotateLogger(ctx, slog.Default())
+}
+
+// annotateLogger attaches the context's IDs to the logger.
+func annotateLogger(ctx context.Context, logger *slog.Logger) *slog.Logger {
+	if id, ok := CorrelationIDFromContext(ctx); ok {
+		logger = logger.With("correlation_id", id)
+	}
+	if id, ok := TraceIDFromContext(ctx); ok {
+		logger = logger.With("trace_id", id)
+	}
+	if id, ok := UserIDFromContext(ctx); ok {
+		logger = logger.With("user_id", id)
+	}
+	return logger
+}
+
+// taskLogger builds the processing logger for one task: the worker
+// logger annotated with the task, correlation, trace and user IDs, so
+// every record written while the task runs correlates automatically.
+func (w *Worker) taskLogger(ctx context.Context, task *Task) *slog.Logger {
+	logger := w.logger.With("task_id", task.ID, "task_type", task.Type)
+	if id := task.CorrelationID(); id != "" {
+		logger = logger.With("correlation_id", id)
+	}
+	if id := task.Metadata[TraceIDMetadataKey]; id != "" {
+		logger = logger.With("trace_id", id)
+	}
+	if id, ok := UserIDFromContext(ctx); ok {
+		logger = logger.With("user_id", id)
+	}
+	return logger
+}
+
+// log returns the context's logger, falling back to the worker logger
+// for contexts outside the processing path.
+func (w *Worker) log(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return w.logger
+}
@@ -0,0 +1,91 @@
+package taskqueue
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syncWriter serializes writes so worker goroutines can log while the
+// test reads.
+type syncWriter struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *syncWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+func TestWithLoggerRoundTrip(t *testing.T) {
+	var out syncWriter
+	logger := slog.New(slog.NewTextHandler(&out, nil))
+
+	ctx := WithLogger(context.Background(), logger)
+	LoggerFromContext(ctx).Info("hello")
+
+	assert.Contains(t, out.String(), "hello")
+}
+
+func TestLoggerFromContextAnnotatesFallback(t *testing.T) {
+	var out syncWriter
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&out, nil)))
+	defer slog.SetDefault(previous)
+
+	ctx := WithCorrelationID(context.Background(), "c9")
+	ctx = WithTraceID(ctx, "trace-1")
+	ctx = WithUserID(ctx, "u7")
+	LoggerFromContext(ctx).Info("annotated")
+
+	logged := out.String()
+	assert.Contains(t, logged, "correlation_id=c9")
+	assert.Contains(t, logged, "trace_id=trace-1")
+	assert.Contains(t, logged, "user_id=u7")
+}
+
+func TestUserIDFromContext(t *testing.T) {
+	_, ok := UserIDFromContext(context.Background())
+	assert.False(t, ok)
+
+	id, ok := UserIDFromContext(WithUserID(context.Background(), "u7"))
+	assert.True(t, ok)
+	assert.Equal(t, "u7", id)
+}
+
+func TestProcessingLogsCarryTaskContext(t *testing.T) {
+	var out syncWriter
+	logger := slog.New(slog.NewTextHandler(&out, nil))
+	w := NewWorker(WorkerConfig{WorkerCount: 1, Logger: logger}, newMockRepository(), nil)
+	w.Executors().Register("email", ExecutorFunc(func(ctx context.Context, _ *Task) (*TaskResult, error) {
+		LoggerFromContext(ctx).Info("executor speaking")
+		return nil, assert.AnError
+	}))
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	task := NewTask("email", nil)
+	task.Metadata = map[string]string{CorrelationIDMetadataKey: "c9"}
+	require.NoError(t, w.SubmitTask(context.Background(), task))
+	require.Eventually(t, func() bool { return w.Stats().Failed == 1 }, 2*time.Second, 5*time.Millisecond)
+
+	logged := out.String()
+	assert.Contains(t, logged, "executor speaking")
+	assert.Contains(t, logged, "task_id="+task.ID)
+	assert.Contains(t, logged, "correlation_id=c9")
+	assert.Contains(t, logged, "task failed")
+}
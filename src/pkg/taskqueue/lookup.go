@@ -0,0 +1,22 @@
+package taskqueue
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetTask returns the task with the given ID, consulting the in-memory
+// cache first and falling back to the repository. Repository hits are
+// stored back into the cache so repeated lookups stay hot. It returns
+// ErrTaskNotFound when neither knows the task.
+func (w *Worker) GetTask(ctx context.Context, id string) (*Task, error) {
+	if task, ok := w.cache.Load(id); ok {
+		return task, nil
+	}
+	task, err := w.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("taskqueue: get task %s: %w", id, err)
+	}
+	w.cache.Store(task)
+	return task, nil
+}
@@ -0,0 +1,47 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTaskReadsThroughCache(t *testing.T) {
+	repo := newMockRepository()
+	worker := NewWorker(WorkerConfig{}, repo, nil)
+	defer worker.Shutdown(context.Background())
+
+	task := NewTask("email", nil)
+	require.NoError(t, worker.SubmitTask(context.Background(), task))
+
+	got, err := worker.GetTask(context.Background(), task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, task.ID, got.ID)
+}
+
+func TestGetTaskFallsBackToRepository(t *testing.T) {
+	repo := newMockRepository()
+	worker := NewWorker(WorkerConfig{}, repo, nil)
+	defer worker.Shutdown(context.Background())
+
+	task := &Task{ID: "cold-1", Type: "email", Status: TaskStatusCompleted}
+	require.NoError(t, repo.Save(context.Background(), task))
+
+	got, err := worker.GetTask(context.Background(), "cold-1")
+	require.NoError(t, err)
+	assert.Equal(t, "cold-1", got.ID)
+
+	// The fallback refreshed the cache.
+	_, ok := worker.cache.Load("cold-1")
+	assert.True(t, ok)
+}
+
+func TestGetTaskNotFound(t *testing.T) {
+	worker := NewWorker(WorkerConfig{}, newMockRepository(), nil)
+	defer worker.Shutdown(context.Background())
+
+	_, err := worker.GetTask(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+}
@@ -0,0 +1,213 @@
+package taskqueue
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// ManagedPublisher is a Publisher with an explicit connection lifecycle,
+// for brokers that need a session to be opened and torn down.
+type ManagedPublisher interface {
+	Publisher
+
+	// Connect establishes the broker session.
+	Connect(ctx context.Context) error
+
+	// Close tears the session down. It is safe to call multiple times.
+	Close() error
+}
+
+// PublisherHealth is the connection state of a ReconnectingPublisher,
+// for health checks.
+type PublisherHealth struct {
+	Connected bool   `json:"connected"`
+	Buffered  int    `json:"buffered"`
+	Dropped   uint64 `json:"dropped"`
+}
+
+// HealthReporter is implemented by publishers that can report their
+// connection health.
+type HealthReporter interface {
+	Health() PublisherHealth
+}
+
+// ReconnectingPublisherConfig tunes a ReconnectingPublisher. Every field
+// has a usable default.
+type ReconnectingPublisherConfig struct {
+	// MaxBuffer is how many messages the degraded-mode buffer holds
+	// while the broker is down; beyond it the oldest are dropped.
+	// Defaults to 1024.
+	MaxBuffer int
+
+	// InitialBackoff is the first reconnect delay. Defaults to 100ms.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the exponential reconnect delay. Defaults to 30s.
+	MaxBackoff time.Duration
+
+	// Logger receives connection transitions. Defaults to slog.Default.
+	Logger *slog.Logger
+}
+
+// bufferedMessage is one message held while the broker is down.
+type bufferedMessage struct {
+	topic   string
+	payload []byte
+}
+
+// ReconnectingPublisher wraps a ManagedPublisher with automatic
+// reconnection and exponential backoff. While the broker is down it
+// degrades into a bounded in-memory buffer instead of failing the
+// worker's publish path, flushing once the connection returns.
+type ReconnectingPublisher struct {
+	next   ManagedPublisher
+	config ReconnectingPublisherConfig
+
+	mu          sync.Mutex
+	connected   bool
+	backoff     time.Duration
+	nextAttempt time.Time
+	buffer      []bufferedMessage
+	dropped     uint64
+}
+
+// NewReconnectingPublisher wraps next, applying defaults for zero config
+// fields. Call Connect to establish the initial session; until then the
+// publisher starts degraded.
+func NewReconnectingPublisher(next ManagedPublisher, config ReconnectingPublisherConfig) *ReconnectingPublisher {
+	if config.MaxBuffer <= 0 {
+		config.MaxBuffer = 1024
+	}
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = 100 * time.Millisecond
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = 30 * time.Second
+	}
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+	return &ReconnectingPublisher{
+		next:    next,
+		config:  config,
+		backoff: config.InitialBackoff,
+	}
+}
+
+// Connect implements ManagedPublisher, opening the broker session and
+// flushing anything buffered while it was down.
+func (p *ReconnectingPublisher) Connect(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.connect(ctx)
+}
+
+// connect opens the session and flushes the buffer. Callers hold p.mu.
+func (p *ReconnectingPublisher) connect(ctx context.Context) error {
+	if p.connected {
+		return nil
+	}
+	if err := p.next.Connect(ctx); err != nil {
+		p.nextAttempt = time.Now().Add(p.retryIn(err))
+		p.backoff = min(p.backoff*2, p.config.MaxBackoff)
+		return err
+	}
+	p.connected = true
+	p.backoff = p.config.InitialBackoff
+	p.config.Logger.Info("taskqueue: publisher connected", "buffered", len(p.buffer))
+	p.flush(ctx)
+	return nil
+}
+
+// flush republishes buffered messages in order, stopping at the first
+// failure. Callers hold p.mu.
+func (p *ReconnectingPublisher) flush(ctx context.Context) {
+	for len(p.buffer) > 0 {
+		msg := p.buffer[0]
+		if err := p.next.Publish(ctx, msg.topic, msg.payload); err != nil {
+			p.degrade(err)
+			return
+		}
+		p.buffer = p.buffer[1:]
+	}
+}
+
+// degrade marks the connection down and schedules the next attempt.
+// Callers hold p.mu.
+func (p *ReconnectingPublisher) degrade(err error) {
+	p.connected = false
+	p.nextAttempt = time.Now().Add(p.retryIn(err))
+	p.backoff = min(p.backoff*2, p.config.MaxBackoff)
+	p.config.Logger.Warn("taskqueue: publisher degraded, buffering", "error", err, "retry_in", time.Until(p.nextAttempt))
+}
+
+// retryIn returns the wait before the next connection attempt, honoring
+// an explicit RetryAfter hint on err over the exponential backoff.
+func (p *ReconnectingPublisher) retryIn(err error) time.Duration {
+	if hint, ok := RetryAfterHint(err); ok && hint > 0 {
+		return hint
+	}
+	return p.backoff
+}
+
+// Publish implements Publisher. While the broker is down, messages are
+// buffered (dropping the oldest beyond MaxBuffer) and nil is returned,
+// so the worker keeps processing through outages.
+func (p *ReconnectingPublisher) Publish(ctx context.Context, topic string, payload []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.connected && time.Now().After(p.nextAttempt) {
+		if err := p.connect(ctx); err != nil {
+			p.config.Logger.Warn("taskqueue: publisher reconnect failed", "error", err, "retry_in", time.Until(p.nextAttempt))
+		}
+	}
+	if p.connected {
+		err := p.next.Publish(ctx, topic, payload)
+		if err == nil {
+			return nil
+		}
+		p.degrade(err)
+	}
+
+	if len(p.buffer) >= p.config.MaxBuffer {
+		p.buffer = p.buffer[1:]
+		p.dropped++
+	}
+	p.buffer = append(p.buffer, bufferedMessage{topic: topic, payload: payload})
+	return nil
+}
+
+// Close implements ManagedPublisher.
+func (p *ReconnectingPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.connected {
+		return nil
+	}
+	p.connected = false
+	return p.next.Close()
+}
+
+// Health implements HealthReporter.
+func (p *ReconnectingPublisher) Health() PublisherHealth {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return PublisherHealth{
+		Connected: p.connected,
+		Buffered:  len(p.buffer),
+		Dropped:   p.dropped,
+	}
+}
+
+// PublisherHealth reports the health of the worker's publisher when it
+// implements HealthReporter; ok is false otherwise.
+func (w *Worker) PublisherHealth() (PublisherHealth, bool) {
+	reporter, ok := w.publisher.(HealthReporter)
+	if !ok {
+		return PublisherHealth{}, false
+	}
+	return reporter.Health(), true
+}
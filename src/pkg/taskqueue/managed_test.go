@@ -0,0 +1,123 @@
+package taskqueue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue/testkit"
+)
+
+// flakyBroker is a ManagedPublisher whose connection can be failed on
+// demand.
+type flakyBroker struct {
+	mu        sync.Mutex
+	down      bool
+	connects  int
+	published []string
+}
+
+func (b *flakyBroker) Connect(context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.connects++
+	if b.down {
+		return assert.AnError
+	}
+	return nil
+}
+
+func (b *flakyBroker) Publish(_ context.Context, _ string, payload []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.down {
+		return assert.AnError
+	}
+	b.published = append(b.published, string(payload))
+	return nil
+}
+
+func (b *flakyBroker) Close() error { return nil }
+
+func (b *flakyBroker) setDown(down bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.down = down
+}
+
+func TestReconnectingPublisherBuffersWhileDownAndFlushes(t *testing.T) {
+	testkit.LeakCheck(t)
+	broker := &flakyBroker{}
+	publisher := NewReconnectingPublisher(broker, ReconnectingPublisherConfig{InitialBackoff: 5 * time.Millisecond})
+
+	ctx := context.Background()
+	require.NoError(t, publisher.Connect(ctx))
+	require.NoError(t, publisher.Publish(ctx, "task.results", []byte("m1")))
+
+	broker.setDown(true)
+	require.NoError(t, publisher.Publish(ctx, "task.results", []byte("m2")))
+	require.NoError(t, publisher.Publish(ctx, "task.results", []byte("m3")))
+
+	health := publisher.Health()
+	assert.False(t, health.Connected)
+	assert.Equal(t, 2, health.Buffered)
+
+	broker.setDown(false)
+	time.Sleep(15 * time.Millisecond)
+	require.NoError(t, publisher.Publish(ctx, "task.results", []byte("m4")))
+
+	assert.Equal(t, []string{"m1", "m2", "m3", "m4"}, broker.published)
+	health = publisher.Health()
+	assert.True(t, health.Connected)
+	assert.Zero(t, health.Buffered)
+}
+
+func TestReconnectingPublisherDropsOldestBeyondCapacity(t *testing.T) {
+	broker := &flakyBroker{down: true}
+	publisher := NewReconnectingPublisher(broker, ReconnectingPublisherConfig{
+		MaxBuffer:      2,
+		InitialBackoff: time.Hour,
+	})
+
+	ctx := context.Background()
+	require.NoError(t, publisher.Publish(ctx, "t", []byte("m1")))
+	require.NoError(t, publisher.Publish(ctx, "t", []byte("m2")))
+	require.NoError(t, publisher.Publish(ctx, "t", []byte("m3")))
+
+	health := publisher.Health()
+	assert.Equal(t, 2, health.Buffered)
+	assert.EqualValues(t, 1, health.Dropped)
+}
+
+func TestReconnectingPublisherBacksOffBetweenAttempts(t *testing.T) {
+	broker := &flakyBroker{down: true}
+	publisher := NewReconnectingPublisher(broker, ReconnectingPublisherConfig{InitialBackoff: time.Hour})
+
+	ctx := context.Background()
+	require.Error(t, publisher.Connect(ctx))
+	// Within the backoff window, publishes buffer without dialing again.
+	require.NoError(t, publisher.Publish(ctx, "t", []byte("m1")))
+	require.NoError(t, publisher.Publish(ctx, "t", []byte("m2")))
+
+	broker.mu.Lock()
+	defer broker.mu.Unlock()
+	assert.Equal(t, 1, broker.connects)
+}
+
+func TestWorkerExposesPublisherHealth(t *testing.T) {
+	broker := &flakyBroker{}
+	publisher := NewReconnectingPublisher(broker, ReconnectingPublisherConfig{})
+	worker := NewWorker(WorkerConfig{}, newMockRepository(), publisher)
+
+	health, ok := worker.PublisherHealth()
+	require.True(t, ok)
+	assert.False(t, health.Connected)
+
+	plain := NewWorker(WorkerConfig{}, newMockRepository(), newMockPublisher())
+	_, ok = plain.PublisherHealth()
+	assert.False(t, ok)
+}
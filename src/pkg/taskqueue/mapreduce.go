@@ -0,0 +1,61 @@
+package taskqueue
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Reducer aggregates the mapped task results into one final output.
+type Reducer func(ctx context.Context, results []*TaskResult) (string, error)
+
+// MapReduce fans the items out as one task of the given type each (map),
+// waits for the whole group to land, and runs the reducer over the
+// collected outputs. The aggregate is persisted as a new task result of
+// type "<taskType>.reduce" and returned. It requires
+// WorkerConfig.Results so the mapped outputs can be collected.
+func (w *Worker) MapReduce(ctx context.Context, taskType string, items []map[string]any, reduce Reducer) (*TaskResult, error) {
+	if w.config.Results == nil {
+		return nil, ErrNoResultRepository
+	}
+
+	start := time.Now()
+	tasks := make([]*Task, len(items))
+	for n, item := range items {
+		tasks[n] = NewTask(taskType, item)
+	}
+	groupID, done, err := w.SubmitGroup(ctx, tasks)
+	if err != nil {
+		return nil, fmt.Errorf("taskqueue: map %s: %w", taskType, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-done:
+	}
+
+	results := make([]*TaskResult, 0, len(tasks))
+	for _, task := range tasks {
+		result, err := w.GetResult(ctx, task.ID)
+		if err != nil {
+			return nil, fmt.Errorf("taskqueue: collect result of %s: %w", task.ID, err)
+		}
+		results = append(results, result)
+	}
+
+	output, err := reduce(ctx, results)
+	if err != nil {
+		return nil, fmt.Errorf("taskqueue: reduce %s: %w", taskType, err)
+	}
+	aggregate := &TaskResult{
+		TaskID:      groupID,
+		TaskType:    taskType + ".reduce",
+		Success:     true,
+		Output:      output,
+		Duration:    time.Since(start),
+		CompletedAt: time.Now().UTC(),
+	}
+	w.storeResult(ctx, aggregate)
+	return aggregate, nil
+}
@@ -0,0 +1,71 @@
+package taskqueue
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapReduceAggregatesOutputs(t *testing.T) {
+	results := newMockResultRepository()
+	w := NewWorker(WorkerConfig{WorkerCount: 2, Results: results}, newMockRepository(), nil)
+	w.Executors().Register("square", ExecutorFunc(func(_ context.Context, task *Task) (*TaskResult, error) {
+		n := task.Data["n"].(int)
+		return &TaskResult{Output: strconv.Itoa(n * n)}, nil
+	}))
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	items := []map[string]any{{"n": 1}, {"n": 2}, {"n": 3}}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	aggregate, err := w.MapReduce(ctx, "square", items, func(_ context.Context, results []*TaskResult) (string, error) {
+		sum := 0
+		for _, result := range results {
+			n, err := strconv.Atoi(result.Output)
+			if err != nil {
+				return "", err
+			}
+			sum += n
+		}
+		return strconv.Itoa(sum), nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "14", aggregate.Output)
+	assert.Equal(t, "square.reduce", aggregate.TaskType)
+	assert.True(t, aggregate.Success)
+
+	stored, err := results.GetResult(context.Background(), aggregate.TaskID)
+	require.NoError(t, err)
+	assert.Equal(t, "14", stored.Output)
+}
+
+func TestMapReduceSurfacesReducerErrors(t *testing.T) {
+	w := NewWorker(WorkerConfig{WorkerCount: 1, Results: newMockResultRepository()}, newMockRepository(), nil)
+	w.Executors().Register("noop", ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		return &TaskResult{Output: "ok"}, nil
+	}))
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_, err := w.MapReduce(ctx, "noop", []map[string]any{{}}, func(_ context.Context, _ []*TaskResult) (string, error) {
+		return "", fmt.Errorf("cannot aggregate")
+	})
+	assert.ErrorContains(t, err, "cannot aggregate")
+}
+
+func TestMapReduceRequiresResultRepository(t *testing.T) {
+	w := NewWorker(WorkerConfig{}, newMockRepository(), nil)
+	defer w.Shutdown(context.Background())
+
+	_, err := w.MapReduce(context.Background(), "noop", []map[string]any{{}}, nil)
+	assert.ErrorIs(t, err, ErrNoResultRepository)
+}
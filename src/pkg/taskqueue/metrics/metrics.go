@@ -0,0 +1,123 @@
+// Package metrics provides a lightweight, dependency-free metrics
+// collector for the task queue. It is intentionally minimal: counters and
+// duration observations that an exporter or the admin API can snapshot.
+package metrics
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Collector accumulates named counters and duration observations. The zero
+// value is not usable; use NewCollector.
+type Collector struct {
+	counters  sync.Map // string -> *atomic.Uint64
+	durations sync.Map // string -> *durationTrack
+}
+
+// durationTrack accumulates observations for one duration metric.
+type durationTrack struct {
+	count atomic.Uint64
+	sum   atomic.Int64 // nanoseconds
+	max   atomic.Int64 // nanoseconds
+}
+
+// DurationStats is a snapshot of one duration metric.
+type DurationStats struct {
+	Count uint64        `json:"count"`
+	Sum   time.Duration `json:"sum"`
+	Max   time.Duration `json:"max"`
+}
+
+// Mean returns the average observed duration, or zero if none were recorded.
+func (s DurationStats) Mean() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Sum / time.Duration(s.Count)
+}
+
+// NewCollector returns an empty collector.
+func NewCollector() *Collector {
+	return &Collector{}
+}
+
+// Inc increments the named counter by one.
+func (c *Collector) Inc(name string) {
+	c.Add(name, 1)
+}
+
+// Add increments the named counter by n.
+func (c *Collector) Add(name string, n uint64) {
+	v, ok := c.counters.Load(name)
+	if !ok {
+		v, _ = c.counters.LoadOrStore(name, new(atomic.Uint64))
+	}
+	v.(*atomic.Uint64).Add(n)
+}
+
+// Counter returns the current value of the named counter.
+func (c *Collector) Counter(name string) uint64 {
+	v, ok := c.counters.Load(name)
+	if !ok {
+		return 0
+	}
+	return v.(*atomic.Uint64).Load()
+}
+
+// Observe records one duration observation under the given name.
+func (c *Collector) Observe(name string, d time.Duration) {
+	v, ok := c.durations.Load(name)
+	if !ok {
+		v, _ = c.durations.LoadOrStore(name, new(durationTrack))
+	}
+	track := v.(*durationTrack)
+	track.count.Add(1)
+	track.sum.Add(int64(d))
+	for {
+		cur := track.max.Load()
+		if int64(d) <= cur || track.max.CompareAndSwap(cur, int64(d)) {
+			break
+		}
+	}
+}
+
+// Duration returns a snapshot of the named duration metric.
+func (c *Collector) Duration(name string) DurationStats {
+	v, ok := c.durations.Load(name)
+	if !ok {
+		return DurationStats{}
+	}
+	track := v.(*durationTrack)
+	return DurationStats{
+		Count: track.count.Load(),
+		Sum:   time.Duration(track.sum.Load()),
+		Max:   time.Duration(track.max.Load()),
+	}
+}
+
+// Counters returns a copy of all counter values.
+func (c *Collector) Counters() map[string]uint64 {
+	out := make(map[string]uint64)
+	c.counters.Range(func(k, v any) bool {
+		out[k.(string)] = v.(*atomic.Uint64).Load()
+		return true
+	})
+	return out
+}
+
+// Durations returns a copy of all duration metrics.
+func (c *Collector) Durations() map[string]DurationStats {
+	out := make(map[string]DurationStats)
+	c.durations.Range(func(k, v any) bool {
+		track := v.(*durationTrack)
+		out[k.(string)] = DurationStats{
+			Count: track.count.Load(),
+			Sum:   time.Duration(track.sum.Load()),
+			Max:   time.Duration(track.max.Load()),
+		}
+		return true
+	})
+	return out
+}
@@ -0,0 +1,87 @@
+package taskqueue
+
+import (
+	"context"
+	"fmt"
+)
+
+// MovedFromMetadataKey records the queue a task was moved out of.
+const MovedFromMetadataKey = "taskqueue.moved_from"
+
+// EventMoved is emitted when a task is moved between named queues.
+const EventMoved EventKind = "moved"
+
+// Move reroutes a buffered task from one named queue to another, so
+// operators can promote stuck bulk tasks into the critical queue or
+// demote floods. The task keeps its ID, metadata and enqueue ordering
+// stamps; a moved audit event is emitted.
+//
+// A channel-backed queue cannot be plucked by ID, so the move is applied
+// lazily: the task is marked and handed to the target queue by whichever
+// worker dequeues it first.
+func (w *Worker) Move(ctx context.Context, taskID, fromQueue, toQueue string) error {
+	if _, ok := w.named[fromQueue]; !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownQueue, fromQueue)
+	}
+	if _, ok := w.named[toQueue]; !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownQueue, toQueue)
+	}
+	if fromQueue == toQueue {
+		return nil
+	}
+	task, ok := w.cache.Load(taskID)
+	if !ok {
+		var err error
+		if task, err = w.repo.GetByID(ctx, taskID); err != nil {
+			return err
+		}
+	}
+	current := task.Metadata[QueueMetadataKey]
+	if current == "" {
+		current = DefaultQueueName
+	}
+	if current != fromQueue {
+		return fmt.Errorf("%w: task %s is on queue %q, not %q", ErrTaskNotFound, taskID, current, fromQueue)
+	}
+
+	w.moves.Store(taskID, toQueue)
+	w.logger.Info("taskqueue: task moved", "task_id", taskID, "from", fromQueue, "to", toQueue)
+	w.emitEvent(EventMoved, task, TaskStatusPending, nil)
+	return nil
+}
+
+// redirect checks whether a dequeued task was moved to another queue and
+// hands it over when so, returning true when the caller should skip it.
+func (w *Worker) redirect(task *Task, nq *namedQueue) bool {
+	target, ok := w.moves.Load(task.ID)
+	if !ok {
+		return false
+	}
+	if target == nq.name {
+		w.moves.Delete(task.ID)
+		return false
+	}
+	dest, ok := w.named[target]
+	if !ok {
+		w.moves.Delete(task.ID)
+		return false
+	}
+	if task.Metadata == nil {
+		task.Metadata = make(map[string]string)
+	}
+	task.Metadata[MovedFromMetadataKey] = nq.name
+	if target == DefaultQueueName {
+		delete(task.Metadata, QueueMetadataKey)
+	} else {
+		task.Metadata[QueueMetadataKey] = target
+	}
+	if err := dest.queue.Enqueue(task); err != nil {
+		// The target queue is full or closed; process in place rather
+		// than lose the task.
+		w.moves.Delete(task.ID)
+		w.logger.Error("taskqueue: move handover failed, processing in place",
+			"task_id", task.ID, "to", target, "error", err)
+		return false
+	}
+	return true
+}
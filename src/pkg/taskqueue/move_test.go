@@ -0,0 +1,78 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMovePromotesTaskToAnotherQueue(t *testing.T) {
+	worker := NewWorker(WorkerConfig{
+		WorkerCount: 1,
+		Queues:      map[string]QueueConfig{"bulk": {Workers: 1}, "critical": {Workers: 1}},
+	}, newMockRepository(), nil)
+	processed := make(chan *Task, 1)
+	worker.Executors().Register("email", ExecutorFunc(func(_ context.Context, task *Task) (*TaskResult, error) {
+		processed <- task
+		return &TaskResult{}, nil
+	}))
+
+	ctx := context.Background()
+	task := NewTask("email", nil)
+	require.NoError(t, worker.SubmitTask(ctx, task, WithQueue("bulk")))
+	require.NoError(t, worker.Move(ctx, task.ID, "bulk", "critical"))
+
+	worker.Start()
+	var got *Task
+	select {
+	case got = <-processed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("moved task not processed")
+	}
+	require.NoError(t, worker.Shutdown(ctx))
+	assert.Equal(t, "critical", got.Metadata[QueueMetadataKey])
+	assert.Equal(t, "bulk", got.Metadata[MovedFromMetadataKey])
+	assert.EqualValues(t, 1, worker.Queues()["critical"].Stats.Completed)
+}
+
+func TestMoveValidatesQueuesAndPlacement(t *testing.T) {
+	worker := NewWorker(WorkerConfig{
+		WorkerCount: 1,
+		Queues:      map[string]QueueConfig{"bulk": {}},
+	}, newMockRepository(), nil)
+
+	ctx := context.Background()
+	task := NewTask("email", nil)
+	require.NoError(t, worker.SubmitTask(ctx, task))
+
+	assert.ErrorIs(t, worker.Move(ctx, task.ID, "nope", "bulk"), ErrUnknownQueue)
+	assert.ErrorIs(t, worker.Move(ctx, task.ID, "bulk", "nope"), ErrUnknownQueue)
+	// The task sits on the default queue, not on "bulk".
+	assert.ErrorIs(t, worker.Move(ctx, task.ID, "bulk", DefaultQueueName), ErrTaskNotFound)
+	assert.ErrorIs(t, worker.Move(ctx, "missing", DefaultQueueName, "bulk"), ErrTaskNotFound)
+	assert.NoError(t, worker.Move(ctx, task.ID, DefaultQueueName, DefaultQueueName))
+	require.NoError(t, worker.Shutdown(ctx))
+}
+
+func TestMoveEmitsAuditEvent(t *testing.T) {
+	worker := NewWorker(WorkerConfig{
+		WorkerCount: 1,
+		Queues:      map[string]QueueConfig{"critical": {}},
+	}, newMockRepository(), nil)
+
+	events, cancel := worker.SubscribeEvents(4)
+	defer cancel()
+
+	ctx := context.Background()
+	task := NewTask("email", nil)
+	require.NoError(t, worker.SubmitTask(ctx, task))
+	require.NoError(t, worker.Move(ctx, task.ID, DefaultQueueName, "critical"))
+
+	got := collectEvents(t, events, 2)
+	assert.Equal(t, EventMoved, got[1].Kind)
+	assert.Equal(t, task.ID, got[1].TaskID)
+	require.NoError(t, worker.Shutdown(ctx))
+}
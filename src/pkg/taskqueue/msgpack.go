@@ -0,0 +1,621 @@
+package taskqueue
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"time"
+)
+
+// MsgpackCodec encodes tasks and results as MessagePack maps keyed by
+// the same field names as the JSON encoding, so existing non-Go
+// consumers switch formats without remapping fields. The encoder is
+// self-contained — it implements the MessagePack primitives the task
+// shapes need — which keeps the msgpack dependency out of the module
+// the same way GobCodec avoids a schema compiler. Timestamps ride as
+// RFC 3339 strings and durations as integer nanoseconds.
+type MsgpackCodec struct{}
+
+// errMalformedMsgpack is wrapped by every decode failure.
+var errMalformedMsgpack = errors.New("malformed msgpack")
+
+// Name implements Codec.
+func (MsgpackCodec) Name() string { return "msgpack" }
+
+// mpField is one key/value pair of an encoded map, kept in a slice so
+// field order is deterministic.
+type mpField struct {
+	key   string
+	value any
+}
+
+// EncodeTask implements Codec, omitting empty optional fields like the
+// JSON tags do.
+func (MsgpackCodec) EncodeTask(task *Task) ([]byte, error) {
+	if task == nil {
+		return nil, ErrNilTask
+	}
+	fields := []mpField{{"id", task.ID}, {"type", task.Type}}
+	if task.Data != nil {
+		fields = append(fields, mpField{"data", task.Data})
+	}
+	fields = append(fields, mpField{"status", string(task.Status)})
+	if task.Priority != 0 {
+		fields = append(fields, mpField{"priority", int64(task.Priority)})
+	}
+	fields = append(fields,
+		mpField{"retries", int64(task.Retries)},
+		mpField{"max_retries", int64(task.MaxRetries)})
+	if task.Metadata != nil {
+		fields = append(fields, mpField{"metadata", task.Metadata})
+	}
+	fields = append(fields,
+		mpField{"created_at", protoTime(task.CreatedAt)},
+		mpField{"updated_at", protoTime(task.UpdatedAt)})
+	if !task.ExpiresAt.IsZero() {
+		fields = append(fields, mpField{"expires_at", protoTime(task.ExpiresAt)})
+	}
+	if task.Timeout != 0 {
+		fields = append(fields, mpField{"timeout", int64(task.Timeout)})
+	}
+	if !task.Deadline.IsZero() {
+		fields = append(fields, mpField{"deadline", protoTime(task.Deadline)})
+	}
+	return mpAppendFields(nil, fields)
+}
+
+// DecodeTask implements Codec; every error wraps ErrInvalidTask.
+func (MsgpackCodec) DecodeTask(data []byte) (*Task, error) {
+	var task Task
+	err := mpDecodeMap(data, func(key string, d *mpDecoder) error {
+		var err error
+		switch key {
+		case "id":
+			task.ID, err = d.readString()
+		case "type":
+			task.Type, err = d.readString()
+		case "data":
+			task.Data, err = d.readStringMap()
+		case "status":
+			var status string
+			if status, err = d.readString(); err == nil {
+				task.Status = TaskStatus(status)
+			}
+		case "priority":
+			task.Priority, err = d.readInt()
+		case "retries":
+			task.Retries, err = d.readInt()
+		case "max_retries":
+			task.MaxRetries, err = d.readInt()
+		case "metadata":
+			task.Metadata, err = d.readStringStringMap()
+		case "created_at":
+			task.CreatedAt, err = d.readTime()
+		case "updated_at":
+			task.UpdatedAt, err = d.readTime()
+		case "expires_at":
+			task.ExpiresAt, err = d.readTime()
+		case "timeout":
+			var ns int
+			if ns, err = d.readInt(); err == nil {
+				task.Timeout = time.Duration(ns)
+			}
+		case "deadline":
+			task.Deadline, err = d.readTime()
+		default:
+			err = d.skip()
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTask, err)
+	}
+	return &task, nil
+}
+
+// EncodeResult implements Codec.
+func (MsgpackCodec) EncodeResult(result *TaskResult) ([]byte, error) {
+	fields := []mpField{{"task_id", result.TaskID}, {"task_type", result.TaskType}}
+	if result.Namespace != "" {
+		fields = append(fields, mpField{"namespace", result.Namespace})
+	}
+	if result.CorrelationID != "" {
+		fields = append(fields, mpField{"correlation_id", result.CorrelationID})
+	}
+	fields = append(fields, mpField{"success", result.Success})
+	if result.Output != "" {
+		fields = append(fields, mpField{"output", result.Output})
+	}
+	if result.Error != "" {
+		fields = append(fields, mpField{"error", result.Error})
+	}
+	fields = append(fields,
+		mpField{"duration", int64(result.Duration)},
+		mpField{"completed_at", protoTime(result.CompletedAt)})
+	return mpAppendFields(nil, fields)
+}
+
+// DecodeResult implements Codec.
+func (MsgpackCodec) DecodeResult(data []byte) (*TaskResult, error) {
+	var result TaskResult
+	err := mpDecodeMap(data, func(key string, d *mpDecoder) error {
+		var err error
+		switch key {
+		case "task_id":
+			result.TaskID, err = d.readString()
+		case "task_type":
+			result.TaskType, err = d.readString()
+		case "namespace":
+			result.Namespace, err = d.readString()
+		case "correlation_id":
+			result.CorrelationID, err = d.readString()
+		case "success":
+			result.Success, err = d.readBool()
+		case "output":
+			result.Output, err = d.readString()
+		case "error":
+			result.Error, err = d.readString()
+		case "duration":
+			var ns int
+			if ns, err = d.readInt(); err == nil {
+				result.Duration = time.Duration(ns)
+			}
+		case "completed_at":
+			result.CompletedAt, err = d.readTime()
+		default:
+			err = d.skip()
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("taskqueue: decode result: %w", err)
+	}
+	return &result, nil
+}
+
+// mpAppendFields encodes one map from its ordered fields.
+func mpAppendFields(b []byte, fields []mpField) ([]byte, error) {
+	b = mpAppendMapHeader(b, len(fields))
+	for _, field := range fields {
+		b = mpAppendString(b, field.key)
+		var err error
+		if b, err = mpAppendValue(b, field.value); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// mpAppendValue encodes one value of the types task payloads carry.
+func mpAppendValue(b []byte, value any) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return append(b, 0xc0), nil
+	case bool:
+		if v {
+			return append(b, 0xc3), nil
+		}
+		return append(b, 0xc2), nil
+	case string:
+		return mpAppendString(b, v), nil
+	case int:
+		return mpAppendInt(b, int64(v)), nil
+	case int64:
+		return mpAppendInt(b, v), nil
+	case uint64:
+		return mpAppendUint(b, v), nil
+	case float64:
+		b = append(b, 0xcb)
+		return binary.BigEndian.AppendUint64(b, math.Float64bits(v)), nil
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return mpAppendInt(b, n), nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("taskqueue: msgpack encode number %q: %w", v, err)
+		}
+		return mpAppendValue(b, f)
+	case []byte:
+		b = mpAppendBinHeader(b, len(v))
+		return append(b, v...), nil
+	case time.Time:
+		return mpAppendString(b, protoTime(v)), nil
+	case []any:
+		b = mpAppendArrayHeader(b, len(v))
+		for _, item := range v {
+			var err error
+			if b, err = mpAppendValue(b, item); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case map[string]any:
+		b = mpAppendMapHeader(b, len(v))
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			b = mpAppendString(b, key)
+			var err error
+			if b, err = mpAppendValue(b, v[key]); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case map[string]string:
+		b = mpAppendMapHeader(b, len(v))
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			b = mpAppendString(b, key)
+			b = mpAppendString(b, v[key])
+		}
+		return b, nil
+	default:
+		return nil, fmt.Errorf("taskqueue: msgpack encode: unsupported type %T", value)
+	}
+}
+
+// mpAppendInt encodes a signed integer in the smallest format.
+func mpAppendInt(b []byte, v int64) []byte {
+	switch {
+	case v >= 0:
+		return mpAppendUint(b, uint64(v))
+	case v >= -32:
+		return append(b, byte(v))
+	case v >= math.MinInt8:
+		return append(b, 0xd0, byte(v))
+	case v >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(b, 0xd1), uint16(v))
+	case v >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(b, 0xd2), uint32(v))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, 0xd3), uint64(v))
+	}
+}
+
+// mpAppendUint encodes an unsigned integer in the smallest format.
+func mpAppendUint(b []byte, v uint64) []byte {
+	switch {
+	case v <= 0x7f:
+		return append(b, byte(v))
+	case v <= math.MaxUint8:
+		return append(b, 0xcc, byte(v))
+	case v <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, 0xcd), uint16(v))
+	case v <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, 0xce), uint32(v))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, 0xcf), v)
+	}
+}
+
+// mpAppendString encodes a string header plus bytes.
+func mpAppendString(b []byte, s string) []byte {
+	switch n := len(s); {
+	case n <= 31:
+		b = append(b, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		b = append(b, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		b = binary.BigEndian.AppendUint16(append(b, 0xda), uint16(n))
+	default:
+		b = binary.BigEndian.AppendUint32(append(b, 0xdb), uint32(n))
+	}
+	return append(b, s...)
+}
+
+// mpAppendBinHeader encodes a binary blob header.
+func mpAppendBinHeader(b []byte, n int) []byte {
+	switch {
+	case n <= math.MaxUint8:
+		return append(b, 0xc4, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, 0xc5), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(b, 0xc6), uint32(n))
+	}
+}
+
+// mpAppendMapHeader encodes a map header.
+func mpAppendMapHeader(b []byte, n int) []byte {
+	switch {
+	case n <= 15:
+		return append(b, 0x80|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, 0xde), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(b, 0xdf), uint32(n))
+	}
+}
+
+// mpAppendArrayHeader encodes an array header.
+func mpAppendArrayHeader(b []byte, n int) []byte {
+	switch {
+	case n <= 15:
+		return append(b, 0x90|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, 0xdc), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(b, 0xdd), uint32(n))
+	}
+}
+
+// mpDecoder reads MessagePack values off a byte slice.
+type mpDecoder struct {
+	data []byte
+}
+
+// mpDecodeMap walks the top-level map, calling fn once per key with the
+// decoder positioned on the value.
+func mpDecodeMap(data []byte, fn func(key string, d *mpDecoder) error) error {
+	d := &mpDecoder{data: data}
+	size, err := d.readMapHeader()
+	if err != nil {
+		return err
+	}
+	for range size {
+		key, err := d.readString()
+		if err != nil {
+			return err
+		}
+		if err := fn(key, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// take consumes n bytes.
+func (d *mpDecoder) take(n int) ([]byte, error) {
+	if len(d.data) < n {
+		return nil, errMalformedMsgpack
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b, nil
+}
+
+// readValue decodes one value generically, mirroring the types
+// mpAppendValue accepts.
+func (d *mpDecoder) readValue() (any, error) {
+	header, err := d.take(1)
+	if err != nil {
+		return nil, err
+	}
+	c := header[0]
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c >= 0xa0 && c <= 0xbf:
+		b, err := d.take(int(c & 0x1f))
+		return string(b), err
+	case c >= 0x80 && c <= 0x8f:
+		return d.readMapBody(int(c & 0x0f))
+	case c >= 0x90 && c <= 0x9f:
+		return d.readArrayBody(int(c & 0x0f))
+	}
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		v, err := d.readUintBody(1 << (c - 0xcc))
+		return int64(v), err
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		return d.readIntBody(1 << (c - 0xd0))
+	case 0xca:
+		b, err := d.take(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), nil
+	case 0xcb:
+		b, err := d.take(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+	case 0xd9, 0xda, 0xdb:
+		size, err := d.readSize(1 << (c - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.take(size)
+		return string(b), err
+	case 0xc4, 0xc5, 0xc6:
+		size, err := d.readSize(1 << (c - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.take(size)
+		return append([]byte(nil), b...), err
+	case 0xdc, 0xdd:
+		size, err := d.readSize(2 << (c - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return d.readArrayBody(size)
+	case 0xde, 0xdf:
+		size, err := d.readSize(2 << (c - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return d.readMapBody(size)
+	default:
+		return nil, errMalformedMsgpack
+	}
+}
+
+// readSize reads an n-byte big-endian length.
+func (d *mpDecoder) readSize(n int) (int, error) {
+	b, err := d.take(n)
+	if err != nil {
+		return 0, err
+	}
+	var size uint64
+	for _, c := range b {
+		size = size<<8 | uint64(c)
+	}
+	if size > uint64(len(d.data)) {
+		return 0, errMalformedMsgpack
+	}
+	return int(size), nil
+}
+
+// readUintBody reads an n-byte unsigned integer.
+func (d *mpDecoder) readUintBody(n int) (uint64, error) {
+	b, err := d.take(n)
+	if err != nil {
+		return 0, err
+	}
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v, nil
+}
+
+// readIntBody reads an n-byte signed integer.
+func (d *mpDecoder) readIntBody(n int) (int64, error) {
+	v, err := d.readUintBody(n)
+	if err != nil {
+		return 0, err
+	}
+	shift := 64 - 8*n
+	return int64(v<<shift) >> shift, nil
+}
+
+// readMapBody reads size key/value pairs into a generic map.
+func (d *mpDecoder) readMapBody(size int) (map[string]any, error) {
+	m := make(map[string]any, size)
+	for range size {
+		key, err := d.readString()
+		if err != nil {
+			return nil, err
+		}
+		if m[key], err = d.readValue(); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// readArrayBody reads size values into a generic slice.
+func (d *mpDecoder) readArrayBody(size int) ([]any, error) {
+	items := make([]any, size)
+	for n := range size {
+		var err error
+		if items[n], err = d.readValue(); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// readMapHeader reads a map header.
+func (d *mpDecoder) readMapHeader() (int, error) {
+	header, err := d.take(1)
+	if err != nil {
+		return 0, err
+	}
+	switch c := header[0]; {
+	case c >= 0x80 && c <= 0x8f:
+		return int(c & 0x0f), nil
+	case c == 0xde:
+		return d.readSize(2)
+	case c == 0xdf:
+		return d.readSize(4)
+	default:
+		return 0, errMalformedMsgpack
+	}
+}
+
+// readString reads a string value.
+func (d *mpDecoder) readString() (string, error) {
+	value, err := d.readValue()
+	if err != nil {
+		return "", err
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("%w: expected string, got %T", errMalformedMsgpack, value)
+	}
+	return s, nil
+}
+
+// readBool reads a boolean value.
+func (d *mpDecoder) readBool() (bool, error) {
+	value, err := d.readValue()
+	if err != nil {
+		return false, err
+	}
+	b, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("%w: expected bool, got %T", errMalformedMsgpack, value)
+	}
+	return b, nil
+}
+
+// readInt reads an integer value.
+func (d *mpDecoder) readInt() (int, error) {
+	value, err := d.readValue()
+	if err != nil {
+		return 0, err
+	}
+	v, ok := value.(int64)
+	if !ok {
+		return 0, fmt.Errorf("%w: expected integer, got %T", errMalformedMsgpack, value)
+	}
+	return int(v), nil
+}
+
+// readTime reads an RFC 3339 string value as a timestamp.
+func (d *mpDecoder) readTime() (time.Time, error) {
+	s, err := d.readString()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+// readStringMap reads a map value with generic values.
+func (d *mpDecoder) readStringMap() (map[string]any, error) {
+	size, err := d.readMapHeader()
+	if err != nil {
+		return nil, err
+	}
+	return d.readMapBody(size)
+}
+
+// readStringStringMap reads a map value whose values are strings.
+func (d *mpDecoder) readStringStringMap() (map[string]string, error) {
+	generic, err := d.readStringMap()
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]string, len(generic))
+	for key, value := range generic {
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: expected string, got %T", errMalformedMsgpack, value)
+		}
+		m[key] = s
+	}
+	return m, nil
+}
+
+// skip discards one value, so unknown keys from newer encoders do not
+// break older decoders.
+func (d *mpDecoder) skip() error {
+	_, err := d.readValue()
+	return err
+}
@@ -0,0 +1,74 @@
+package taskqueue
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MultiError aggregates the failures of a batch operation while
+// remembering which element each came from. It plays by the errors
+// package's rules: Unwrap exposes the individual errors, so errors.Is
+// and errors.As see through it exactly as they do through errors.Join.
+type MultiError struct {
+	indexes []int
+	errs    []error
+}
+
+// Add records a failure at the given element index.
+func (e *MultiError) Add(index int, err error) {
+	if err == nil {
+		return
+	}
+	e.indexes = append(e.indexes, index)
+	e.errs = append(e.errs, err)
+}
+
+// Len returns how many failures were recorded.
+func (e *MultiError) Len() int {
+	return len(e.errs)
+}
+
+// ByIndex returns the failure recorded for the element index, or nil
+// when that element succeeded.
+func (e *MultiError) ByIndex(index int) error {
+	for i, recorded := range e.indexes {
+		if recorded == index {
+			return e.errs[i]
+		}
+	}
+	return nil
+}
+
+// Indexes returns the element indexes that failed, in recording order.
+func (e *MultiError) Indexes() []int {
+	return append([]int(nil), e.indexes...)
+}
+
+// Error implements error.
+func (e *MultiError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "taskqueue: %d operation(s) failed", len(e.errs))
+	for i, err := range e.errs {
+		if i == 0 {
+			b.WriteString(": ")
+		} else {
+			b.WriteString("; ")
+		}
+		fmt.Fprintf(&b, "[%d] %v", e.indexes[i], err)
+	}
+	return b.String()
+}
+
+// Unwrap exposes the individual errors to errors.Is and errors.As.
+func (e *MultiError) Unwrap() []error {
+	return e.errs
+}
+
+// ErrOrNil returns the aggregate as an error, or a plain nil when
+// nothing failed, so callers never compare against a typed nil.
+func (e *MultiError) ErrOrNil() error {
+	if e == nil || len(e.errs) == 0 {
+		return nil
+	}
+	return e
+}
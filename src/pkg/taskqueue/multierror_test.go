@@ -0,0 +1,69 @@
+package taskqueue
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiErrorAggregation(t *testing.T) {
+	merr := &MultiError{}
+	assert.NoError(t, merr.ErrOrNil(), "empty aggregate is nil")
+
+	merr.Add(1, ErrInvalidTask)
+	merr.Add(3, nil)
+	merr.Add(4, ErrTaskNotFound)
+
+	assert.Equal(t, 2, merr.Len())
+	assert.Equal(t, []int{1, 4}, merr.Indexes())
+	assert.ErrorIs(t, merr.ByIndex(1), ErrInvalidTask)
+	assert.NoError(t, merr.ByIndex(3))
+
+	err := merr.ErrOrNil()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrInvalidTask, "errors.Is sees through the aggregate")
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+	assert.Contains(t, err.Error(), "2 operation(s) failed")
+	assert.Contains(t, err.Error(), "[4]")
+}
+
+func TestProcessBatchAggregatesFailures(t *testing.T) {
+	tasks := []*Task{NewTask("a", nil), NewTask("b", nil), NewTask("c", nil)}
+	boom := errors.New("boom")
+
+	err := ProcessBatch(context.Background(), tasks, func(_ context.Context, task *Task) error {
+		if task.Type == "b" {
+			return boom
+		}
+		return nil
+	})
+
+	var merr *MultiError
+	require.ErrorAs(t, err, &merr)
+	assert.Equal(t, []int{1}, merr.Indexes())
+	assert.ErrorIs(t, err, boom)
+
+	assert.NoError(t, ProcessBatch(context.Background(), tasks, func(context.Context, *Task) error {
+		return nil
+	}))
+}
+
+func TestBulkRequeueAggregatesFailures(t *testing.T) {
+	repo := newMockRepository()
+	ctx := context.Background()
+	require.NoError(t, repo.Save(ctx, &Task{ID: "f1", Type: "", Status: TaskStatusFailed}))
+	require.NoError(t, repo.Save(ctx, &Task{ID: "f2", Type: "email", Status: TaskStatusFailed}))
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, repo, nil)
+	defer w.Shutdown(ctx)
+
+	result, err := w.BulkRequeue(ctx, TaskFilter{Status: TaskStatusFailed}, BulkOptions{})
+
+	var merr *MultiError
+	require.ErrorAs(t, err, &merr)
+	assert.Equal(t, 1, merr.Len(), "the typeless task fails validation")
+	assert.ErrorIs(t, err, ErrInvalidTask)
+	assert.Equal(t, 1, result.Changed, "the valid task is still requeued")
+}
@@ -0,0 +1,74 @@
+package taskqueue
+
+import "context"
+
+// traceContextKey is the context key for distributed trace IDs.
+type traceContextKey struct{}
+
+// WithTraceID returns a context carrying a distributed trace ID.
+// SubmitTask copies it into the task metadata, and the worker restores
+// it into the processing context, so spans on both sides of the queue
+// hand-off land in the same trace.
+func WithTraceID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, id)
+}
+
+// TraceIDFromContext returns the trace ID carried by the context, if
+// any.
+func TraceIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(traceContextKey{}).(string)
+	return id, ok && id != ""
+}
+
+// TracerProvider is the minimal tracer surface the worker instruments
+// its pipeline with. It mirrors the shape of an OpenTelemetry tracer —
+// Start derives a context and returns a span to end — so bridging to an
+// OTel SDK is a few lines in the caller without this module importing
+// it. Implementations must be safe for concurrent use.
+type TracerProvider interface {
+	Start(ctx context.Context, name string) (context.Context, ActiveSpan)
+}
+
+// ActiveSpan is one in-flight span started by a TracerProvider.
+type ActiveSpan interface {
+	// SetAttribute annotates the span.
+	SetAttribute(key, value string)
+
+	// End closes the span.
+	End()
+}
+
+// propagateTraceID copies the submission context's trace ID into the
+// task metadata, unless the task already carries one.
+func propagateTraceID(ctx context.Context, task *Task) {
+	if task.Metadata[TraceIDMetadataKey] != "" {
+		return
+	}
+	id, ok := TraceIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	if task.Metadata == nil {
+		task.Metadata = make(map[string]string, 1)
+	}
+	task.Metadata[TraceIDMetadataKey] = id
+}
+
+// startSpan starts a provider span around one pipeline phase of the
+// task, restoring the task's trace ID into the context first. It returns
+// the derived context and the span's End; both are no-ops when no
+// provider is configured.
+func (w *Worker) startSpan(ctx context.Context, name string, task *Task) (context.Context, func()) {
+	if w.config.TracerProvider == nil {
+		return ctx, func() {}
+	}
+	if id := task.Metadata[TraceIDMetadataKey]; id != "" {
+		if _, ok := TraceIDFromContext(ctx); !ok {
+			ctx = WithTraceID(ctx, id)
+		}
+	}
+	ctx, span := w.config.TracerProvider.Start(ctx, name)
+	span.SetAttribute("task.id", task.ID)
+	span.SetAttribute("task.type", task.Type)
+	return ctx, span.End
+}
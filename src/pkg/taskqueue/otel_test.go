@@ -0,0 +1,114 @@
+package taskqueue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProvider records started spans for assertions.
+type fakeProvider struct {
+	mu    sync.Mutex
+	spans []*fakeSpan
+}
+
+type fakeSpan struct {
+	provider *fakeProvider
+	name     string
+	traceID  string
+	attrs    map[string]string
+	ended    bool
+}
+
+func (p *fakeProvider) Start(ctx context.Context, name string) (context.Context, ActiveSpan) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	span := &fakeSpan{provider: p, name: name, attrs: make(map[string]string)}
+	span.traceID, _ = TraceIDFromContext(ctx)
+	p.spans = append(p.spans, span)
+	return ctx, span
+}
+
+func (s *fakeSpan) SetAttribute(key, value string) {
+	s.provider.mu.Lock()
+	defer s.provider.mu.Unlock()
+	s.attrs[key] = value
+}
+
+func (s *fakeSpan) End() {
+	s.provider.mu.Lock()
+	defer s.provider.mu.Unlock()
+	s.ended = true
+}
+
+// span returns a copy of the first ended span with the given name.
+func (p *fakeProvider) span(name string) (fakeSpan, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, span := range p.spans {
+		if span.name == name && span.ended {
+			return *span, true
+		}
+	}
+	return fakeSpan{}, false
+}
+
+func TestTracerProviderCoversThePipeline(t *testing.T) {
+	provider := &fakeProvider{}
+	w := NewWorker(WorkerConfig{WorkerCount: 1, TracerProvider: provider}, newMockRepository(), newMockPublisher())
+	executorTrace := make(chan string, 1)
+	w.Executors().Register("email", ExecutorFunc(func(ctx context.Context, _ *Task) (*TaskResult, error) {
+		id, _ := TraceIDFromContext(ctx)
+		executorTrace <- id
+		return &TaskResult{Output: "sent"}, nil
+	}))
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	ctx := WithTraceID(context.Background(), "trace-1")
+	task := NewTask("email", nil)
+	require.NoError(t, w.SubmitTask(ctx, task))
+
+	select {
+	case id := <-executorTrace:
+		assert.Equal(t, "trace-1", id, "trace ID should reach the executor context")
+	case <-time.After(2 * time.Second):
+		t.Fatal("task never executed")
+	}
+
+	for _, name := range []string{"taskqueue.SubmitTask", "taskqueue.processTask", "taskqueue.executeTask", "taskqueue.publishResult"} {
+		require.Eventually(t, func() bool {
+			_, ok := provider.span(name)
+			return ok
+		}, 2*time.Second, 5*time.Millisecond, name)
+	}
+
+	submit, _ := provider.span("taskqueue.SubmitTask")
+	assert.Equal(t, "trace-1", submit.traceID)
+	assert.Equal(t, task.ID, submit.attrs["task.id"])
+	assert.Equal(t, "email", submit.attrs["task.type"])
+
+	process, _ := provider.span("taskqueue.processTask")
+	assert.Equal(t, "trace-1", process.traceID, "trace ID should cross the queue hand-off")
+}
+
+func TestWithTraceIDRoundTrip(t *testing.T) {
+	_, ok := TraceIDFromContext(context.Background())
+	assert.False(t, ok)
+
+	ctx := WithTraceID(context.Background(), "trace-9")
+	id, ok := TraceIDFromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, "trace-9", id)
+
+	task := NewTask("email", nil)
+	propagateTraceID(ctx, task)
+	assert.Equal(t, "trace-9", task.Metadata[TraceIDMetadataKey])
+
+	propagateTraceID(WithTraceID(context.Background(), "other"), task)
+	assert.Equal(t, "trace-9", task.Metadata[TraceIDMetadataKey], "existing trace ID wins")
+}
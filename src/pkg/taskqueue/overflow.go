@@ -0,0 +1,239 @@
+package taskqueue
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultOverflowSegmentSize is the number of tasks per overflow segment
+// file when WorkerConfig.OverflowSegmentSize is zero.
+const DefaultOverflowSegmentSize = 1024
+
+// overflowQueue is a disk-backed FIFO used to absorb submission bursts
+// when the in-memory buffer is full. Tasks are appended to JSON-lines
+// segment files and drained back into memory when capacity frees.
+type overflowQueue struct {
+	dir         string
+	segmentSize int
+
+	mu       sync.Mutex
+	writeSeq uint64 // sequence of the segment being written
+	writeLen int    // tasks written to the active segment
+	writeF   *os.File
+	pending  []*Task        // tasks loaded from the oldest segment, not yet drained
+	segments []uint64       // completed segments awaiting drain, oldest first
+	counts   map[uint64]int // records per segment, keyed by sequence
+	length   int            // total tasks on disk and in pending
+}
+
+// newOverflowQueue opens (or creates) an overflow directory and recovers
+// any segments left by a previous run.
+func newOverflowQueue(dir string, segmentSize int) (*overflowQueue, error) {
+	if segmentSize <= 0 {
+		segmentSize = DefaultOverflowSegmentSize
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("taskqueue: create overflow dir: %w", err)
+	}
+	q := &overflowQueue{dir: dir, segmentSize: segmentSize, counts: make(map[uint64]int)}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("taskqueue: read overflow dir: %w", err)
+	}
+	for _, entry := range entries {
+		var seq uint64
+		if _, err := fmt.Sscanf(entry.Name(), "seg-%016d.jsonl", &seq); err != nil {
+			continue
+		}
+		q.segments = append(q.segments, seq)
+		n, err := countLines(q.segmentPath(seq))
+		if err != nil {
+			return nil, err
+		}
+		q.counts[seq] = n
+		q.length += n
+		if seq >= q.writeSeq {
+			q.writeSeq = seq + 1
+		}
+	}
+	sort.Slice(q.segments, func(i, j int) bool { return q.segments[i] < q.segments[j] })
+	return q, nil
+}
+
+func (q *overflowQueue) segmentPath(seq uint64) string {
+	return filepath.Join(q.dir, fmt.Sprintf("seg-%016d.jsonl", seq))
+}
+
+// Push appends a task to the active segment, rotating it when full.
+func (q *overflowQueue) Push(task *Task) error {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("taskqueue: encode overflow task %s: %w", task.ID, err)
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.writeF == nil {
+		f, err := os.OpenFile(q.segmentPath(q.writeSeq), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+		if err != nil {
+			return fmt.Errorf("taskqueue: open overflow segment: %w", err)
+		}
+		q.writeF = f
+		q.writeLen = 0
+		q.segments = append(q.segments, q.writeSeq)
+	}
+	if _, err := q.writeF.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("taskqueue: write overflow segment: %w", err)
+	}
+	q.writeLen++
+	q.counts[q.writeSeq]++
+	q.length++
+	if q.writeLen >= q.segmentSize {
+		q.writeF.Close()
+		q.writeF = nil
+		q.writeSeq++
+	}
+	return nil
+}
+
+// Pop returns the oldest spilled task, loading the next segment from disk
+// when the in-memory batch is exhausted.
+func (q *overflowQueue) Pop() (*Task, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for len(q.pending) == 0 {
+		if len(q.segments) == 0 {
+			return nil, false
+		}
+		seq := q.segments[0]
+		if q.writeF != nil && seq == q.writeSeq {
+			// The oldest segment is still being written: seal it first.
+			q.writeF.Close()
+			q.writeF = nil
+			q.writeSeq++
+		}
+		tasks, err := readSegment(q.segmentPath(seq))
+		if err != nil {
+			// A corrupt segment is skipped rather than wedging the drain.
+			tasks = nil
+		}
+		os.Remove(q.segmentPath(seq))
+		q.segments = q.segments[1:]
+		// Records lost to corruption never reach pending, so drop them
+		// from the length now or Len would drift upward for good.
+		if expected := q.counts[seq]; expected > len(tasks) {
+			q.length -= expected - len(tasks)
+		}
+		delete(q.counts, seq)
+		q.pending = tasks
+	}
+	task := q.pending[0]
+	q.pending = q.pending[1:]
+	q.length--
+	return task, true
+}
+
+// Len returns the number of spilled tasks not yet drained.
+func (q *overflowQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.length
+}
+
+// Close seals the active segment.
+func (q *overflowQueue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.writeF != nil {
+		err := q.writeF.Close()
+		q.writeF = nil
+		return err
+	}
+	return nil
+}
+
+func readSegment(path string) ([]*Task, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var tasks []*Task
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var task Task
+		if err := json.Unmarshal(scanner.Bytes(), &task); err != nil {
+			continue
+		}
+		tasks = append(tasks, &task)
+	}
+	return tasks, scanner.Err()
+}
+
+func countLines(path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	n := 0
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		n++
+	}
+	return n, scanner.Err()
+}
+
+// drainOverflow moves spilled tasks back into the queue as capacity
+// frees, until the worker stops. A task popped from disk but not yet
+// accepted by the queue is carried across ticks and re-spilled on stop.
+func (w *Worker) drainOverflow() {
+	defer w.drainWg.Done()
+	defer func() {
+		if w.carry != nil {
+			if err := w.overflow.Push(w.carry); err != nil {
+				w.logger.Error("taskqueue: re-spill carried task", "task_id", w.carry.ID, "error", err)
+			}
+			w.carry = nil
+		}
+	}()
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stopCh:
+			return
+		case <-ticker.C:
+			for {
+				select {
+				case <-w.stopCh:
+					return
+				default:
+				}
+				if w.carry == nil {
+					task, ok := w.overflow.Pop()
+					if !ok {
+						break
+					}
+					w.carry = task
+				}
+				if err := w.queue.Enqueue(w.carry); err != nil {
+					// Still full (or closing): keep the task and retry
+					// on the next tick.
+					break
+				}
+				w.statuses.Increment(w.carry.Status)
+				w.carry = nil
+			}
+		}
+	}
+}
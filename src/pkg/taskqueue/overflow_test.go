@@ -0,0 +1,105 @@
+package taskqueue
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverflowQueuePushPop(t *testing.T) {
+	q, err := newOverflowQueue(t.TempDir(), 2)
+	require.NoError(t, err)
+
+	for _, id := range []string{"a", "b", "c"} {
+		require.NoError(t, q.Push(&Task{ID: id, Type: "email"}))
+	}
+	assert.Equal(t, 3, q.Len())
+
+	var ids []string
+	for {
+		task, ok := q.Pop()
+		if !ok {
+			break
+		}
+		ids = append(ids, task.ID)
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, ids, "FIFO order across segments")
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestOverflowQueueRecoversSegments(t *testing.T) {
+	dir := t.TempDir()
+	q, err := newOverflowQueue(dir, 2)
+	require.NoError(t, err)
+	require.NoError(t, q.Push(&Task{ID: "a", Type: "email"}))
+	require.NoError(t, q.Push(&Task{ID: "b", Type: "email"}))
+	require.NoError(t, q.Close())
+
+	reopened, err := newOverflowQueue(dir, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, reopened.Len())
+
+	task, ok := reopened.Pop()
+	require.True(t, ok)
+	assert.Equal(t, "a", task.ID)
+}
+
+func TestOverflowQueueDiscardedSegmentDoesNotInflateLen(t *testing.T) {
+	dir := t.TempDir()
+	q, err := newOverflowQueue(dir, 2)
+	require.NoError(t, err)
+	require.NoError(t, q.Push(&Task{ID: "a", Type: "email"}))
+	require.NoError(t, q.Push(&Task{ID: "b", Type: "email"}))
+	require.NoError(t, q.Push(&Task{ID: "c", Type: "email"}))
+
+	// Corrupt the first, sealed segment so its records are discarded.
+	require.NoError(t, os.WriteFile(q.segmentPath(0), []byte("not json\nstill not json\n"), 0o640))
+
+	task, ok := q.Pop()
+	require.True(t, ok)
+	assert.Equal(t, "c", task.ID, "the surviving segment still drains")
+	_, ok = q.Pop()
+	assert.False(t, ok)
+	assert.Equal(t, 0, q.Len(), "discarded records must leave the length")
+}
+
+func TestWorkerSpillsAndDrains(t *testing.T) {
+	repo := newMockRepository()
+	w := NewWorker(WorkerConfig{
+		WorkerCount: 1,
+		BufferSize:  1,
+		OverflowDir: t.TempDir(),
+	}, repo, newMockPublisher())
+
+	processed := make(chan string, 8)
+	w.Executors().Register("email", ExecutorFunc(func(_ context.Context, task *Task) (*TaskResult, error) {
+		processed <- task.ID
+		return nil, nil
+	}))
+
+	// Fill the buffer before starting so further submissions spill.
+	require.NoError(t, w.SubmitTask(context.Background(), &Task{ID: "t1", Type: "email"}))
+	require.NoError(t, w.SubmitTask(context.Background(), &Task{ID: "t2", Type: "email"}))
+	require.NoError(t, w.SubmitTask(context.Background(), &Task{ID: "t3", Type: "email"}))
+	assert.EqualValues(t, 2, w.Stats().Spilled)
+
+	w.Start()
+	seen := map[string]bool{}
+	timeout := time.After(5 * time.Second)
+	for len(seen) < 3 {
+		select {
+		case id := <-processed:
+			seen[id] = true
+		case <-timeout:
+			t.Fatalf("only processed %v", seen)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	require.NoError(t, w.Shutdown(ctx))
+}
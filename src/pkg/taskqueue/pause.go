@@ -0,0 +1,28 @@
+package taskqueue
+
+// Pause stops the pool from picking up queued tasks. In-flight tasks run
+// to completion and submissions are still accepted; they accumulate in
+// the queue until Resume. Pausing an already paused worker is a no-op.
+func (w *Worker) Pause() {
+	if w.gate.Load() != nil {
+		return
+	}
+	gate := make(chan struct{})
+	if !w.gate.CompareAndSwap(nil, &gate) {
+		return
+	}
+	w.logger.Info("taskqueue: worker paused")
+}
+
+// Resume lets a paused pool pick up queued tasks again.
+func (w *Worker) Resume() {
+	if gate := w.gate.Swap(nil); gate != nil {
+		close(*gate)
+		w.logger.Info("taskqueue: worker resumed")
+	}
+}
+
+// Paused reports whether the pool is currently paused.
+func (w *Worker) Paused() bool {
+	return w.gate.Load() != nil
+}
@@ -0,0 +1,54 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauseHoldsQueuedTasksUntilResume(t *testing.T) {
+	worker := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), nil)
+	processed := make(chan string, 2)
+	worker.Executors().Register("email", ExecutorFunc(func(_ context.Context, task *Task) (*TaskResult, error) {
+		processed <- task.ID
+		return &TaskResult{}, nil
+	}))
+
+	worker.Pause()
+	assert.True(t, worker.Paused())
+	worker.Start()
+
+	ctx := context.Background()
+	require.NoError(t, worker.SubmitTask(ctx, NewTask("email", nil)))
+	select {
+	case id := <-processed:
+		t.Fatalf("task %s processed while paused", id)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	worker.Resume()
+	assert.False(t, worker.Paused())
+	select {
+	case <-processed:
+	case <-time.After(time.Second):
+		t.Fatal("task not processed after resume")
+	}
+	require.NoError(t, worker.Shutdown(ctx))
+}
+
+func TestShutdownReleasesPausedPool(t *testing.T) {
+	worker := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), nil)
+	worker.Executors().Register("email", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		return &TaskResult{}, nil
+	}))
+	worker.Start()
+	worker.Pause()
+
+	ctx := context.Background()
+	require.NoError(t, worker.SubmitTask(ctx, NewTask("email", nil)))
+	require.NoError(t, worker.Shutdown(ctx))
+	assert.EqualValues(t, 1, worker.Stats().Completed)
+}
@@ -0,0 +1,125 @@
+package taskqueue
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// pluginMu guards the manifest registry, so plugins may register while
+// workers are starting.
+var pluginMu sync.RWMutex
+
+// pluginManifests tracks the manifests added via RegisterPlugin, keyed
+// by plugin name.
+var pluginManifests = make(map[string]PluginManifest)
+
+// PluginManifest describes what a plugin contributes to a worker: the
+// task types it handles, the executor behind each, and optional per-type
+// policies. Plugins register their manifest at init time; every worker
+// started afterwards discovers the declared types and wires them into
+// its ExecutorRegistry, so services pick up plugin task types without
+// per-type registration calls.
+type PluginManifest struct {
+	// Name identifies the plugin and must be unique across the process.
+	Name string
+
+	// Version is informational, surfaced in the startup log.
+	Version string
+
+	// Executors maps declared task types to their executors. At least
+	// one entry is required.
+	Executors map[string]Executor
+
+	// Policies supplies per-task-type defaults for the declared types,
+	// merged into the worker's policy table at startup.
+	Policies map[string]TypePolicy
+}
+
+// RegisterPlugin adds a manifest to the process-wide plugin registry.
+// The manifest is validated up front: a name and at least one executor
+// are required, and declared types must not collide with another
+// registered plugin. Errors wrap ErrInvalidPlugin.
+func RegisterPlugin(manifest PluginManifest) error {
+	if manifest.Name == "" {
+		return fmt.Errorf("%w: manifest needs a name", ErrInvalidPlugin)
+	}
+	if len(manifest.Executors) == 0 {
+		return fmt.Errorf("%w: %q declares no executors", ErrInvalidPlugin, manifest.Name)
+	}
+	for taskType, executor := range manifest.Executors {
+		if taskType == "" || executor == nil {
+			return fmt.Errorf("%w: %q declares an empty task type or nil executor", ErrInvalidPlugin, manifest.Name)
+		}
+	}
+	for taskType := range manifest.Policies {
+		if _, ok := manifest.Executors[taskType]; !ok {
+			return fmt.Errorf("%w: %q declares a policy for undeclared type %q", ErrInvalidPlugin, manifest.Name, taskType)
+		}
+	}
+
+	pluginMu.Lock()
+	defer pluginMu.Unlock()
+	if _, exists := pluginManifests[manifest.Name]; exists {
+		return fmt.Errorf("%w: %q already registered", ErrInvalidPlugin, manifest.Name)
+	}
+	for name, registered := range pluginManifests {
+		for taskType := range manifest.Executors {
+			if _, taken := registered.Executors[taskType]; taken {
+				return fmt.Errorf("%w: type %q already declared by %q", ErrInvalidPlugin, taskType, name)
+			}
+		}
+	}
+	pluginManifests[manifest.Name] = manifest
+	return nil
+}
+
+// Plugins returns a snapshot of the registered manifests, sorted by
+// name.
+func Plugins() []PluginManifest {
+	pluginMu.RLock()
+	defer pluginMu.RUnlock()
+	manifests := make([]PluginManifest, 0, len(pluginManifests))
+	for _, manifest := range pluginManifests {
+		manifests = append(manifests, manifest)
+	}
+	sort.Slice(manifests, func(i, j int) bool { return manifests[i].Name < manifests[j].Name })
+	return manifests
+}
+
+// unregisterPlugin removes a manifest from the registry. Tests use it to
+// keep the global registry clean.
+func unregisterPlugin(name string) {
+	pluginMu.Lock()
+	defer pluginMu.Unlock()
+	delete(pluginManifests, name)
+}
+
+// wirePlugins scans the registered manifests and registers their
+// executors and policies with this worker. Types the service already
+// registered directly win over plugin declarations, so a deployment can
+// override one plugin type without unregistering the plugin. Called from
+// Start before the pool goroutines launch.
+func (w *Worker) wirePlugins() {
+	for _, manifest := range Plugins() {
+		wired := 0
+		for taskType, executor := range manifest.Executors {
+			if _, err := w.executors.Get(taskType); err == nil {
+				continue
+			}
+			w.executors.Register(taskType, executor)
+			wired++
+		}
+		if len(manifest.Policies) > 0 && w.config.Policies == nil {
+			w.config.Policies = NewTypePolicies()
+		}
+		for taskType, policy := range manifest.Policies {
+			if _, exists := w.config.Policies.Get(taskType); !exists {
+				w.config.Policies.Set(taskType, policy)
+			}
+		}
+		if wired > 0 {
+			w.logger.Info("taskqueue: plugin wired", "plugin", manifest.Name, "version", manifest.Version, "types", wired)
+		}
+	}
+}
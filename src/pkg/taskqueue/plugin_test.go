@@ -0,0 +1,109 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func registerTestPlugin(t *testing.T, manifest PluginManifest) {
+	t.Helper()
+	require.NoError(t, RegisterPlugin(manifest))
+	t.Cleanup(func() { unregisterPlugin(manifest.Name) })
+}
+
+func TestRegisterPluginValidation(t *testing.T) {
+	echo := ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		return &TaskResult{}, nil
+	})
+
+	tests := []struct {
+		name     string
+		manifest PluginManifest
+	}{
+		{name: "missing name", manifest: PluginManifest{Executors: map[string]Executor{"echo": echo}}},
+		{name: "no executors", manifest: PluginManifest{Name: "empty"}},
+		{name: "nil executor", manifest: PluginManifest{Name: "nil", Executors: map[string]Executor{"echo": nil}}},
+		{
+			name: "policy for undeclared type",
+			manifest: PluginManifest{
+				Name:      "stray",
+				Executors: map[string]Executor{"echo": echo},
+				Policies:  map[string]TypePolicy{"other": {MaxRetries: 1}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, RegisterPlugin(tt.manifest), ErrInvalidPlugin)
+		})
+	}
+}
+
+func TestRegisterPluginRejectsCollisions(t *testing.T) {
+	echo := ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		return &TaskResult{}, nil
+	})
+	registerTestPlugin(t, PluginManifest{Name: "first", Executors: map[string]Executor{"plugin-echo": echo}})
+
+	err := RegisterPlugin(PluginManifest{Name: "first", Executors: map[string]Executor{"other": echo}})
+	assert.ErrorIs(t, err, ErrInvalidPlugin)
+
+	err = RegisterPlugin(PluginManifest{Name: "second", Executors: map[string]Executor{"plugin-echo": echo}})
+	assert.ErrorIs(t, err, ErrInvalidPlugin)
+}
+
+func TestStartWiresPluginManifests(t *testing.T) {
+	registerTestPlugin(t, PluginManifest{
+		Name:    "notifier",
+		Version: "1.2.0",
+		Executors: map[string]Executor{
+			"plugin-notify": ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+				return &TaskResult{Output: "notified"}, nil
+			}),
+		},
+		Policies: map[string]TypePolicy{"plugin-notify": {MaxRetries: 2, Timeout: 5 * time.Second}},
+	})
+
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), nil)
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	require.NoError(t, w.SubmitTask(context.Background(), NewTask("plugin-notify", nil)))
+	require.Eventually(t, func() bool { return w.Stats().Completed == 1 }, 2*time.Second, 5*time.Millisecond)
+
+	policy, ok := w.config.Policies.Get("plugin-notify")
+	require.True(t, ok)
+	assert.Equal(t, 2, policy.MaxRetries)
+}
+
+func TestDirectRegistrationWinsOverPlugin(t *testing.T) {
+	registerTestPlugin(t, PluginManifest{
+		Name: "shadowed",
+		Executors: map[string]Executor{
+			"plugin-shadow": ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+				return &TaskResult{Output: "plugin"}, nil
+			}),
+		},
+	})
+
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), nil)
+	w.Executors().Register("plugin-shadow", ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		return &TaskResult{Output: "direct"}, nil
+	}))
+	results, cancel := w.SubscribeResults(1)
+	defer cancel()
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	require.NoError(t, w.SubmitTask(context.Background(), NewTask("plugin-shadow", nil)))
+	select {
+	case result := <-results:
+		assert.Equal(t, "direct", result.Output)
+	case <-time.After(2 * time.Second):
+		t.Fatal("result never delivered")
+	}
+}
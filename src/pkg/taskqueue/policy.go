@@ -0,0 +1,155 @@
+package taskqueue
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue/syncmap"
+)
+
+// TypePolicy holds per-task-type defaults applied when a submission does
+// not set the corresponding field, so policies live in one table instead
+// of being encoded in every CreateTaskRequest.
+type TypePolicy struct {
+	// Timeout overrides WorkerConfig.ProcessTimeout for this type.
+	Timeout time.Duration `json:"timeout,omitempty"`
+
+	// MaxRetries is the default retry budget for this type.
+	MaxRetries int `json:"max_retries,omitempty"`
+
+	// Backoff is the base delay between retries of this type.
+	Backoff time.Duration `json:"backoff,omitempty"`
+
+	// Concurrency caps the number of in-flight tasks of this type across
+	// the pool. Zero means unlimited.
+	Concurrency int `json:"concurrency,omitempty"`
+
+	// Priority is the default priority for this type.
+	Priority int `json:"priority,omitempty"`
+}
+
+// UnmarshalJSON accepts durations either as nanosecond numbers or as
+// strings like "30s", so policies read naturally from config files.
+func (p *TypePolicy) UnmarshalJSON(data []byte) error {
+	type alias TypePolicy
+	aux := struct {
+		*alias
+		Timeout json.RawMessage `json:"timeout,omitempty"`
+		Backoff json.RawMessage `json:"backoff,omitempty"`
+	}{alias: (*alias)(p)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	var err error
+	if p.Timeout, err = unmarshalDuration(aux.Timeout); err != nil {
+		return fmt.Errorf("taskqueue: policy timeout: %w", err)
+	}
+	if p.Backoff, err = unmarshalDuration(aux.Backoff); err != nil {
+		return fmt.Errorf("taskqueue: policy backoff: %w", err)
+	}
+	return nil
+}
+
+// unmarshalDuration parses a JSON duration that is either a number of
+// nanoseconds or a time.ParseDuration string.
+func unmarshalDuration(raw json.RawMessage) (time.Duration, error) {
+	if len(raw) == 0 {
+		return 0, nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return time.ParseDuration(s)
+	}
+	var n int64
+	if err := json.Unmarshal(raw, &n); err != nil {
+		return 0, err
+	}
+	return time.Duration(n), nil
+}
+
+// TypePolicies is a concurrent registry of policies keyed by task type.
+type TypePolicies struct {
+	mu       sync.RWMutex
+	policies map[string]TypePolicy
+}
+
+// NewTypePolicies returns an empty registry.
+func NewTypePolicies() *TypePolicies {
+	return &TypePolicies{policies: make(map[string]TypePolicy)}
+}
+
+// LoadTypePolicies reads a registry from a JSON object keyed by task
+// type, as found in a config file.
+func LoadTypePolicies(r io.Reader) (*TypePolicies, error) {
+	policies := make(map[string]TypePolicy)
+	if err := json.NewDecoder(r).Decode(&policies); err != nil {
+		return nil, fmt.Errorf("taskqueue: load type policies: %w", err)
+	}
+	return &TypePolicies{policies: policies}, nil
+}
+
+// Set inserts or replaces the policy for a task type.
+func (p *TypePolicies) Set(taskType string, policy TypePolicy) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.policies[taskType] = policy
+}
+
+// Get returns the policy for a task type and whether one is registered.
+func (p *TypePolicies) Get(taskType string) (TypePolicy, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	policy, ok := p.policies[taskType]
+	return policy, ok
+}
+
+// applyPolicy fills zero task fields from the type's policy at submission.
+func (w *Worker) applyPolicy(task *Task) {
+	if w.config.Policies == nil {
+		return
+	}
+	policy, ok := w.config.Policies.Get(task.Type)
+	if !ok {
+		return
+	}
+	if task.MaxRetries == 0 {
+		task.MaxRetries = policy.MaxRetries
+	}
+	if task.Priority == 0 {
+		task.Priority = policy.Priority
+	}
+}
+
+// processTimeout returns the execution timeout for the task: the task's
+// own timeout when set, then the type policy's, then the pool default.
+func (w *Worker) processTimeout(task *Task) time.Duration {
+	if task.Timeout > 0 {
+		return task.Timeout
+	}
+	if w.config.Policies != nil {
+		if policy, ok := w.config.Policies.Get(task.Type); ok && policy.Timeout > 0 {
+			return policy.Timeout
+		}
+	}
+	return w.config.ProcessTimeout
+}
+
+// typeSlot returns the concurrency semaphore for the task's type, or nil
+// when the type is not concurrency-capped.
+func (w *Worker) typeSlot(task *Task) chan struct{} {
+	if w.config.Policies == nil {
+		return nil
+	}
+	policy, ok := w.config.Policies.Get(task.Type)
+	if !ok || policy.Concurrency <= 0 {
+		return nil
+	}
+	slot, _ := w.slots.LoadOrStore(task.Type, make(chan struct{}, policy.Concurrency))
+	return slot
+}
+
+// slotMap is the lazily populated per-type semaphore table.
+type slotMap = syncmap.Map[string, chan struct{}]
@@ -0,0 +1,82 @@
+package taskqueue
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTypePolicies(t *testing.T) {
+	config := `{
+		"email":  {"timeout": "45s", "max_retries": 3, "backoff": "2s", "priority": 5},
+		"report": {"timeout": 60000000000, "concurrency": 2}
+	}`
+
+	policies, err := LoadTypePolicies(strings.NewReader(config))
+	require.NoError(t, err)
+
+	email, ok := policies.Get("email")
+	require.True(t, ok)
+	assert.Equal(t, 45*time.Second, email.Timeout)
+	assert.Equal(t, 3, email.MaxRetries)
+	assert.Equal(t, 2*time.Second, email.Backoff)
+	assert.Equal(t, 5, email.Priority)
+
+	report, ok := policies.Get("report")
+	require.True(t, ok)
+	assert.Equal(t, time.Minute, report.Timeout)
+	assert.Equal(t, 2, report.Concurrency)
+
+	_, ok = policies.Get("unknown")
+	assert.False(t, ok)
+}
+
+func TestLoadTypePoliciesRejectsBadDuration(t *testing.T) {
+	_, err := LoadTypePolicies(strings.NewReader(`{"email": {"timeout": "forever"}}`))
+	assert.Error(t, err)
+}
+
+func TestSubmitTaskAppliesTypePolicy(t *testing.T) {
+	policies := NewTypePolicies()
+	policies.Set("email", TypePolicy{MaxRetries: 3, Priority: 7})
+	worker := NewWorker(WorkerConfig{Policies: policies}, newMockRepository(), nil)
+
+	task := NewTask("email", nil)
+	require.NoError(t, worker.SubmitTask(context.Background(), task))
+	assert.Equal(t, 3, task.MaxRetries)
+	assert.Equal(t, 7, task.Priority)
+
+	// Explicit values win over the policy defaults.
+	explicit := NewTask("email", nil)
+	explicit.MaxRetries = 1
+	explicit.Priority = 2
+	require.NoError(t, worker.SubmitTask(context.Background(), explicit))
+	assert.Equal(t, 1, explicit.MaxRetries)
+	assert.Equal(t, 2, explicit.Priority)
+}
+
+func TestProcessTimeoutPrefersTypePolicy(t *testing.T) {
+	policies := NewTypePolicies()
+	policies.Set("slow", TypePolicy{Timeout: time.Minute})
+	worker := NewWorker(WorkerConfig{Policies: policies}, newMockRepository(), nil)
+
+	assert.Equal(t, time.Minute, worker.processTimeout(NewTask("slow", nil)))
+	assert.Equal(t, DefaultProcessTimeout, worker.processTimeout(NewTask("fast", nil)))
+}
+
+func TestTypeSlotCapsConcurrency(t *testing.T) {
+	policies := NewTypePolicies()
+	policies.Set("report", TypePolicy{Concurrency: 2})
+	worker := NewWorker(WorkerConfig{Policies: policies}, newMockRepository(), nil)
+
+	slot := worker.typeSlot(NewTask("report", nil))
+	require.NotNil(t, slot)
+	assert.Equal(t, 2, cap(slot))
+	assert.True(t, worker.typeSlot(NewTask("report", nil)) == slot, "same semaphore reused per type")
+
+	assert.Nil(t, worker.typeSlot(NewTask("email", nil)))
+}
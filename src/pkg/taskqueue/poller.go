@@ -0,0 +1,161 @@
+package taskqueue
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Polling defaults.
+const (
+	// DefaultPollInterval is how often the Poller checks the repository
+	// when polls keep returning work.
+	DefaultPollInterval = 5 * time.Second
+
+	// DefaultPollLimit caps how many pending tasks one poll fetches.
+	DefaultPollLimit = 100
+)
+
+// ClaimedByMetadataKey is the Metadata key a claim stamps on the task,
+// recording which poller owner took it.
+const ClaimedByMetadataKey = "taskqueue.claimed_by"
+
+// TaskClaimer is the optional repository capability the Poller prefers:
+// atomically claiming pending tasks for one owner, so pollers on
+// different instances never submit the same task twice. Implementations
+// must hand each pending task to exactly one caller.
+type TaskClaimer interface {
+	// ClaimPending atomically claims up to limit pending tasks for the
+	// owner and returns them.
+	ClaimPending(ctx context.Context, limit int, owner string) ([]*Task, error)
+}
+
+// PollerConfig tunes a Poller.
+type PollerConfig struct {
+	// Interval is the base poll interval. Defaults to
+	// DefaultPollInterval.
+	Interval time.Duration
+
+	// Limit caps how many tasks one poll fetches. Defaults to
+	// DefaultPollLimit.
+	Limit int
+
+	// MaxBackoff caps the exponential backoff applied while polls come
+	// back empty. Defaults to ten times the interval.
+	MaxBackoff time.Duration
+
+	// Owner identifies this poller in claims. Defaults to the worker's
+	// instance ID.
+	Owner string
+}
+
+// Poller drains persisted pending tasks back into a worker: it
+// periodically fetches a batch from the repository, claims it and
+// submits it, backing off while the repository stays empty. Running one
+// after Start recovers the work a previous process persisted but never
+// finished — the restart-recovery half of the repository contract that
+// writing tasks on submission only sets up.
+type Poller struct {
+	worker *Worker
+	config PollerConfig
+}
+
+// NewPoller builds a poller for the given worker, applying defaults for
+// any zero config fields.
+func NewPoller(worker *Worker, config PollerConfig) *Poller {
+	if config.Interval <= 0 {
+		config.Interval = DefaultPollInterval
+	}
+	if config.Limit <= 0 {
+		config.Limit = DefaultPollLimit
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = 10 * config.Interval
+	}
+	if config.Owner == "" {
+		config.Owner = worker.instanceID
+	}
+	if config.Owner == "" {
+		config.Owner = newTaskID()
+	}
+	return &Poller{worker: worker, config: config}
+}
+
+// Poll fetches and submits one batch of pending tasks, returning how
+// many it handed to the worker. Repositories implementing SweepLocker
+// serialize polls across instances: a poll that does not win the
+// recovery lock is a quiet no-op. Repositories implementing TaskClaimer
+// get tasks claimed atomically; otherwise the poller falls back to
+// ListPending and skips tasks this worker already has in flight, which
+// is only safe with a single polling instance.
+func (p *Poller) Poll(ctx context.Context) (int, error) {
+	held, err := acquireSweepLock(ctx, p.worker.repo, sweepLockRecovery, p.config.Owner, p.config.Interval)
+	if err != nil {
+		return 0, fmt.Errorf("taskqueue: recovery sweep lock: %w", err)
+	}
+	if !held {
+		return 0, nil
+	}
+	tasks, err := p.fetch(ctx)
+	if err != nil {
+		return 0, err
+	}
+	submitted := 0
+	merr := &MultiError{}
+	for i, task := range tasks {
+		if _, inFlight := p.worker.cache.Load(task.ID); inFlight {
+			continue
+		}
+		if err := p.worker.SubmitTask(ctx, task); err != nil {
+			merr.Add(i, fmt.Errorf("taskqueue: poll submit %s: %w", task.ID, err))
+			continue
+		}
+		submitted++
+	}
+	return submitted, merr.ErrOrNil()
+}
+
+// fetch claims a batch through the repository's claimer, falling back
+// to a plain pending listing.
+func (p *Poller) fetch(ctx context.Context) ([]*Task, error) {
+	if claimer, ok := repositoryAs[TaskClaimer](p.worker.repo); ok {
+		tasks, err := claimer.ClaimPending(ctx, p.config.Limit, p.config.Owner)
+		if err != nil {
+			return nil, fmt.Errorf("taskqueue: claim pending: %w", err)
+		}
+		return tasks, nil
+	}
+	tasks, err := p.worker.repo.ListPending(ctx, p.config.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("taskqueue: list pending: %w", err)
+	}
+	return tasks, nil
+}
+
+// Run polls until ctx is cancelled, doubling the wait up to MaxBackoff
+// while polls come back empty and snapping back to the base interval as
+// soon as one returns work. The first poll is delayed by a deterministic
+// per-owner jitter so a fleet restarting together does not sweep the
+// repository simultaneously.
+func (p *Poller) Run(ctx context.Context) {
+	wait := p.config.Interval
+	timer := time.NewTimer(wait + sweepJitter(p.config.Owner, wait))
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		submitted, err := p.Poll(ctx)
+		if err != nil {
+			p.worker.logger.Error("taskqueue: poll failed", "owner", p.config.Owner, "error", err)
+		}
+		if submitted > 0 {
+			wait = p.config.Interval
+		} else {
+			wait = min(wait*2, p.config.MaxBackoff)
+		}
+		timer.Reset(wait)
+	}
+}
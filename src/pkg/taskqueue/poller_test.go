@@ -0,0 +1,123 @@
+package taskqueue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// claimingRepository extends the mock repository with atomic claims.
+type claimingRepository struct {
+	*mockRepository
+	mu     sync.Mutex
+	claims map[string]string
+}
+
+func newClaimingRepository() *claimingRepository {
+	return &claimingRepository{mockRepository: newMockRepository(), claims: make(map[string]string)}
+}
+
+func (r *claimingRepository) ClaimPending(ctx context.Context, limit int, owner string) ([]*Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	pending, err := r.ListPending(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	var claimed []*Task
+	for _, task := range pending {
+		if limit > 0 && len(claimed) >= limit {
+			break
+		}
+		if _, taken := r.claims[task.ID]; taken {
+			continue
+		}
+		r.claims[task.ID] = owner
+		claimed = append(claimed, task)
+	}
+	return claimed, nil
+}
+
+func TestPollerRecoversPersistedTasks(t *testing.T) {
+	repo := newClaimingRepository()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, repo.Save(context.Background(), NewTask("email", nil)))
+	}
+
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, repo, nil)
+	w.Executors().Register("email", ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		return &TaskResult{}, nil
+	}))
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	poller := NewPoller(w, PollerConfig{Interval: 10 * time.Millisecond, Owner: "instance-a"})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go poller.Run(ctx)
+
+	require.Eventually(t, func() bool { return w.Stats().Completed == 3 }, 2*time.Second, 5*time.Millisecond)
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	assert.Len(t, repo.claims, 3)
+	for _, owner := range repo.claims {
+		assert.Equal(t, "instance-a", owner)
+	}
+}
+
+func TestPollerClaimsAreExclusive(t *testing.T) {
+	repo := newClaimingRepository()
+	require.NoError(t, repo.Save(context.Background(), NewTask("email", nil)))
+
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, repo, nil)
+	defer w.Shutdown(context.Background())
+	w.Executors().Register("email", ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		return &TaskResult{}, nil
+	}))
+	w.Start()
+
+	first := NewPoller(w, PollerConfig{Owner: "a"})
+	second := NewPoller(w, PollerConfig{Owner: "b"})
+
+	submitted, err := first.Poll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, submitted)
+
+	submitted, err = second.Poll(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, submitted, "claimed tasks are not handed out twice")
+}
+
+func TestPollerFallbackSkipsInFlightTasks(t *testing.T) {
+	repo := newMockRepository()
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, repo, nil)
+	defer w.Shutdown(context.Background())
+	release := make(chan struct{})
+	w.Executors().Register("email", ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		<-release
+		return &TaskResult{}, nil
+	}))
+	w.Start()
+
+	require.NoError(t, w.SubmitTask(context.Background(), NewTask("email", nil)))
+
+	poller := NewPoller(w, PollerConfig{})
+	submitted, err := poller.Poll(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, submitted, "a task this worker already carries is not resubmitted")
+	close(release)
+}
+
+func TestPollerEmptyRepositoryIsNotAnError(t *testing.T) {
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), nil)
+	defer w.Shutdown(context.Background())
+
+	submitted, err := NewPoller(w, PollerConfig{}).Poll(context.Background())
+	assert.NoError(t, err)
+	assert.Zero(t, submitted)
+}
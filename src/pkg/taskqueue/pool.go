@@ -0,0 +1,18 @@
+package taskqueue
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+)
+
+// bufferPool recycles encode buffers so the hot publishing path does not
+// allocate per task.
+var bufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// builderPool recycles string builders for summary formatting.
+var builderPool = sync.Pool{
+	New: func() any { return new(strings.Builder) },
+}
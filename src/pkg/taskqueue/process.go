@@ -0,0 +1,41 @@
+package taskqueue
+
+import (
+	"context"
+)
+
+// ProcessFunc handles one task outside of a Worker pool.
+type ProcessFunc func(ctx context.Context, task *Task) error
+
+// ProcessWithGracefulShutdown processes tasks sequentially, stopping
+// cleanly when ctx is cancelled. It returns the first processing error or
+// the context error if cancelled mid-batch. It is a thin wrapper over
+// Runner.
+func ProcessWithGracefulShutdown(ctx context.Context, tasks []*Task, fn ProcessFunc) error {
+	errs := NewRunner(RunnerConfig{Concurrency: 1, StopOnError: true}).Run(ctx, tasks, fn)
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// ProcessConcurrent processes tasks with up to workers goroutines and
+// returns the errors encountered, in no particular order. It is a thin
+// wrapper over Runner.
+func ProcessConcurrent(ctx context.Context, tasks []*Task, workers int, fn ProcessFunc) []error {
+	return NewRunner(RunnerConfig{Concurrency: workers}).Run(ctx, tasks, fn)
+}
+
+// ProcessBatch processes tasks sequentially, keeps going past failures
+// and returns them aggregated in a *MultiError mapping task indexes to
+// their errors. The context ending mid-batch is returned as-is.
+func ProcessBatch(ctx context.Context, tasks []*Task, fn ProcessFunc) error {
+	merr := &MultiError{}
+	for i, task := range tasks {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		merr.Add(i, fn(ctx, task))
+	}
+	return merr.ErrOrNil()
+}
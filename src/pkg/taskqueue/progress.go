@@ -0,0 +1,61 @@
+package taskqueue
+
+import (
+	"context"
+	"time"
+)
+
+// progressKey is the context key carrying the task's progress reporter.
+type progressKey struct{}
+
+// TaskProgress is one intermediate progress report from an executor,
+// published on Bus().Progress and readable via Worker.Progress.
+type TaskProgress struct {
+	TaskID  string    `json:"task_id"`
+	Percent float64   `json:"percent"`
+	Message string    `json:"message,omitempty"`
+	At      time.Time `json:"at"`
+}
+
+// progressReporter binds progress reports from one execution to its
+// worker and task.
+type progressReporter struct {
+	worker *Worker
+	taskID string
+}
+
+// report records and publishes one progress update.
+func (r *progressReporter) report(percent float64, message string) {
+	progress := TaskProgress{
+		TaskID:  r.taskID,
+		Percent: min(max(percent, 0), 100),
+		Message: message,
+		At:      time.Now().UTC(),
+	}
+	r.worker.progress.Store(r.taskID, progress)
+	r.worker.bus.Progress.Publish(progress)
+}
+
+// ReportProgress records intermediate progress for the task being
+// processed in ctx, so long-running executors are observable before
+// they produce a result. The percentage is clamped to [0, 100], the
+// latest report is readable via Worker.Progress and every report is
+// published on Bus().Progress. Outside a worker execution it is a
+// no-op.
+func ReportProgress(ctx context.Context, percent float64, message string) {
+	if reporter, ok := ctx.Value(progressKey{}).(*progressReporter); ok {
+		reporter.report(percent, message)
+	}
+}
+
+// withProgress installs a progress reporter for the task into ctx.
+func (w *Worker) withProgress(ctx context.Context, task *Task) context.Context {
+	return context.WithValue(ctx, progressKey{}, &progressReporter{worker: w, taskID: task.ID})
+}
+
+// Progress returns the latest progress reported for the task, if any.
+// Reports only live for the duration of the processing attempt; once
+// the task finishes, its result is the source of truth.
+func (w *Worker) Progress(taskID string) (TaskProgress, bool) {
+	return w.progress.Load(taskID)
+}
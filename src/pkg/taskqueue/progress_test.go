@@ -0,0 +1,60 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportProgressOutsideWorkerIsNoOp(t *testing.T) {
+	assert.NotPanics(t, func() {
+		ReportProgress(context.Background(), 50, "halfway")
+	})
+}
+
+func TestReportProgressPublishesAndExposesLatest(t *testing.T) {
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), nil)
+	reported := make(chan struct{})
+	release := make(chan struct{})
+	w.Executors().Register("export", ExecutorFunc(func(ctx context.Context, _ *Task) (*TaskResult, error) {
+		ReportProgress(ctx, 10, "scanning")
+		ReportProgress(ctx, 160, "writing")
+		close(reported)
+		<-release
+		return &TaskResult{}, nil
+	}))
+
+	updates, cancel := w.Bus().Progress.Subscribe(16, DropNewest)
+	defer cancel()
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	task := NewTask("export", nil)
+	require.NoError(t, w.SubmitTask(context.Background(), task))
+
+	select {
+	case <-reported:
+	case <-time.After(2 * time.Second):
+		t.Fatal("executor never reported")
+	}
+
+	latest, ok := w.Progress(task.ID)
+	require.True(t, ok)
+	assert.Equal(t, task.ID, latest.TaskID)
+	assert.Equal(t, float64(100), latest.Percent, "percentages are clamped to [0, 100]")
+	assert.Equal(t, "writing", latest.Message)
+
+	first := <-updates
+	assert.Equal(t, float64(10), first.Percent)
+	assert.Equal(t, "scanning", first.Message)
+
+	close(release)
+	require.Eventually(t, func() bool { return w.Stats().Completed == 1 }, 2*time.Second, 5*time.Millisecond)
+	require.Eventually(t, func() bool {
+		_, ok := w.Progress(task.ID)
+		return !ok
+	}, 2*time.Second, 5*time.Millisecond, "reports are dropped once the attempt ends")
+}
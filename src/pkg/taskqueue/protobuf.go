@@ -0,0 +1,164 @@
+package taskqueue
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue/protowire"
+)
+
+// ProtobufCodec encodes tasks and results as the taskqueue.v1.Task and
+// taskqueue.v1.TaskResult messages from grpcapi/taskqueue.proto, using
+// the protowire helpers instead of the protobuf runtime. Payloads are
+// byte-compatible with protoc-generated code, so consumers in other
+// languages decode them with their regular stubs. The data map rides as
+// a JSON-encoded bytes field, per the proto contract.
+type ProtobufCodec struct{}
+
+// Name implements Codec.
+func (ProtobufCodec) Name() string { return "protobuf" }
+
+// EncodeTask implements Codec.
+func (ProtobufCodec) EncodeTask(task *Task) ([]byte, error) {
+	if task == nil {
+		return nil, ErrNilTask
+	}
+	var data []byte
+	if task.Data != nil {
+		encoded, err := json.Marshal(task.Data)
+		if err != nil {
+			return nil, fmt.Errorf("taskqueue: protobuf encode task data: %w", err)
+		}
+		data = encoded
+	}
+	var b []byte
+	b = protowire.AppendStringField(b, 1, task.ID)
+	b = protowire.AppendStringField(b, 2, task.Type)
+	b = protowire.AppendBytesField(b, 3, data)
+	b = protowire.AppendStringField(b, 4, string(task.Status))
+	b = protowire.AppendVarintField(b, 5, uint64(int64(task.Priority)))
+	b = protowire.AppendVarintField(b, 6, uint64(int64(task.Retries)))
+	b = protowire.AppendVarintField(b, 7, uint64(int64(task.MaxRetries)))
+	b = protowire.AppendMapField(b, 8, task.Metadata)
+	b = protowire.AppendStringField(b, 9, protoTime(task.CreatedAt))
+	b = protowire.AppendStringField(b, 10, protoTime(task.UpdatedAt))
+	b = protowire.AppendStringField(b, 11, protoTime(task.ExpiresAt))
+	b = protowire.AppendVarintField(b, 12, uint64(task.Timeout))
+	b = protowire.AppendStringField(b, 13, protoTime(task.Deadline))
+	return b, nil
+}
+
+// DecodeTask implements Codec; every error wraps ErrInvalidTask.
+func (ProtobufCodec) DecodeTask(data []byte) (*Task, error) {
+	var task Task
+	err := protowire.Parse(data, func(f protowire.Field) error {
+		var err error
+		switch f.Num {
+		case 1:
+			task.ID = string(f.Bytes)
+		case 2:
+			task.Type = string(f.Bytes)
+		case 3:
+			err = json.Unmarshal(f.Bytes, &task.Data)
+		case 4:
+			task.Status = TaskStatus(f.Bytes)
+		case 5:
+			task.Priority = int(int64(f.Varint))
+		case 6:
+			task.Retries = int(int64(f.Varint))
+		case 7:
+			task.MaxRetries = int(int64(f.Varint))
+		case 8:
+			var key, value string
+			if key, value, err = protowire.ParseMapEntry(f.Bytes); err == nil {
+				if task.Metadata == nil {
+					task.Metadata = make(map[string]string)
+				}
+				task.Metadata[key] = value
+			}
+		case 9:
+			task.CreatedAt, err = parseProtoTime(f.Bytes)
+		case 10:
+			task.UpdatedAt, err = parseProtoTime(f.Bytes)
+		case 11:
+			task.ExpiresAt, err = parseProtoTime(f.Bytes)
+		case 12:
+			task.Timeout = time.Duration(f.Varint)
+		case 13:
+			task.Deadline, err = parseProtoTime(f.Bytes)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTask, err)
+	}
+	return &task, nil
+}
+
+// EncodeResult implements Codec.
+func (ProtobufCodec) EncodeResult(result *TaskResult) ([]byte, error) {
+	var b []byte
+	b = protowire.AppendStringField(b, 1, result.TaskID)
+	b = protowire.AppendStringField(b, 2, result.TaskType)
+	b = protowire.AppendStringField(b, 3, result.Namespace)
+	b = protowire.AppendStringField(b, 4, result.CorrelationID)
+	if result.Success {
+		b = protowire.AppendVarintField(b, 5, 1)
+	}
+	b = protowire.AppendStringField(b, 6, result.Output)
+	b = protowire.AppendStringField(b, 7, result.Error)
+	b = protowire.AppendVarintField(b, 8, uint64(result.Duration))
+	b = protowire.AppendStringField(b, 9, protoTime(result.CompletedAt))
+	return b, nil
+}
+
+// DecodeResult implements Codec.
+func (ProtobufCodec) DecodeResult(data []byte) (*TaskResult, error) {
+	var result TaskResult
+	err := protowire.Parse(data, func(f protowire.Field) error {
+		var err error
+		switch f.Num {
+		case 1:
+			result.TaskID = string(f.Bytes)
+		case 2:
+			result.TaskType = string(f.Bytes)
+		case 3:
+			result.Namespace = string(f.Bytes)
+		case 4:
+			result.CorrelationID = string(f.Bytes)
+		case 5:
+			result.Success = f.Varint != 0
+		case 6:
+			result.Output = string(f.Bytes)
+		case 7:
+			result.Error = string(f.Bytes)
+		case 8:
+			result.Duration = time.Duration(f.Varint)
+		case 9:
+			result.CompletedAt, err = parseProtoTime(f.Bytes)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("taskqueue: decode result: %w", err)
+	}
+	return &result, nil
+}
+
+// protoTime formats a timestamp field, writing the empty string for the
+// zero time.
+func protoTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// parseProtoTime parses a timestamp field.
+func parseProtoTime(b []byte) (time.Time, error) {
+	if len(b) == 0 {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, string(b))
+}
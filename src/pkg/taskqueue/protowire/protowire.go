@@ -0,0 +1,159 @@
+// Package protowire implements the subset of the proto3 binary wire
+// format shared by the taskqueue protobuf codec and the grpcapi message
+// bindings: varints, length-delimited fields and string maps. It exists
+// so both speak the same bytes without the module depending on the
+// protobuf runtime.
+package protowire
+
+import (
+	"errors"
+	"maps"
+	"slices"
+)
+
+// ErrMalformed is returned when input is not valid proto3 wire data.
+var ErrMalformed = errors.New("protowire: malformed message")
+
+// Wire types of the proto3 binary format.
+const (
+	TypeVarint  = 0
+	TypeFixed64 = 1
+	TypeBytes   = 2
+	TypeFixed32 = 5
+)
+
+// AppendVarint appends v in base-128 varint encoding.
+func AppendVarint(b []byte, v uint64) []byte {
+	for v >= 0x80 {
+		b = append(b, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(b, byte(v))
+}
+
+// AppendTag appends the field tag for the given number and wire type.
+func AppendTag(b []byte, field int, wireType int) []byte {
+	return AppendVarint(b, uint64(field)<<3|uint64(wireType))
+}
+
+// AppendVarintField appends a varint field, omitted at its zero value as
+// proto3 does for implicit-presence fields.
+func AppendVarintField(b []byte, field int, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = AppendTag(b, field, TypeVarint)
+	return AppendVarint(b, v)
+}
+
+// AppendBytesField appends a length-delimited field, omitted when empty.
+func AppendBytesField(b []byte, field int, v []byte) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	b = AppendTag(b, field, TypeBytes)
+	b = AppendVarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+// AppendStringField appends a string field, omitted when empty.
+func AppendStringField(b []byte, field int, v string) []byte {
+	return AppendBytesField(b, field, []byte(v))
+}
+
+// AppendMapField appends a map<string, string> field as its repeated
+// entry messages, in sorted key order so encoding is deterministic.
+func AppendMapField(b []byte, field int, m map[string]string) []byte {
+	for _, key := range slices.Sorted(maps.Keys(m)) {
+		var entry []byte
+		entry = AppendStringField(entry, 1, key)
+		entry = AppendStringField(entry, 2, m[key])
+		b = AppendTag(b, field, TypeBytes)
+		b = AppendVarint(b, uint64(len(entry)))
+		b = append(b, entry...)
+	}
+	return b
+}
+
+// ConsumeVarint reads one varint, returning its value and encoded size;
+// a size of zero means the input is malformed.
+func ConsumeVarint(b []byte) (uint64, int) {
+	var v uint64
+	for n := 0; n < len(b) && n < 10; n++ {
+		v |= uint64(b[n]&0x7f) << (7 * n)
+		if b[n] < 0x80 {
+			return v, n + 1
+		}
+	}
+	return 0, 0
+}
+
+// Field is one decoded field of a message. Exactly one of Varint and
+// Bytes is meaningful, depending on the field's wire type.
+type Field struct {
+	Num    int
+	Varint uint64
+	Bytes  []byte
+}
+
+// Parse walks the fields of one encoded message, calling fn for each
+// varint and length-delimited field and skipping unknown fixed-width
+// fields, as proto3 decoders must.
+func Parse(data []byte, fn func(f Field) error) error {
+	for len(data) > 0 {
+		tag, n := ConsumeVarint(data)
+		if n == 0 {
+			return ErrMalformed
+		}
+		data = data[n:]
+		field := Field{Num: int(tag >> 3)}
+		switch tag & 7 {
+		case TypeVarint:
+			v, n := ConsumeVarint(data)
+			if n == 0 {
+				return ErrMalformed
+			}
+			field.Varint = v
+			data = data[n:]
+		case TypeBytes:
+			size, n := ConsumeVarint(data)
+			if n == 0 || size > uint64(len(data)-n) {
+				return ErrMalformed
+			}
+			field.Bytes = data[n : n+int(size)]
+			data = data[n+int(size):]
+		case TypeFixed64:
+			if len(data) < 8 {
+				return ErrMalformed
+			}
+			data = data[8:]
+			continue
+		case TypeFixed32:
+			if len(data) < 4 {
+				return ErrMalformed
+			}
+			data = data[4:]
+			continue
+		default:
+			return ErrMalformed
+		}
+		if err := fn(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseMapEntry decodes one map<string, string> entry message.
+func ParseMapEntry(data []byte) (key, value string, err error) {
+	err = Parse(data, func(f Field) error {
+		switch f.Num {
+		case 1:
+			key = string(f.Bytes)
+		case 2:
+			value = string(f.Bytes)
+		}
+		return nil
+	})
+	return key, value, err
+}
@@ -0,0 +1,69 @@
+package taskqueue
+
+import (
+	"context"
+	"strings"
+)
+
+// Publisher delivers serialized messages to a named topic on the message
+// bus. Implementations are expected to be safe for concurrent use.
+type Publisher interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+}
+
+// resultTopic is the topic task results are published to when the task
+// has no namespace.
+const resultTopic = "task.results"
+
+// NamespaceMetadataKey is the Metadata key carrying a task's tenant
+// namespace.
+const NamespaceMetadataKey = "taskqueue.namespace"
+
+// Namespace returns the tenant namespace of the task, or "" when the
+// task is not namespaced.
+func (t *Task) Namespace() string {
+	return t.Metadata[NamespaceMetadataKey]
+}
+
+// ResultTopic returns the topic results for the given namespace are
+// published to: "task.results" for the empty namespace, otherwise
+// "task.results.{namespace}".
+func ResultTopic(namespace string) string {
+	if namespace == "" {
+		return resultTopic
+	}
+	return resultTopic + "." + namespace
+}
+
+// NamespacePublisher routes messages to per-namespace publishers so each
+// tenant's result stream can use its own broker credentials. Messages on
+// topics without a registered namespace go to the default publisher.
+type NamespacePublisher struct {
+	fallback    Publisher
+	byNamespace map[string]Publisher
+}
+
+// NewNamespacePublisher returns a router delivering unmatched topics to
+// the given default publisher.
+func NewNamespacePublisher(fallback Publisher) *NamespacePublisher {
+	return &NamespacePublisher{
+		fallback:    fallback,
+		byNamespace: make(map[string]Publisher),
+	}
+}
+
+// Register routes the namespace's topics to the given publisher. Register
+// all namespaces before publishing starts; the map is not locked.
+func (p *NamespacePublisher) Register(namespace string, publisher Publisher) {
+	p.byNamespace[namespace] = publisher
+}
+
+// Publish implements Publisher by routing on the topic's namespace suffix.
+func (p *NamespacePublisher) Publish(ctx context.Context, topic string, payload []byte) error {
+	if namespace, ok := strings.CutPrefix(topic, resultTopic+"."); ok {
+		if publisher, ok := p.byNamespace[namespace]; ok {
+			return publisher.Publish(ctx, topic, payload)
+		}
+	}
+	return p.fallback.Publish(ctx, topic, payload)
+}
@@ -0,0 +1,51 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultTopic(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		want      string
+	}{
+		{"no namespace", "", "task.results"},
+		{"tenant namespace", "acme", "task.results.acme"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ResultTopic(tt.namespace))
+		})
+	}
+}
+
+func TestTaskNamespace(t *testing.T) {
+	task := NewTask("email", nil)
+	assert.Empty(t, task.Namespace())
+
+	task.Metadata = map[string]string{NamespaceMetadataKey: "acme"}
+	assert.Equal(t, "acme", task.Namespace())
+}
+
+func TestNamespacePublisherRouting(t *testing.T) {
+	fallback := newMockPublisher()
+	acme := newMockPublisher()
+
+	router := NewNamespacePublisher(fallback)
+	router.Register("acme", acme)
+
+	ctx := context.Background()
+	require.NoError(t, router.Publish(ctx, ResultTopic("acme"), []byte("a")))
+	require.NoError(t, router.Publish(ctx, ResultTopic("globex"), []byte("b")))
+	require.NoError(t, router.Publish(ctx, ResultTopic(""), []byte("c")))
+
+	assert.Len(t, acme.messages[ResultTopic("acme")], 1)
+	assert.Len(t, fallback.messages[ResultTopic("globex")], 1)
+	assert.Len(t, fallback.messages[ResultTopic("")], 1)
+	assert.Empty(t, fallback.messages[ResultTopic("acme")])
+}
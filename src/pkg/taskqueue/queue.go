@@ -0,0 +1,377 @@
+package taskqueue
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+)
+
+// Queue is the buffering discipline between submission and the worker
+// pool. Implementations must be safe for concurrent use. After Close,
+// Enqueue fails but Dequeue keeps returning buffered tasks until the
+// queue is empty, then reports ErrQueueClosed.
+type Queue interface {
+	// Enqueue adds a task without blocking, returning ErrQueueFull when
+	// at capacity or ErrQueueClosed after Close.
+	Enqueue(task *Task) error
+
+	// Dequeue blocks until a task is available, ctx is done, or the
+	// queue is closed and drained.
+	Dequeue(ctx context.Context) (*Task, error)
+
+	// Len returns the number of buffered tasks.
+	Len() int
+
+	// Close stops intake. It is safe to call multiple times.
+	Close() error
+}
+
+// channelQueue is the default FIFO queue backed by a buffered channel.
+type channelQueue struct {
+	ch     chan *Task
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewChannelQueue returns a FIFO queue with the given capacity.
+func NewChannelQueue(capacity int) Queue {
+	if capacity <= 0 {
+		capacity = DefaultBufferSize
+	}
+	return &channelQueue{ch: make(chan *Task, capacity)}
+}
+
+func (q *channelQueue) Enqueue(task *Task) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+	select {
+	case q.ch <- task:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+func (q *channelQueue) Dequeue(ctx context.Context) (*Task, error) {
+	select {
+	case task, ok := <-q.ch:
+		if !ok {
+			return nil, ErrQueueClosed
+		}
+		return task, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (q *channelQueue) Len() int {
+	return len(q.ch)
+}
+
+func (q *channelQueue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.closed {
+		q.closed = true
+		close(q.ch)
+	}
+	return nil
+}
+
+// store is the ordering backend shared by the lock-based queues.
+type store interface {
+	push(task *Task)
+	pop() (*Task, bool)
+	len() int
+}
+
+// blockingQueue adds capacity, blocking Dequeue and close semantics on
+// top of a store.
+type blockingQueue struct {
+	mu       sync.Mutex
+	items    store
+	capacity int
+	closed   bool
+	signal   chan struct{}
+	closedCh chan struct{}
+}
+
+func newBlockingQueue(items store, capacity int) *blockingQueue {
+	if capacity <= 0 {
+		capacity = DefaultBufferSize
+	}
+	return &blockingQueue{
+		items:    items,
+		capacity: capacity,
+		signal:   make(chan struct{}, capacity),
+		closedCh: make(chan struct{}),
+	}
+}
+
+func (q *blockingQueue) Enqueue(task *Task) error {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return ErrQueueClosed
+	}
+	if q.items.len() >= q.capacity {
+		q.mu.Unlock()
+		return ErrQueueFull
+	}
+	q.items.push(task)
+	q.mu.Unlock()
+	select {
+	case q.signal <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (q *blockingQueue) Dequeue(ctx context.Context) (*Task, error) {
+	for {
+		q.mu.Lock()
+		if task, ok := q.items.pop(); ok {
+			q.mu.Unlock()
+			return task, nil
+		}
+		closed := q.closed
+		q.mu.Unlock()
+		if closed {
+			return nil, ErrQueueClosed
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-q.signal:
+		case <-q.closedCh:
+		}
+	}
+}
+
+func (q *blockingQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.len()
+}
+
+func (q *blockingQueue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.closed {
+		q.closed = true
+		close(q.closedCh)
+	}
+	return nil
+}
+
+// ringStore is a fixed-capacity circular FIFO buffer.
+type ringStore struct {
+	buf   []*Task
+	head  int
+	count int
+}
+
+func (s *ringStore) push(task *Task) {
+	s.buf[(s.head+s.count)%len(s.buf)] = task
+	s.count++
+}
+
+func (s *ringStore) pop() (*Task, bool) {
+	if s.count == 0 {
+		return nil, false
+	}
+	task := s.buf[s.head]
+	s.buf[s.head] = nil
+	s.head = (s.head + 1) % len(s.buf)
+	s.count--
+	return task, true
+}
+
+func (s *ringStore) len() int { return s.count }
+
+// NewRingQueue returns a FIFO queue backed by a pre-allocated ring buffer,
+// avoiding channel overhead for very large capacities.
+func NewRingQueue(capacity int) Queue {
+	if capacity <= 0 {
+		capacity = DefaultBufferSize
+	}
+	return newBlockingQueue(&ringStore{buf: make([]*Task, capacity)}, capacity)
+}
+
+// lifoStore is a stack: the freshest task is popped first.
+type lifoStore struct {
+	items []*Task
+}
+
+func (s *lifoStore) push(task *Task) { s.items = append(s.items, task) }
+
+func (s *lifoStore) pop() (*Task, bool) {
+	if len(s.items) == 0 {
+		return nil, false
+	}
+	task := s.items[len(s.items)-1]
+	s.items[len(s.items)-1] = nil
+	s.items = s.items[:len(s.items)-1]
+	return task, true
+}
+
+func (s *lifoStore) len() int { return len(s.items) }
+
+// NewLIFOQueue returns a queue that dequeues the most recently enqueued
+// task first, keeping latency low for fresh work while a backlog ages.
+func NewLIFOQueue(capacity int) Queue {
+	return newBlockingQueue(&lifoStore{}, capacity)
+}
+
+// heapStore orders tasks by descending Priority, FIFO within one priority.
+type heapStore struct {
+	entries []heapEntry
+	seq     uint64
+}
+
+type heapEntry struct {
+	task *Task
+	seq  uint64
+}
+
+func (s *heapStore) Len() int { return len(s.entries) }
+
+func (s *heapStore) Less(i, j int) bool {
+	if s.entries[i].task.Priority != s.entries[j].task.Priority {
+		return s.entries[i].task.Priority > s.entries[j].task.Priority
+	}
+	return s.entries[i].seq < s.entries[j].seq
+}
+
+func (s *heapStore) Swap(i, j int) {
+	s.entries[i], s.entries[j] = s.entries[j], s.entries[i]
+}
+
+func (s *heapStore) Push(x any) {
+	s.entries = append(s.entries, x.(heapEntry))
+}
+
+func (s *heapStore) Pop() any {
+	last := s.entries[len(s.entries)-1]
+	s.entries = s.entries[:len(s.entries)-1]
+	return last
+}
+
+func (s *heapStore) push(task *Task) {
+	s.seq++
+	heap.Push(s, heapEntry{task: task, seq: s.seq})
+}
+
+func (s *heapStore) pop() (*Task, bool) {
+	if len(s.entries) == 0 {
+		return nil, false
+	}
+	return heap.Pop(s).(heapEntry).task, true
+}
+
+func (s *heapStore) len() int { return len(s.entries) }
+
+// NewPriorityQueue returns a queue that dequeues the highest Priority
+// task first, FIFO among equal priorities.
+func NewPriorityQueue(capacity int) Queue {
+	return newBlockingQueue(&heapStore{}, capacity)
+}
+
+// TaskOrdering selects the dequeue discipline of a buffer declared in
+// the worker config, for callers who want a different ordering without
+// supplying a Queue implementation themselves.
+type TaskOrdering string
+
+// Dequeue disciplines accepted by WorkerConfig.Ordering and
+// QueueConfig.Ordering.
+const (
+	// OrderFIFO processes tasks in submission order. The default.
+	OrderFIFO TaskOrdering = "fifo"
+
+	// OrderLIFO processes the freshest task first, which keeps latency
+	// low for new work while a backlog ages.
+	OrderLIFO TaskOrdering = "lifo"
+
+	// OrderPriority processes the highest Priority task first, FIFO
+	// among equal priorities.
+	OrderPriority TaskOrdering = "priority"
+)
+
+// newOrderedQueue builds the queue implementing the ordering, defaulting
+// to FIFO for the zero value.
+func newOrderedQueue(ordering TaskOrdering, capacity int) Queue {
+	switch ordering {
+	case OrderLIFO:
+		return NewLIFOQueue(capacity)
+	case OrderPriority:
+		return NewPriorityQueue(capacity)
+	default:
+		return NewChannelQueue(capacity)
+	}
+}
+
+// diskQueue persists every buffered task in overflow segment files,
+// trading latency for durability of the backlog itself.
+type diskQueue struct {
+	q      *overflowQueue
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewDiskQueue returns a queue storing its backlog in segment files under
+// dir. Capacity is unbounded; Enqueue only fails on I/O errors.
+func NewDiskQueue(dir string, segmentSize int) (Queue, error) {
+	q, err := newOverflowQueue(dir, segmentSize)
+	if err != nil {
+		return nil, err
+	}
+	return &diskQueue{q: q}, nil
+}
+
+func (d *diskQueue) Enqueue(task *Task) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return ErrQueueClosed
+	}
+	return d.q.Push(task)
+}
+
+func (d *diskQueue) Dequeue(ctx context.Context) (*Task, error) {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		if task, ok := d.q.Pop(); ok {
+			return task, nil
+		}
+		d.mu.Lock()
+		closed := d.closed
+		d.mu.Unlock()
+		if closed {
+			return nil, ErrQueueClosed
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *diskQueue) Len() int {
+	return d.q.Len()
+}
+
+func (d *diskQueue) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return nil
+	}
+	d.closed = true
+	return d.q.Close()
+}
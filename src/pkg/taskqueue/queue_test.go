@@ -0,0 +1,154 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testQueueFIFO(t *testing.T, q Queue) {
+	t.Helper()
+	require.NoError(t, q.Enqueue(&Task{ID: "a"}))
+	require.NoError(t, q.Enqueue(&Task{ID: "b"}))
+	assert.Equal(t, 2, q.Len())
+
+	task, err := q.Dequeue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "a", task.ID)
+
+	require.NoError(t, q.Close())
+	assert.ErrorIs(t, q.Enqueue(&Task{ID: "c"}), ErrQueueClosed)
+
+	// Remaining tasks drain after Close, then the queue reports closed.
+	task, err = q.Dequeue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "b", task.ID)
+	_, err = q.Dequeue(context.Background())
+	assert.ErrorIs(t, err, ErrQueueClosed)
+}
+
+func TestQueueImplementations(t *testing.T) {
+	t.Run("channel", func(t *testing.T) { testQueueFIFO(t, NewChannelQueue(4)) })
+	t.Run("ring", func(t *testing.T) { testQueueFIFO(t, NewRingQueue(4)) })
+	t.Run("disk", func(t *testing.T) {
+		q, err := NewDiskQueue(t.TempDir(), 0)
+		require.NoError(t, err)
+		testQueueFIFO(t, q)
+	})
+}
+
+func TestQueueFullAndContext(t *testing.T) {
+	q := NewRingQueue(1)
+	require.NoError(t, q.Enqueue(&Task{ID: "a"}))
+	assert.ErrorIs(t, q.Enqueue(&Task{ID: "b"}), ErrQueueFull)
+
+	_, err := q.Dequeue(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = q.Dequeue(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestPriorityQueueOrdering(t *testing.T) {
+	q := NewPriorityQueue(8)
+	require.NoError(t, q.Enqueue(&Task{ID: "low", Priority: 1}))
+	require.NoError(t, q.Enqueue(&Task{ID: "high", Priority: 9}))
+	require.NoError(t, q.Enqueue(&Task{ID: "mid-1", Priority: 5}))
+	require.NoError(t, q.Enqueue(&Task{ID: "mid-2", Priority: 5}))
+
+	var order []string
+	for i := 0; i < 4; i++ {
+		task, err := q.Dequeue(context.Background())
+		require.NoError(t, err)
+		order = append(order, task.ID)
+	}
+	assert.Equal(t, []string{"high", "mid-1", "mid-2", "low"}, order)
+}
+
+func TestWorkerWithPriorityQueue(t *testing.T) {
+	w := NewWorker(WorkerConfig{WorkerCount: 1, Queue: NewPriorityQueue(8)}, newMockRepository(), newMockPublisher())
+
+	processed := make(chan string, 2)
+	w.Executors().Register("email", ExecutorFunc(func(_ context.Context, task *Task) (*TaskResult, error) {
+		processed <- task.ID
+		return nil, nil
+	}))
+
+	require.NoError(t, w.SubmitTask(context.Background(), &Task{ID: "low", Type: "email", Priority: 1}))
+	require.NoError(t, w.SubmitTask(context.Background(), &Task{ID: "high", Type: "email", Priority: 9}))
+	w.Start()
+
+	first := <-processed
+	assert.Equal(t, "high", first)
+	<-processed
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	require.NoError(t, w.Shutdown(ctx))
+}
+
+func TestLIFOQueueOrdering(t *testing.T) {
+	q := NewLIFOQueue(8)
+	require.NoError(t, q.Enqueue(&Task{ID: "a"}))
+	require.NoError(t, q.Enqueue(&Task{ID: "b"}))
+	require.NoError(t, q.Enqueue(&Task{ID: "c"}))
+
+	var order []string
+	for i := 0; i < 3; i++ {
+		task, err := q.Dequeue(context.Background())
+		require.NoError(t, err)
+		order = append(order, task.ID)
+	}
+	assert.Equal(t, []string{"c", "b", "a"}, order, "the freshest task is dequeued first")
+}
+
+func TestNewOrderedQueueSelectsDiscipline(t *testing.T) {
+	fifo := newOrderedQueue(OrderFIFO, 4)
+	require.NoError(t, fifo.Enqueue(&Task{ID: "a"}))
+	require.NoError(t, fifo.Enqueue(&Task{ID: "b"}))
+	task, err := fifo.Dequeue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "a", task.ID)
+
+	lifo := newOrderedQueue(OrderLIFO, 4)
+	require.NoError(t, lifo.Enqueue(&Task{ID: "a"}))
+	require.NoError(t, lifo.Enqueue(&Task{ID: "b"}))
+	task, err = lifo.Dequeue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "b", task.ID)
+
+	priority := newOrderedQueue(OrderPriority, 4)
+	require.NoError(t, priority.Enqueue(&Task{ID: "low", Priority: 1}))
+	require.NoError(t, priority.Enqueue(&Task{ID: "high", Priority: 9}))
+	task, err = priority.Dequeue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "high", task.ID)
+}
+
+func TestWorkerOrderingConfig(t *testing.T) {
+	w := NewWorker(WorkerConfig{WorkerCount: 1, Ordering: OrderLIFO}, newMockRepository(), newMockPublisher())
+
+	processed := make(chan string, 3)
+	w.Executors().Register("email", ExecutorFunc(func(_ context.Context, task *Task) (*TaskResult, error) {
+		processed <- task.ID
+		return nil, nil
+	}))
+
+	require.NoError(t, w.SubmitTask(context.Background(), &Task{ID: "oldest", Type: "email"}))
+	require.NoError(t, w.SubmitTask(context.Background(), &Task{ID: "older", Type: "email"}))
+	require.NoError(t, w.SubmitTask(context.Background(), &Task{ID: "fresh", Type: "email"}))
+	w.Start()
+
+	assert.Equal(t, "fresh", <-processed, "LIFO serves the freshest backlog first")
+	<-processed
+	<-processed
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	require.NoError(t, w.Shutdown(ctx))
+}
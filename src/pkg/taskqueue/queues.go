@@ -0,0 +1,86 @@
+package taskqueue
+
+import "fmt"
+
+// DefaultQueueName is the queue tasks land on unless routed elsewhere.
+const DefaultQueueName = "default"
+
+// QueueMetadataKey records which named queue a task was submitted to.
+const QueueMetadataKey = "taskqueue.queue"
+
+// QueueConfig declares one additional named queue inside a Worker, so
+// simple tiering ("critical", "bulk") does not require running multiple
+// Worker instances.
+type QueueConfig struct {
+	// Buffer is the queue's capacity. Defaults to the worker's
+	// BufferSize.
+	Buffer int
+
+	// Workers is how many pool goroutines are dedicated to this queue.
+	// Defaults to 1.
+	Workers int
+
+	// Ordering selects the queue's dequeue discipline: FIFO (the
+	// default), LIFO or priority.
+	Ordering TaskOrdering
+}
+
+// namedQueue is one queue tier with its own buffer, worker allocation
+// and counters.
+type namedQueue struct {
+	name    string
+	queue   Queue
+	workers int
+	stats   WorkerStats
+}
+
+// SubmitOption adjusts how one task is submitted.
+type SubmitOption func(*submitOptions)
+
+// submitOptions collects the applied submit options.
+type submitOptions struct {
+	queue string
+}
+
+// WithQueue routes the task to the named queue instead of the default
+// one.
+func WithQueue(name string) SubmitOption {
+	return func(o *submitOptions) { o.queue = name }
+}
+
+// resolveQueue picks the task's target queue from the submit options,
+// falling back to the task's queue metadata and then the default queue.
+func (w *Worker) resolveQueue(task *Task, opts submitOptions) (*namedQueue, error) {
+	name := opts.queue
+	if name == "" {
+		name = task.Metadata[QueueMetadataKey]
+	}
+	if name == "" {
+		name = DefaultQueueName
+	}
+	nq, ok := w.named[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownQueue, name)
+	}
+	return nq, nil
+}
+
+// QueueSnapshot is the state of one named queue.
+type QueueSnapshot struct {
+	Depth   int           `json:"depth"`
+	Workers int           `json:"workers"`
+	Stats   StatsSnapshot `json:"stats"`
+}
+
+// Queues returns a snapshot of every named queue, including "default".
+func (w *Worker) Queues() map[string]QueueSnapshot {
+	snapshots := make(map[string]QueueSnapshot, len(w.named))
+	for name, nq := range w.named {
+		snapshots[name] = QueueSnapshot{
+			Depth:   nq.queue.Len(),
+			Workers: nq.workers,
+			Stats:   nq.stats.Snapshot(),
+		}
+	}
+	return snapshots
+}
@@ -0,0 +1,60 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithQueueRoutesToNamedQueue(t *testing.T) {
+	worker := NewWorker(WorkerConfig{
+		WorkerCount: 1,
+		Queues:      map[string]QueueConfig{"critical": {Buffer: 4, Workers: 2}},
+	}, newMockRepository(), nil)
+	processed := make(chan string, 2)
+	worker.Executors().Register("email", ExecutorFunc(func(_ context.Context, task *Task) (*TaskResult, error) {
+		processed <- task.Metadata[QueueMetadataKey]
+		return &TaskResult{}, nil
+	}))
+
+	ctx := context.Background()
+	require.NoError(t, worker.SubmitTask(ctx, NewTask("email", nil), WithQueue("critical")))
+	require.NoError(t, worker.SubmitTask(ctx, NewTask("email", nil)))
+	worker.Start()
+	require.NoError(t, worker.Shutdown(ctx))
+
+	queues := map[string]int{<-processed: 1}
+	queues[<-processed]++
+	assert.Equal(t, 1, queues["critical"])
+	assert.Equal(t, 1, queues[""])
+
+	snapshots := worker.Queues()
+	assert.EqualValues(t, 1, snapshots["critical"].Stats.Submitted)
+	assert.EqualValues(t, 1, snapshots["critical"].Stats.Completed)
+	assert.Equal(t, 2, snapshots["critical"].Workers)
+	assert.EqualValues(t, 1, snapshots[DefaultQueueName].Stats.Submitted)
+}
+
+func TestSubmitToUnknownQueueIsRejected(t *testing.T) {
+	worker := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), nil)
+	err := worker.SubmitTask(context.Background(), NewTask("email", nil), WithQueue("nope"))
+	assert.ErrorIs(t, err, ErrUnknownQueue)
+	assert.EqualValues(t, 1, worker.Stats().Rejected)
+}
+
+func TestQueueMetadataRoutesResubmissions(t *testing.T) {
+	worker := NewWorker(WorkerConfig{
+		WorkerCount: 1,
+		Queues:      map[string]QueueConfig{"bulk": {}},
+	}, newMockRepository(), nil)
+
+	task := NewTask("email", nil)
+	task.Metadata = map[string]string{QueueMetadataKey: "bulk"}
+	require.NoError(t, worker.SubmitTask(context.Background(), task))
+
+	assert.EqualValues(t, 1, worker.Queues()["bulk"].Stats.Submitted)
+	assert.Equal(t, 1, worker.Queues()["bulk"].Depth)
+	require.NoError(t, worker.Shutdown(context.Background()))
+}
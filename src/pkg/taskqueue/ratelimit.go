@@ -0,0 +1,131 @@
+package taskqueue
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter decides whether a submission may proceed. SubmitTask
+// consults the configured limiter after the governor's shed check and
+// rejects throttled submissions with ErrRateLimited, counting them in
+// the Throttled stat.
+type RateLimiter interface {
+	// Allow reports whether one submission of the task type may proceed
+	// now, consuming capacity when it does.
+	Allow(taskType string) bool
+}
+
+// TokenBucketLimiter admits bursts up to a bucket's capacity and refills
+// at a steady rate, the usual choice when short spikes are fine but the
+// sustained rate must stay bounded. The zero rate blocks everything;
+// per-type buckets override the default for hot task types.
+type TokenBucketLimiter struct {
+	mu      sync.Mutex
+	def     *bucket
+	byType  map[string]*bucket
+	rate    float64
+	burst   float64
+	byRates map[string][2]float64
+}
+
+// NewTokenBucketLimiter returns a limiter refilling rate tokens per
+// second with room for burst tokens.
+func NewTokenBucketLimiter(rate float64, burst int) *TokenBucketLimiter {
+	return &TokenBucketLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		byType:  make(map[string]*bucket),
+		byRates: make(map[string][2]float64),
+	}
+}
+
+// SetTypeLimit gives the task type its own bucket instead of the shared
+// default, so one chatty type cannot starve the rest.
+func (l *TokenBucketLimiter) SetTypeLimit(taskType string, rate float64, burst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.byRates[taskType] = [2]float64{rate, float64(burst)}
+	delete(l.byType, taskType)
+}
+
+// Allow implements RateLimiter.
+func (l *TokenBucketLimiter) Allow(taskType string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if rates, ok := l.byRates[taskType]; ok {
+		b, exists := l.byType[taskType]
+		if !exists {
+			b = newBucket(rates[0], rates[1])
+			l.byType[taskType] = b
+		}
+		return b.take()
+	}
+	if l.def == nil {
+		l.def = newBucket(l.rate, l.burst)
+	}
+	return l.def.take()
+}
+
+// bucket is one token bucket; callers hold the limiter lock.
+type bucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(rate, burst float64) *bucket {
+	return &bucket{rate: rate, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// take refills by elapsed time and consumes one token when available.
+func (b *bucket) take() bool {
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	b.last = now
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// LeakyBucketLimiter smooths submissions to a fixed drain rate: each
+// submission adds to the bucket's level, the level drains steadily, and
+// anything arriving at a full bucket is rejected. Unlike the token
+// bucket it never admits a burst faster than the drain rate plus the
+// configured slack.
+type LeakyBucketLimiter struct {
+	mu       sync.Mutex
+	rate     float64
+	capacity float64
+	level    float64
+	last     time.Time
+}
+
+// NewLeakyBucketLimiter returns a limiter draining rate submissions per
+// second from a bucket holding capacity of them.
+func NewLeakyBucketLimiter(rate float64, capacity int) *LeakyBucketLimiter {
+	return &LeakyBucketLimiter{rate: rate, capacity: float64(capacity), last: time.Now()}
+}
+
+// Allow implements RateLimiter. The leaky bucket is shared across task
+// types, matching its role of bounding total downstream pressure.
+func (l *LeakyBucketLimiter) Allow(string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	l.level -= now.Sub(l.last).Seconds() * l.rate
+	l.last = now
+	if l.level < 0 {
+		l.level = 0
+	}
+	if l.level+1 > l.capacity {
+		return false
+	}
+	l.level++
+	return true
+}
@@ -0,0 +1,56 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBucketLimiterBurstAndRefill(t *testing.T) {
+	limiter := NewTokenBucketLimiter(100, 2)
+
+	assert.True(t, limiter.Allow("email"))
+	assert.True(t, limiter.Allow("email"))
+	assert.False(t, limiter.Allow("email"), "burst exhausted")
+
+	time.Sleep(25 * time.Millisecond)
+	assert.True(t, limiter.Allow("email"), "refilled at 100/s")
+}
+
+func TestTokenBucketLimiterPerTypeLimits(t *testing.T) {
+	limiter := NewTokenBucketLimiter(0, 0)
+	limiter.SetTypeLimit("email", 100, 1)
+
+	assert.True(t, limiter.Allow("email"), "typed bucket has its own burst")
+	assert.False(t, limiter.Allow("email"))
+	assert.False(t, limiter.Allow("sms"), "default bucket admits nothing")
+}
+
+func TestLeakyBucketLimiterSmoothsBursts(t *testing.T) {
+	limiter := NewLeakyBucketLimiter(100, 2)
+
+	assert.True(t, limiter.Allow("email"))
+	assert.True(t, limiter.Allow("sms"), "capacity is shared across types")
+	assert.False(t, limiter.Allow("email"), "bucket full")
+
+	time.Sleep(25 * time.Millisecond)
+	assert.True(t, limiter.Allow("email"), "level drained")
+}
+
+func TestSubmitTaskReturnsErrRateLimited(t *testing.T) {
+	w := NewWorker(WorkerConfig{WorkerCount: 1, RateLimiter: NewTokenBucketLimiter(0, 1)}, newMockRepository(), nil)
+	w.Executors().Register("email", ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		return &TaskResult{}, nil
+	}))
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	require.NoError(t, w.SubmitTask(context.Background(), NewTask("email", nil)))
+	err := w.SubmitTask(context.Background(), NewTask("email", nil))
+	assert.ErrorIs(t, err, ErrRateLimited)
+
+	require.Eventually(t, func() bool { return w.Stats().Throttled == 1 }, 2*time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,84 @@
+package taskqueue
+
+import "strings"
+
+// RedactedPlaceholder replaces masked values in logs and messages.
+const RedactedPlaceholder = "[REDACTED]"
+
+// DefaultRedactedKeys are the data keys masked when a Redactor is built
+// without an explicit key list.
+var DefaultRedactedKeys = []string{"password", "token", "secret", "authorization", "api_key", "email"}
+
+// defaultRedactor masks DefaultRedactedKeys; it backs FormatTaskSummary
+// and workers configured without an explicit Redactor.
+var defaultRedactor = NewRedactor()
+
+// Redactor masks sensitive task data before it reaches logs, published
+// messages or diagnostic output. Key matching is case-insensitive and
+// applies to nested maps.
+type Redactor struct {
+	keys map[string]struct{}
+}
+
+// NewRedactor returns a redactor masking the given data keys, or
+// DefaultRedactedKeys when none are given.
+func NewRedactor(keys ...string) *Redactor {
+	if len(keys) == 0 {
+		keys = DefaultRedactedKeys
+	}
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[strings.ToLower(k)] = struct{}{}
+	}
+	return &Redactor{keys: set}
+}
+
+// Sensitive reports whether values under the key should be masked.
+func (r *Redactor) Sensitive(key string) bool {
+	_, ok := r.keys[strings.ToLower(key)]
+	return ok
+}
+
+// RedactData returns a copy of data with sensitive values replaced.
+// Nested map values are redacted recursively; the input is not modified.
+func (r *Redactor) RedactData(data map[string]any) map[string]any {
+	if data == nil {
+		return nil
+	}
+	out := make(map[string]any, len(data))
+	for key, value := range data {
+		switch {
+		case r.Sensitive(key):
+			out[key] = RedactedPlaceholder
+		default:
+			if nested, ok := value.(map[string]any); ok {
+				out[key] = r.RedactData(nested)
+			} else {
+				out[key] = value
+			}
+		}
+	}
+	return out
+}
+
+// RedactTask returns a shallow clone of the task with redacted Data and
+// Metadata, leaving the original untouched.
+func (r *Redactor) RedactTask(task *Task) *Task {
+	if task == nil {
+		return nil
+	}
+	clone := *task
+	clone.Data = r.RedactData(task.Data)
+	if task.Metadata != nil {
+		meta := make(map[string]string, len(task.Metadata))
+		for key, value := range task.Metadata {
+			if r.Sensitive(key) {
+				meta[key] = RedactedPlaceholder
+			} else {
+				meta[key] = value
+			}
+		}
+		clone.Metadata = meta
+	}
+	return &clone
+}
@@ -0,0 +1,71 @@
+package taskqueue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactorRedactData(t *testing.T) {
+	redactor := NewRedactor()
+
+	tests := []struct {
+		name string
+		data map[string]any
+		want map[string]any
+	}{
+		{
+			name: "nil data",
+			data: nil,
+			want: nil,
+		},
+		{
+			name: "masks configured keys case-insensitively",
+			data: map[string]any{"Password": "hunter2", "to": "ops@example.com"},
+			want: map[string]any{"Password": RedactedPlaceholder, "to": "ops@example.com"},
+		},
+		{
+			name: "recurses into nested maps",
+			data: map[string]any{"auth": map[string]any{"token": "abc", "user": "alice"}},
+			want: map[string]any{"auth": map[string]any{"token": RedactedPlaceholder, "user": "alice"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, redactor.RedactData(tt.data))
+		})
+	}
+}
+
+func TestRedactorDoesNotModifyInput(t *testing.T) {
+	redactor := NewRedactor("token")
+	data := map[string]any{"token": "abc"}
+
+	redactor.RedactData(data)
+
+	assert.Equal(t, "abc", data["token"])
+}
+
+func TestRedactTaskClonesDataAndMetadata(t *testing.T) {
+	redactor := NewRedactor()
+	task := NewTask("email", map[string]any{"password": "hunter2", "to": "x"})
+	task.Metadata = map[string]string{"authorization": "Bearer abc", "trace": "t-1"}
+
+	redacted := redactor.RedactTask(task)
+
+	assert.Equal(t, RedactedPlaceholder, redacted.Data["password"])
+	assert.Equal(t, RedactedPlaceholder, redacted.Metadata["authorization"])
+	assert.Equal(t, "t-1", redacted.Metadata["trace"])
+	assert.Equal(t, "hunter2", task.Data["password"])
+	assert.Equal(t, "Bearer abc", task.Metadata["authorization"])
+}
+
+func TestFormatTaskSummaryMasksSensitiveData(t *testing.T) {
+	task := NewTask("email", map[string]any{"password": "hunter2", "to": "ops@example.com"})
+
+	summary := FormatTaskSummary(task)
+
+	assert.Contains(t, summary, "password="+RedactedPlaceholder)
+	assert.Contains(t, summary, "to=ops@example.com")
+	assert.NotContains(t, summary, "hunter2")
+}
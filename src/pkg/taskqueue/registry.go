@@ -0,0 +1,147 @@
+package taskqueue
+
+import (
+	"fmt"
+	"iter"
+	"sync"
+	"time"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue/syncmap"
+)
+
+// Session tracks one producer session interacting with the queue.
+type Session struct {
+	ID        string
+	StartedAt time.Time
+	Values    map[string]string
+}
+
+// SessionStore is a concurrent map of session ID to session.
+type SessionStore struct {
+	m syncmap.Map[string, *Session]
+}
+
+// NewSessionStore returns an empty store.
+func NewSessionStore() *SessionStore {
+	return &SessionStore{}
+}
+
+// Put inserts or replaces a session.
+func (s *SessionStore) Put(session *Session) {
+	s.m.Store(session.ID, session)
+}
+
+// Get returns the session and whether it was present.
+func (s *SessionStore) Get(id string) (*Session, bool) {
+	return s.m.Load(id)
+}
+
+// Delete removes a session.
+func (s *SessionStore) Delete(id string) {
+	s.m.Delete(id)
+}
+
+// Range calls fn for each session until fn returns false.
+func (s *SessionStore) Range(fn func(id string, session *Session) bool) {
+	s.m.Range(fn)
+}
+
+// All returns an iterator over the sessions keyed by ID.
+func (s *SessionStore) All() iter.Seq2[string, *Session] {
+	return s.m.All()
+}
+
+// WorkerInfo describes one worker goroutine in the pool.
+type WorkerInfo struct {
+	ID            int
+	StartedAt     time.Time
+	CurrentTaskID string
+}
+
+// WorkerRegistry tracks the worker goroutines of a pool and the task each
+// is currently processing.
+type WorkerRegistry struct {
+	m syncmap.Map[int, *WorkerInfo]
+}
+
+// NewWorkerRegistry returns an empty registry.
+func NewWorkerRegistry() *WorkerRegistry {
+	return &WorkerRegistry{}
+}
+
+// Register adds or replaces a worker entry.
+func (r *WorkerRegistry) Register(info *WorkerInfo) {
+	r.m.Store(info.ID, info)
+}
+
+// Get returns the worker entry and whether it was present.
+func (r *WorkerRegistry) Get(id int) (*WorkerInfo, bool) {
+	return r.m.Load(id)
+}
+
+// Unregister removes a worker entry.
+func (r *WorkerRegistry) Unregister(id int) {
+	r.m.Delete(id)
+}
+
+// Range calls fn for each worker until fn returns false.
+func (r *WorkerRegistry) Range(fn func(id int, info *WorkerInfo) bool) {
+	r.m.Range(fn)
+}
+
+// All returns an iterator over the registered workers keyed by ID.
+func (r *WorkerRegistry) All() iter.Seq2[int, *WorkerInfo] {
+	return r.m.All()
+}
+
+// ExecutorRegistry maps task types to their executors. It is safe for
+// concurrent use.
+type ExecutorRegistry struct {
+	mu        sync.RWMutex
+	executors map[string]Executor
+}
+
+// NewExecutorRegistry returns an empty registry.
+func NewExecutorRegistry() *ExecutorRegistry {
+	return &ExecutorRegistry{executors: make(map[string]Executor)}
+}
+
+// Register binds an executor to a task type, replacing any previous binding.
+func (r *ExecutorRegistry) Register(taskType string, executor Executor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.executors[taskType] = executor
+}
+
+// Get returns the executor for a task type, or an error if none is registered.
+func (r *ExecutorRegistry) Get(taskType string) (Executor, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	executor, ok := r.executors[taskType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrNoExecutor, taskType)
+	}
+	return executor, nil
+}
+
+// All returns the registered executors in no particular order.
+func (r *ExecutorRegistry) All() []Executor {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	executors := make([]Executor, 0, len(r.executors))
+	for _, executor := range r.executors {
+		executors = append(executors, executor)
+	}
+	return executors
+}
+
+// Types returns the registered task types in no particular order.
+func (r *ExecutorRegistry) Types() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	types := make([]string, 0, len(r.executors))
+	for t := range r.executors {
+		types = append(types, t)
+	}
+	return types
+}
@@ -0,0 +1,120 @@
+package taskqueue
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// TypeReport aggregates the results of one task type over a time range.
+type TypeReport struct {
+	TaskType       string         `json:"task_type"`
+	Total          int            `json:"total"`
+	Succeeded      int            `json:"succeeded"`
+	Failed         int            `json:"failed"`
+	SuccessRate    float64        `json:"success_rate"`
+	MinDuration    time.Duration  `json:"min_duration"`
+	MeanDuration   time.Duration  `json:"mean_duration"`
+	MaxDuration    time.Duration  `json:"max_duration"`
+	FailureReasons map[string]int `json:"failure_reasons,omitempty"`
+}
+
+// Report is the aggregated view over the result store for a time range.
+type Report struct {
+	From  time.Time              `json:"from"`
+	To    time.Time              `json:"to"`
+	Types map[string]*TypeReport `json:"types"`
+}
+
+// Reporter computes aggregated reports from a result repository.
+type Reporter struct {
+	results ResultRepository
+}
+
+// NewReporter builds a reporter over the given result store.
+func NewReporter(results ResultRepository) *Reporter {
+	return &Reporter{results: results}
+}
+
+// Report aggregates all results completed in [from, to) into per-type
+// success rates, duration distributions and failure reason histograms.
+// A zero "to" means now.
+func (r *Reporter) Report(ctx context.Context, from, to time.Time) (*Report, error) {
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+	results, err := r.results.ListResults(ctx, ResultFilter{Since: from})
+	if err != nil {
+		return nil, fmt.Errorf("taskqueue: report: %w", err)
+	}
+
+	report := &Report{From: from, To: to, Types: make(map[string]*TypeReport)}
+	durations := make(map[string]time.Duration)
+	for _, result := range results {
+		if !result.CompletedAt.Before(to) {
+			continue
+		}
+		tr, ok := report.Types[result.TaskType]
+		if !ok {
+			tr = &TypeReport{TaskType: result.TaskType}
+			report.Types[result.TaskType] = tr
+		}
+		tr.Total++
+		if result.Success {
+			tr.Succeeded++
+		} else {
+			tr.Failed++
+			if tr.FailureReasons == nil {
+				tr.FailureReasons = make(map[string]int)
+			}
+			tr.FailureReasons[result.Error]++
+		}
+		durations[result.TaskType] += result.Duration
+		if tr.MinDuration == 0 || result.Duration < tr.MinDuration {
+			tr.MinDuration = result.Duration
+		}
+		if result.Duration > tr.MaxDuration {
+			tr.MaxDuration = result.Duration
+		}
+	}
+	for taskType, tr := range report.Types {
+		tr.SuccessRate = float64(tr.Succeeded) / float64(tr.Total)
+		tr.MeanDuration = durations[taskType] / time.Duration(tr.Total)
+	}
+	return report, nil
+}
+
+// WriteCSV exports the report as one CSV row per task type, sorted by
+// type name.
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"task_type", "total", "succeeded", "failed", "success_rate", "min_duration", "mean_duration", "max_duration"}); err != nil {
+		return fmt.Errorf("taskqueue: write report header: %w", err)
+	}
+	types := make([]string, 0, len(r.Types))
+	for taskType := range r.Types {
+		types = append(types, taskType)
+	}
+	sort.Strings(types)
+	for _, taskType := range types {
+		tr := r.Types[taskType]
+		row := []string{
+			tr.TaskType,
+			fmt.Sprintf("%d", tr.Total),
+			fmt.Sprintf("%d", tr.Succeeded),
+			fmt.Sprintf("%d", tr.Failed),
+			fmt.Sprintf("%.4f", tr.SuccessRate),
+			tr.MinDuration.String(),
+			tr.MeanDuration.String(),
+			tr.MaxDuration.String(),
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("taskqueue: write report row: %w", err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
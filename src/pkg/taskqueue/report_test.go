@@ -0,0 +1,61 @@
+package taskqueue
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReporterAggregatesByType(t *testing.T) {
+	results := newMockResultRepository()
+	now := time.Now().UTC()
+	ctx := context.Background()
+	seed := []*TaskResult{
+		{TaskID: "e1", TaskType: "email", Success: true, Duration: 100 * time.Millisecond, CompletedAt: now},
+		{TaskID: "e2", TaskType: "email", Success: true, Duration: 300 * time.Millisecond, CompletedAt: now},
+		{TaskID: "e3", TaskType: "email", Success: false, Error: "smtp timeout", Duration: 200 * time.Millisecond, CompletedAt: now},
+		{TaskID: "w1", TaskType: "webhook", Success: false, Error: "status 500", CompletedAt: now},
+		{TaskID: "old", TaskType: "email", Success: false, Error: "ancient", CompletedAt: now.Add(-48 * time.Hour)},
+	}
+	for _, result := range seed {
+		require.NoError(t, results.SaveResult(ctx, result))
+	}
+
+	report, err := NewReporter(results).Report(ctx, now.Add(-time.Hour), time.Time{})
+	require.NoError(t, err)
+
+	email := report.Types["email"]
+	require.NotNil(t, email)
+	assert.Equal(t, 3, email.Total)
+	assert.Equal(t, 2, email.Succeeded)
+	assert.InDelta(t, 2.0/3.0, email.SuccessRate, 0.001)
+	assert.Equal(t, 100*time.Millisecond, email.MinDuration)
+	assert.Equal(t, 200*time.Millisecond, email.MeanDuration)
+	assert.Equal(t, 300*time.Millisecond, email.MaxDuration)
+	assert.Equal(t, map[string]int{"smtp timeout": 1}, email.FailureReasons)
+
+	webhook := report.Types["webhook"]
+	require.NotNil(t, webhook)
+	assert.Equal(t, 1, webhook.Failed)
+	assert.Zero(t, webhook.SuccessRate)
+}
+
+func TestReportWriteCSV(t *testing.T) {
+	report := &Report{Types: map[string]*TypeReport{
+		"webhook": {TaskType: "webhook", Total: 1, Failed: 1},
+		"email":   {TaskType: "email", Total: 2, Succeeded: 2, SuccessRate: 1},
+	}}
+
+	var b strings.Builder
+	require.NoError(t, report.WriteCSV(&b))
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "task_type,total,succeeded,failed,success_rate,min_duration,mean_duration,max_duration", lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "email,2,2,0,1.0000"))
+	assert.True(t, strings.HasPrefix(lines[2], "webhook,1,0,1,0.0000"))
+}
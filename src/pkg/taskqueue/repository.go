@@ -0,0 +1,20 @@
+package taskqueue
+
+import "context"
+
+// TaskRepository persists tasks and their status transitions. The Worker
+// writes through it on submission and after every lifecycle change so the
+// durable store never diverges from the in-memory queue.
+type TaskRepository interface {
+	// Save persists a new task or overwrites an existing one by ID.
+	Save(ctx context.Context, task *Task) error
+
+	// GetByID returns the task with the given ID or ErrTaskNotFound.
+	GetByID(ctx context.Context, id string) (*Task, error)
+
+	// UpdateStatus records a status transition for the given task.
+	UpdateStatus(ctx context.Context, id string, status TaskStatus) error
+
+	// ListPending returns up to limit tasks still awaiting processing.
+	ListPending(ctx context.Context, limit int) ([]*Task, error)
+}
@@ -0,0 +1,112 @@
+package taskqueue
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue/metrics"
+)
+
+// repoReadRetries is how many times an idempotent read is retried by the
+// guarded repository before its error is returned.
+const repoReadRetries = 2
+
+// guardedRepository decorates a TaskRepository with per-call deadlines,
+// call duration metrics and retries for idempotent reads. NewWorker
+// applies it automatically when WorkerConfig.RepositoryTimeout is set.
+type guardedRepository struct {
+	next    TaskRepository
+	timeout time.Duration
+	metrics *metrics.Collector
+}
+
+// newGuardedRepository wraps next with the given per-call timeout.
+func newGuardedRepository(next TaskRepository, timeout time.Duration, collector *metrics.Collector) *guardedRepository {
+	return &guardedRepository{next: next, timeout: timeout, metrics: collector}
+}
+
+// Unwrap returns the decorated repository so optional capabilities
+// (TaskLister, StatusCounter) remain reachable via repositoryAs.
+func (r *guardedRepository) Unwrap() TaskRepository {
+	return r.next
+}
+
+// call bounds one repository call and records its duration.
+func (r *guardedRepository) call(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	start := time.Now()
+	err := fn(ctx)
+	if r.metrics != nil {
+		r.metrics.Observe("repo."+name, time.Since(start))
+	}
+	return err
+}
+
+// read is call with retries for idempotent operations. Not-found answers
+// and context errors are returned immediately.
+func (r *guardedRepository) read(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	var err error
+	for attempt := 0; attempt <= repoReadRetries; attempt++ {
+		if err = r.call(ctx, name, fn); err == nil {
+			return nil
+		}
+		if errors.Is(err, ErrTaskNotFound) || ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// Save implements TaskRepository.
+func (r *guardedRepository) Save(ctx context.Context, task *Task) error {
+	return r.call(ctx, "save", func(ctx context.Context) error {
+		return r.next.Save(ctx, task)
+	})
+}
+
+// GetByID implements TaskRepository.
+func (r *guardedRepository) GetByID(ctx context.Context, id string) (*Task, error) {
+	var task *Task
+	err := r.read(ctx, "get", func(ctx context.Context) error {
+		var err error
+		task, err = r.next.GetByID(ctx, id)
+		return err
+	})
+	return task, err
+}
+
+// UpdateStatus implements TaskRepository.
+func (r *guardedRepository) UpdateStatus(ctx context.Context, id string, status TaskStatus) error {
+	return r.call(ctx, "update_status", func(ctx context.Context) error {
+		return r.next.UpdateStatus(ctx, id, status)
+	})
+}
+
+// ListPending implements TaskRepository.
+func (r *guardedRepository) ListPending(ctx context.Context, limit int) ([]*Task, error) {
+	var tasks []*Task
+	err := r.read(ctx, "list_pending", func(ctx context.Context) error {
+		var err error
+		tasks, err = r.next.ListPending(ctx, limit)
+		return err
+	})
+	return tasks, err
+}
+
+// repositoryAs reports whether the repository — or anything it wraps —
+// provides the optional capability T, in the spirit of errors.As.
+func repositoryAs[T any](repo TaskRepository) (T, bool) {
+	for {
+		if capability, ok := repo.(T); ok {
+			return capability, true
+		}
+		wrapper, ok := repo.(interface{ Unwrap() TaskRepository })
+		if !ok {
+			var zero T
+			return zero, false
+		}
+		repo = wrapper.Unwrap()
+	}
+}
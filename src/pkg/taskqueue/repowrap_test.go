@@ -0,0 +1,97 @@
+package taskqueue
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue/metrics"
+)
+
+// faultyRepository fails a configurable number of reads before
+// succeeding.
+type faultyRepository struct {
+	*mockRepository
+	readFailures atomic.Int32
+	slow         time.Duration
+}
+
+func (r *faultyRepository) GetByID(ctx context.Context, id string) (*Task, error) {
+	if r.slow > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(r.slow):
+		}
+	}
+	if r.readFailures.Add(-1) >= 0 {
+		return nil, assert.AnError
+	}
+	return r.mockRepository.GetByID(ctx, id)
+}
+
+func TestGuardedRepositoryRetriesIdempotentReads(t *testing.T) {
+	repo := &faultyRepository{mockRepository: newMockRepository()}
+	repo.readFailures.Store(2)
+	guarded := newGuardedRepository(repo, time.Second, nil)
+
+	ctx := context.Background()
+	require.NoError(t, guarded.Save(ctx, &Task{ID: "t1", Type: "email", Status: TaskStatusPending}))
+
+	task, err := guarded.GetByID(ctx, "t1")
+	require.NoError(t, err)
+	assert.Equal(t, "t1", task.ID)
+}
+
+func TestGuardedRepositoryDoesNotRetryNotFound(t *testing.T) {
+	reads := atomic.Int32{}
+	repo := &countingRepository{mockRepository: newMockRepository(), reads: &reads}
+	guarded := newGuardedRepository(repo, time.Second, nil)
+
+	_, err := guarded.GetByID(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+	assert.EqualValues(t, 1, reads.Load())
+}
+
+// countingRepository counts GetByID calls.
+type countingRepository struct {
+	*mockRepository
+	reads *atomic.Int32
+}
+
+func (r *countingRepository) GetByID(ctx context.Context, id string) (*Task, error) {
+	r.reads.Add(1)
+	return r.mockRepository.GetByID(ctx, id)
+}
+
+func TestGuardedRepositoryEnforcesDeadline(t *testing.T) {
+	repo := &faultyRepository{mockRepository: newMockRepository(), slow: 200 * time.Millisecond}
+	guarded := newGuardedRepository(repo, 10*time.Millisecond, nil)
+
+	_, err := guarded.GetByID(context.Background(), "t1")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestGuardedRepositoryRecordsDurations(t *testing.T) {
+	collector := metrics.NewCollector()
+	guarded := newGuardedRepository(newMockRepository(), time.Second, collector)
+
+	require.NoError(t, guarded.Save(context.Background(), &Task{ID: "t1", Type: "email", Status: TaskStatusPending}))
+	assert.Contains(t, collector.Durations(), "repo.save")
+}
+
+func TestRepositoryTimeoutKeepsOptionalCapabilities(t *testing.T) {
+	worker := NewWorker(WorkerConfig{RepositoryTimeout: time.Second}, newMockRepository(), nil)
+
+	ctx := context.Background()
+	require.NoError(t, worker.SubmitTask(ctx, NewTask("email", nil)))
+	// Backfill requires TaskLister, reachable through the decorator.
+	submitted, err := worker.Backfill(ctx, TaskFilter{Status: TaskStatusPending}, nil, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, submitted)
+	require.NoError(t, worker.Shutdown(ctx))
+}
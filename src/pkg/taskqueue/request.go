@@ -0,0 +1,103 @@
+package taskqueue
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxDecodedTaskSize bounds the JSON documents DecodeTask accepts, since
+// payloads arrive from untrusted producers over the network API.
+const MaxDecodedTaskSize = 1 << 20
+
+// CreateTaskRequest is the external-facing payload used to create a task,
+// typically decoded from an API call before conversion with ToTask.
+type CreateTaskRequest struct {
+	Type       string         `json:"type"`
+	Data       map[string]any `json:"data,omitempty"`
+	Priority   int            `json:"priority,omitempty"`
+	MaxRetries int            `json:"max_retries,omitempty"`
+	Timeout    time.Duration  `json:"timeout,omitempty"`
+	Deadline   time.Time      `json:"deadline,omitzero"`
+}
+
+// Validate checks the request fields and returns all problems found.
+func (r *CreateTaskRequest) Validate() error {
+	var errs []error
+	if r.Type == "" {
+		errs = append(errs, errors.New("type is required"))
+	}
+	if r.MaxRetries < 0 {
+		errs = append(errs, fmt.Errorf("max_retries must not be negative, got %d", r.MaxRetries))
+	}
+	if r.Priority < 0 {
+		errs = append(errs, fmt.Errorf("priority must not be negative, got %d", r.Priority))
+	}
+	if r.Timeout < 0 {
+		errs = append(errs, fmt.Errorf("timeout must not be negative, got %v", r.Timeout))
+	}
+	if !r.Deadline.IsZero() && r.Deadline.Before(time.Now()) {
+		errs = append(errs, fmt.Errorf("deadline %s is in the past", r.Deadline.Format(time.RFC3339)))
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidTask, errors.Join(errs...))
+	}
+	return nil
+}
+
+// DecodeTask parses a task document received from an untrusted producer,
+// rejecting oversized input, unknown fields and values that violate the
+// task invariants. A missing status defaults to pending and a missing ID
+// is generated; every error wraps ErrInvalidTask.
+func DecodeTask(data []byte) (*Task, error) {
+	if len(data) > MaxDecodedTaskSize {
+		return nil, fmt.Errorf("%w: document exceeds %d bytes", ErrInvalidTask, MaxDecodedTaskSize)
+	}
+	var task Task
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&task); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTask, err)
+	}
+
+	var errs []error
+	if task.Type == "" {
+		errs = append(errs, errors.New("type is required"))
+	}
+	if task.Status == "" {
+		task.Status = TaskStatusPending
+	} else if !IsValidStatus(task.Status) {
+		errs = append(errs, fmt.Errorf("unknown status %q", task.Status))
+	}
+	if task.Priority < 0 {
+		errs = append(errs, fmt.Errorf("priority must not be negative, got %d", task.Priority))
+	}
+	if task.Retries < 0 {
+		errs = append(errs, fmt.Errorf("retries must not be negative, got %d", task.Retries))
+	}
+	if task.MaxRetries < 0 {
+		errs = append(errs, fmt.Errorf("max_retries must not be negative, got %d", task.MaxRetries))
+	}
+	if task.Timeout < 0 {
+		errs = append(errs, fmt.Errorf("timeout must not be negative, got %v", task.Timeout))
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTask, errors.Join(errs...))
+	}
+	if task.ID == "" {
+		task.ID = newTaskID()
+	}
+	return &task, nil
+}
+
+// ToTask converts a validated request into a pending Task.
+func (r *CreateTaskRequest) ToTask() *Task {
+	task := NewTask(r.Type, r.Data)
+	task.Priority = r.Priority
+	task.MaxRetries = r.MaxRetries
+	task.Timeout = r.Timeout
+	task.Deadline = r.Deadline
+	return task
+}
@@ -0,0 +1,16 @@
+package taskqueue
+
+import "time"
+
+// TaskResult captures the outcome of one task execution.
+type TaskResult struct {
+	TaskID        string        `json:"task_id"`
+	TaskType      string        `json:"task_type"`
+	Namespace     string        `json:"namespace,omitempty"`
+	CorrelationID string        `json:"correlation_id,omitempty"`
+	Success       bool          `json:"success"`
+	Output        string        `json:"output,omitempty"`
+	Error         string        `json:"error,omitempty"`
+	Duration      time.Duration `json:"duration"`
+	CompletedAt   time.Time     `json:"completed_at"`
+}
@@ -0,0 +1,111 @@
+package taskqueue
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"time"
+)
+
+// ResultRepository stores task results so they can be queried after the
+// fact instead of existing only as fire-and-forget messages.
+type ResultRepository interface {
+	// SaveResult persists a result, overwriting any previous result for
+	// the same task ID.
+	SaveResult(ctx context.Context, result *TaskResult) error
+
+	// GetResult returns the result for a task ID or ErrResultNotFound.
+	GetResult(ctx context.Context, taskID string) (*TaskResult, error)
+
+	// ListResults returns the results matching the filter, newest first.
+	ListResults(ctx context.Context, filter ResultFilter) ([]*TaskResult, error)
+}
+
+// ResultFilter selects results in ListResults. Zero fields match
+// everything.
+type ResultFilter struct {
+	// TaskType restricts results to one task type.
+	TaskType string
+
+	// Success restricts results to successes or failures.
+	Success *bool
+
+	// Since restricts results to completions at or after this time.
+	Since time.Time
+
+	// Limit caps the number of returned results. Zero means no limit.
+	Limit int
+}
+
+// Matches reports whether the result passes the filter, ignoring Limit.
+func (f ResultFilter) Matches(result *TaskResult) bool {
+	if f.TaskType != "" && result.TaskType != f.TaskType {
+		return false
+	}
+	if f.Success != nil && result.Success != *f.Success {
+		return false
+	}
+	if !f.Since.IsZero() && result.CompletedAt.Before(f.Since) {
+		return false
+	}
+	return true
+}
+
+// GetResult returns the stored result for a task ID. It requires
+// WorkerConfig.Results to be set.
+func (w *Worker) GetResult(ctx context.Context, taskID string) (*TaskResult, error) {
+	if w.config.Results == nil {
+		return nil, ErrNoResultRepository
+	}
+	return w.config.Results.GetResult(ctx, taskID)
+}
+
+// ListResults returns the stored results matching the filter. It requires
+// WorkerConfig.Results to be set.
+func (w *Worker) ListResults(ctx context.Context, filter ResultFilter) ([]*TaskResult, error) {
+	if w.config.Results == nil {
+		return nil, ErrNoResultRepository
+	}
+	return w.config.Results.ListResults(ctx, filter)
+}
+
+// Results returns an iterator over the stored results matching the
+// filter, in the repository's ListResults order.
+func (w *Worker) Results(ctx context.Context, filter ResultFilter) (iter.Seq[*TaskResult], error) {
+	results, err := w.ListResults(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return func(yield func(*TaskResult) bool) {
+		for _, result := range results {
+			if !yield(result) {
+				return
+			}
+		}
+	}, nil
+}
+
+// storeResult announces the result on the bus Results topic and persists
+// it when a result repository is configured.
+func (w *Worker) storeResult(ctx context.Context, result *TaskResult) {
+	w.bus.Results.Publish(result)
+	if w.config.Results == nil {
+		return
+	}
+	if err := w.config.Results.SaveResult(ctx, result); err != nil {
+		w.log(ctx).Error("taskqueue: save result failed", "task_id", result.TaskID, "error", err)
+	}
+}
+
+// failureResult builds the stored result for a failed execution.
+func failureResult(task *Task, err error) *TaskResult {
+	return &TaskResult{
+		TaskID:        task.ID,
+		TaskType:      task.Type,
+		Namespace:     task.Namespace(),
+		CorrelationID: task.CorrelationID(),
+		Success:       false,
+		Error:         fmt.Sprintf("%v", err),
+		CompletedAt:   time.Now().UTC(),
+	}
+}
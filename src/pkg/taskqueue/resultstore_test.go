@@ -0,0 +1,125 @@
+package taskqueue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockResultRepository is an in-memory ResultRepository for tests.
+type mockResultRepository struct {
+	mu      sync.Mutex
+	results map[string]*TaskResult
+}
+
+func newMockResultRepository() *mockResultRepository {
+	return &mockResultRepository{results: make(map[string]*TaskResult)}
+}
+
+func (r *mockResultRepository) SaveResult(_ context.Context, result *TaskResult) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results[result.TaskID] = result
+	return nil
+}
+
+func (r *mockResultRepository) GetResult(_ context.Context, taskID string) (*TaskResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result, ok := r.results[taskID]
+	if !ok {
+		return nil, ErrResultNotFound
+	}
+	return result, nil
+}
+
+func (r *mockResultRepository) ListResults(_ context.Context, filter ResultFilter) ([]*TaskResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var matches []*TaskResult
+	for _, result := range r.results {
+		if filter.Matches(result) {
+			matches = append(matches, result)
+		}
+	}
+	return matches, nil
+}
+
+func TestResultFilterMatches(t *testing.T) {
+	success := true
+	now := time.Now().UTC()
+	result := &TaskResult{TaskType: "email", Success: true, CompletedAt: now}
+
+	tests := []struct {
+		name   string
+		filter ResultFilter
+		want   bool
+	}{
+		{"empty filter", ResultFilter{}, true},
+		{"matching type", ResultFilter{TaskType: "email"}, true},
+		{"other type", ResultFilter{TaskType: "webhook"}, false},
+		{"matching success", ResultFilter{Success: &success}, true},
+		{"since before completion", ResultFilter{Since: now.Add(-time.Hour)}, true},
+		{"since after completion", ResultFilter{Since: now.Add(time.Hour)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.Matches(result))
+		})
+	}
+}
+
+func TestWorkerStoresResults(t *testing.T) {
+	results := newMockResultRepository()
+	worker := NewWorker(WorkerConfig{Results: results}, newMockRepository(), nil)
+	worker.Executors().Register("ok", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		return &TaskResult{Output: "done"}, nil
+	}))
+	worker.Executors().Register("boom", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		return nil, assert.AnError
+	}))
+
+	ctx := context.Background()
+	ok := NewTask("ok", nil)
+	boom := NewTask("boom", nil)
+	require.NoError(t, worker.SubmitTask(ctx, ok))
+	require.NoError(t, worker.SubmitTask(ctx, boom))
+	worker.Start()
+	require.NoError(t, worker.Shutdown(ctx))
+
+	stored, err := worker.GetResult(ctx, ok.ID)
+	require.NoError(t, err)
+	assert.True(t, stored.Success)
+	assert.Equal(t, "done", stored.Output)
+
+	failed, err := worker.GetResult(ctx, boom.ID)
+	require.NoError(t, err)
+	assert.False(t, failed.Success)
+	assert.Contains(t, failed.Error, assert.AnError.Error())
+
+	listed, err := worker.ListResults(ctx, ResultFilter{TaskType: "ok"})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+
+	var seen int
+	iter, err := worker.Results(ctx, ResultFilter{})
+	require.NoError(t, err)
+	for range iter {
+		seen++
+	}
+	assert.Equal(t, 2, seen)
+}
+
+func TestResultQueriesWithoutRepository(t *testing.T) {
+	worker := NewWorker(WorkerConfig{}, newMockRepository(), nil)
+
+	_, err := worker.GetResult(context.Background(), "t1")
+	assert.ErrorIs(t, err, ErrNoResultRepository)
+
+	_, err = worker.ListResults(context.Background(), ResultFilter{})
+	assert.ErrorIs(t, err, ErrNoResultRepository)
+}
@@ -0,0 +1,150 @@
+package taskqueue
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// Retry policy defaults applied by RetryPolicy.Delay.
+const (
+	// DefaultRetryBackoff is the base delay when Initial is unset.
+	DefaultRetryBackoff = time.Second
+
+	// DefaultRetryMaxBackoff caps computed delays when Max is unset.
+	DefaultRetryMaxBackoff = time.Minute
+)
+
+// BackoffStrategy selects how retry delays grow with the attempt count.
+type BackoffStrategy int
+
+const (
+	// BackoffExponential doubles the delay on every attempt.
+	BackoffExponential BackoffStrategy = iota
+
+	// BackoffLinear grows the delay by the base on every attempt.
+	BackoffLinear
+
+	// BackoffConstant keeps the delay at the base for every attempt.
+	BackoffConstant
+)
+
+// RetryPolicy makes the worker re-enqueue failed tasks up to their
+// MaxRetries budget instead of leaving them failed. Attempts update
+// Task.Retries, move the task back to pending and show up as Retried in
+// WorkerStats. Tasks without a MaxRetries budget are never retried.
+type RetryPolicy struct {
+	// Strategy selects the backoff curve. Defaults to exponential.
+	Strategy BackoffStrategy
+
+	// Initial is the base delay before the first retry. Defaults to
+	// DefaultRetryBackoff.
+	Initial time.Duration
+
+	// Max caps the computed delay. Defaults to DefaultRetryMaxBackoff.
+	Max time.Duration
+
+	// Jitter adds up to this fraction of the computed delay as random
+	// slack, spreading synchronized retries. Zero adds none.
+	Jitter float64
+}
+
+// Delay returns how long to wait before the given attempt (1-based).
+func (p RetryPolicy) Delay(attempt int) time.Duration {
+	base := p.Initial
+	if base <= 0 {
+		base = DefaultRetryBackoff
+	}
+	maxDelay := p.Max
+	if maxDelay <= 0 {
+		maxDelay = DefaultRetryMaxBackoff
+	}
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	var delay time.Duration
+	switch p.Strategy {
+	case BackoffLinear:
+		delay = base * time.Duration(attempt)
+	case BackoffConstant:
+		delay = base
+	default:
+		delay = base
+		for i := 1; i < attempt && delay < maxDelay; i++ {
+			delay *= 2
+		}
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	if p.Jitter > 0 {
+		delay += time.Duration(rand.Float64() * p.Jitter * float64(delay))
+	}
+	return delay
+}
+
+// maybeRetry re-enqueues the task after a backoff when the retry policy
+// and its remaining budget allow it, reporting whether the failure was
+// absorbed.
+func (w *Worker) maybeRetry(ctx context.Context, task *Task, err error) bool {
+	policy := w.config.Retry
+	if policy == nil || task.MaxRetries <= 0 || task.Retries >= task.MaxRetries {
+		return false
+	}
+	if w.retryBudget != nil && !w.retryBudget.allow(task.Type) {
+		w.log(ctx).Warn("taskqueue: retry budget exhausted, failing task",
+			"task_id", task.ID, "task_type", task.Type, "error", err)
+		return false
+	}
+
+	task.Retries++
+	delay := policy.Delay(task.Retries)
+	if hint, ok := RetryAfterHint(err); ok && hint > 0 {
+		delay = hint
+	}
+	if !w.scheduleRetry(task, delay) {
+		// Shutting down: the failure takes the normal path instead.
+		task.Retries--
+		return false
+	}
+	w.stats.retried.Add(1)
+	w.transition(ctx, task, TaskStatusPending)
+	w.log(ctx).Warn("taskqueue: task failed, retrying",
+		"task_id", task.ID, "attempt", task.Retries, "max_retries", task.MaxRetries,
+		"delay", delay, "error", err)
+	return true
+}
+
+// scheduleRetry re-enqueues the task after the delay, or immediately
+// once shutdown starts so the backlog still drains. It reports false
+// when the worker is already stopped.
+func (w *Worker) scheduleRetry(task *Task, delay time.Duration) bool {
+	w.mu.Lock()
+	if w.stopped {
+		w.mu.Unlock()
+		return false
+	}
+	w.drainWg.Add(1)
+	w.mu.Unlock()
+
+	go func() {
+		defer w.drainWg.Done()
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-w.stopCh:
+		}
+		nq, err := w.resolveQueue(task, submitOptions{})
+		if err == nil {
+			err = nq.queue.Enqueue(task)
+		}
+		if err != nil {
+			w.logger.Error("taskqueue: retry enqueue failed", "task_id", task.ID, "error", err)
+			w.transition(context.Background(), task, TaskStatusFailed)
+			w.stats.failed.Add(1)
+		}
+	}()
+	return true
+}
@@ -0,0 +1,142 @@
+package taskqueue
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		attempt int
+		want    time.Duration
+	}{
+		{"exponential first", RetryPolicy{Initial: time.Second}, 1, time.Second},
+		{"exponential third", RetryPolicy{Initial: time.Second}, 3, 4 * time.Second},
+		{"exponential capped", RetryPolicy{Initial: time.Second, Max: 3 * time.Second}, 10, 3 * time.Second},
+		{"linear", RetryPolicy{Strategy: BackoffLinear, Initial: time.Second}, 3, 3 * time.Second},
+		{"constant", RetryPolicy{Strategy: BackoffConstant, Initial: time.Second}, 5, time.Second},
+		{"defaults", RetryPolicy{}, 1, DefaultRetryBackoff},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.Delay(tt.attempt))
+		})
+	}
+}
+
+func TestRetryPolicyJitterStaysBounded(t *testing.T) {
+	policy := RetryPolicy{Strategy: BackoffConstant, Initial: time.Second, Jitter: 0.5}
+	for range 50 {
+		delay := policy.Delay(1)
+		assert.GreaterOrEqual(t, delay, time.Second)
+		assert.LessOrEqual(t, delay, 1500*time.Millisecond)
+	}
+}
+
+func TestWorkerRetriesFailedTasks(t *testing.T) {
+	repo := newMockRepository()
+	w := NewWorker(WorkerConfig{
+		WorkerCount: 1,
+		Retry:       &RetryPolicy{Strategy: BackoffConstant, Initial: time.Millisecond},
+	}, repo, nil)
+
+	var attempts atomic.Int32
+	done := make(chan struct{})
+	w.Executors().Register("email", ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		if attempts.Add(1) < 3 {
+			return nil, assert.AnError
+		}
+		close(done)
+		return &TaskResult{Output: "sent"}, nil
+	}))
+	w.Start()
+
+	task := &Task{Type: "email", MaxRetries: 5}
+	require.NoError(t, w.SubmitTask(context.Background(), task))
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("task was not retried to completion")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	require.NoError(t, w.Shutdown(ctx))
+
+	assert.EqualValues(t, 3, attempts.Load())
+	assert.Equal(t, 2, task.Retries)
+	stats := w.Stats()
+	assert.EqualValues(t, 2, stats.Retried)
+	assert.EqualValues(t, 1, stats.Completed)
+	assert.Zero(t, stats.Failed)
+}
+
+func TestWorkerStopsRetryingAtBudget(t *testing.T) {
+	w := NewWorker(WorkerConfig{
+		WorkerCount: 1,
+		Retry:       &RetryPolicy{Strategy: BackoffConstant, Initial: time.Millisecond},
+	}, newMockRepository(), nil)
+
+	var attempts atomic.Int32
+	failed := make(chan struct{})
+	w.Executors().Register("email", ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		if attempts.Add(1) == 3 {
+			defer close(failed)
+		}
+		return nil, assert.AnError
+	}))
+	w.Start()
+
+	task := &Task{Type: "email", MaxRetries: 2}
+	require.NoError(t, w.SubmitTask(context.Background(), task))
+
+	select {
+	case <-failed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("retries did not exhaust the budget")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	require.NoError(t, w.Shutdown(ctx))
+
+	assert.EqualValues(t, 3, attempts.Load())
+	assert.Equal(t, TaskStatusFailed, task.Status)
+	stats := w.Stats()
+	assert.EqualValues(t, 2, stats.Retried)
+	assert.EqualValues(t, 1, stats.Failed)
+}
+
+func TestWorkerWithoutRetryPolicyFailsImmediately(t *testing.T) {
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), nil)
+
+	var attempts atomic.Int32
+	failed := make(chan struct{})
+	w.Executors().Register("email", ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		if attempts.Add(1) == 1 {
+			defer close(failed)
+		}
+		return nil, assert.AnError
+	}))
+	w.Start()
+
+	require.NoError(t, w.SubmitTask(context.Background(), &Task{Type: "email", MaxRetries: 5}))
+	select {
+	case <-failed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("task never ran")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	require.NoError(t, w.Shutdown(ctx))
+
+	assert.EqualValues(t, 1, attempts.Load())
+	assert.Zero(t, w.Stats().Retried)
+}
@@ -0,0 +1,59 @@
+package taskqueue
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// retryAfterHinter is the interface an error implements to tell the
+// retry scheduler exactly how long to wait before the next attempt,
+// overriding the policy's computed backoff. Downstream client errors
+// that already know the server's Retry-After header implement it
+// directly; everyone else wraps with RetryAfter.
+type retryAfterHinter interface {
+	RetryAfter() time.Duration
+}
+
+// retryAfterError carries an explicit retry delay around another error.
+type retryAfterError struct {
+	err   error
+	delay time.Duration
+}
+
+// RetryAfter wraps err with an explicit delay before the next retry
+// attempt, typically lifted from a downstream 429 response's Retry-After
+// header. The worker's retry scheduler honors the hint instead of the
+// policy's backoff; errors.Is and errors.As see through the wrapper.
+// Wrapping nil returns nil.
+func RetryAfter(err error, delay time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return &retryAfterError{err: err, delay: delay}
+}
+
+// Error implements error.
+func (e *retryAfterError) Error() string {
+	return fmt.Sprintf("%v (retry after %v)", e.err, e.delay)
+}
+
+// Unwrap exposes the wrapped error to errors.Is and errors.As.
+func (e *retryAfterError) Unwrap() error {
+	return e.err
+}
+
+// RetryAfter implements retryAfterHinter.
+func (e *retryAfterError) RetryAfter() time.Duration {
+	return e.delay
+}
+
+// RetryAfterHint walks err's chain and returns the first explicit retry
+// delay it carries, reporting false when there is none.
+func RetryAfterHint(err error) (time.Duration, bool) {
+	var hinter retryAfterHinter
+	if errors.As(err, &hinter) {
+		return hinter.RetryAfter(), true
+	}
+	return 0, false
+}
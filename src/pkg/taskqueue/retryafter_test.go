@@ -0,0 +1,64 @@
+package taskqueue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hintedError implements the retry-after interface directly, as a
+// downstream client error type would.
+type hintedError struct{ delay time.Duration }
+
+func (e hintedError) Error() string             { return "throttled" }
+func (e hintedError) RetryAfter() time.Duration { return e.delay }
+
+func TestRetryAfterWrapsAndUnwraps(t *testing.T) {
+	assert.NoError(t, RetryAfter(nil, time.Second), "wrapping nil stays nil")
+
+	wrapped := RetryAfter(ErrInvalidTask, 3*time.Second)
+	assert.ErrorIs(t, wrapped, ErrInvalidTask, "errors.Is sees through the wrapper")
+	assert.Contains(t, wrapped.Error(), "retry after 3s")
+
+	hint, ok := RetryAfterHint(wrapped)
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, hint)
+
+	hint, ok = RetryAfterHint(hintedError{delay: time.Minute})
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, hint)
+
+	_, ok = RetryAfterHint(errors.New("plain"))
+	assert.False(t, ok)
+}
+
+func TestRetryHonoursRetryAfterHint(t *testing.T) {
+	// Without the hint, the policy would wait a minute before retrying.
+	w := NewWorker(WorkerConfig{
+		WorkerCount: 1,
+		Retry:       &RetryPolicy{Strategy: BackoffConstant, Initial: time.Minute},
+	}, newMockRepository(), nil)
+
+	attempts := make(chan int, 4)
+	w.Executors().Register("fetch", ExecutorFunc(func(_ context.Context, task *Task) (*TaskResult, error) {
+		attempts <- task.Retries
+		if task.Retries == 0 {
+			return nil, RetryAfter(errors.New("rate limited"), 20*time.Millisecond)
+		}
+		return &TaskResult{}, nil
+	}))
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	task := NewTask("fetch", nil)
+	task.MaxRetries = 3
+	require.NoError(t, w.SubmitTask(context.Background(), task))
+
+	require.Eventually(t, func() bool { return w.Stats().Completed == 1 }, 2*time.Second, 5*time.Millisecond)
+	assert.Equal(t, 0, <-attempts)
+	assert.Equal(t, 1, <-attempts, "the hinted delay replaces the minute-long backoff")
+}
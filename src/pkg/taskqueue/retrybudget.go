@@ -0,0 +1,167 @@
+package taskqueue
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Retry budget defaults applied by newRetryBudget.
+const (
+	// DefaultRetryBudgetRatio is the tolerated retries as a fraction of
+	// fresh submissions when Ratio is unset.
+	DefaultRetryBudgetRatio = 0.2
+
+	// DefaultRetryBudgetWindow is the rolling window when Window is
+	// unset.
+	DefaultRetryBudgetWindow = time.Minute
+
+	// DefaultRetryBudgetMinRetries is the grace floor when MinRetries is
+	// unset: this many retries per window are always allowed so sparse
+	// traffic is not starved of retries.
+	DefaultRetryBudgetMinRetries = 10
+)
+
+// RetryBudgetConfig caps retries relative to fresh traffic so a
+// downstream incident degrades into dead-letters instead of a retry
+// storm. With a ratio of 0.2, retries may be at most 20% of fresh
+// submissions over the rolling window; beyond that, failures skip the
+// retry policy and take the normal dead-letter path.
+type RetryBudgetConfig struct {
+	// Ratio is the tolerated retries as a fraction of fresh submissions
+	// over the window. Defaults to DefaultRetryBudgetRatio.
+	Ratio float64
+
+	// TypeRatios overrides Ratio per task type, so one noisy type can be
+	// budgeted tighter than the rest.
+	TypeRatios map[string]float64
+
+	// Window is the rolling window both sides of the ratio are counted
+	// over. Defaults to DefaultRetryBudgetWindow.
+	Window time.Duration
+
+	// MinRetries per window are always allowed regardless of the ratio,
+	// so sparse traffic still gets its retries. Defaults to
+	// DefaultRetryBudgetMinRetries.
+	MinRetries int
+
+	// OnExhausted, when set, receives one AlertEvent each time the
+	// budget transitions from available to exhausted.
+	OnExhausted AlertFunc
+}
+
+// retryBudget tracks fresh submissions and retries per task type over a
+// rolling window and answers whether another retry fits the budget.
+type retryBudget struct {
+	config RetryBudgetConfig
+
+	mu        sync.Mutex
+	fresh     map[string][]time.Time
+	retries   map[string][]time.Time
+	exhausted bool
+}
+
+// newRetryBudget builds a tracker, applying defaults for any zero
+// config fields.
+func newRetryBudget(config RetryBudgetConfig) *retryBudget {
+	if config.Ratio <= 0 {
+		config.Ratio = DefaultRetryBudgetRatio
+	}
+	if config.Window <= 0 {
+		config.Window = DefaultRetryBudgetWindow
+	}
+	if config.MinRetries <= 0 {
+		config.MinRetries = DefaultRetryBudgetMinRetries
+	}
+	return &retryBudget{
+		config:  config,
+		fresh:   make(map[string][]time.Time),
+		retries: make(map[string][]time.Time),
+	}
+}
+
+// recordFresh counts one accepted submission of the type.
+func (b *retryBudget) recordFresh(taskType string) {
+	now := time.Now().UTC()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.fresh[taskType] = append(b.fresh[taskType], now)
+	b.prune(b.fresh, taskType, now)
+}
+
+// allow reports whether one more retry of the type fits both the
+// per-type and the global budget, counting it when it does. A denial
+// transitioning the budget from available to exhausted fires
+// OnExhausted.
+func (b *retryBudget) allow(taskType string) bool {
+	now := time.Now().UTC()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for key := range b.fresh {
+		b.prune(b.fresh, key, now)
+	}
+	for key := range b.retries {
+		b.prune(b.retries, key, now)
+	}
+
+	ratio := b.config.Ratio
+	if override, ok := b.config.TypeRatios[taskType]; ok && override > 0 {
+		ratio = override
+	}
+	typeOK := b.fits(len(b.retries[taskType])+1, len(b.fresh[taskType]), ratio)
+	globalOK := b.fits(b.total(b.retries)+1, b.total(b.fresh), b.config.Ratio)
+	if typeOK && globalOK {
+		b.retries[taskType] = append(b.retries[taskType], now)
+		b.exhausted = false
+		return true
+	}
+	if !b.exhausted {
+		b.exhausted = true
+		if b.config.OnExhausted != nil {
+			b.config.OnExhausted(AlertEvent{
+				Name:      "retry_budget",
+				Message:   fmt.Sprintf("retry budget exhausted for type %q, converting retries to failures", taskType),
+				Value:     float64(b.total(b.retries)),
+				Threshold: b.config.Ratio,
+				At:        now,
+			})
+		}
+	}
+	return false
+}
+
+// fits checks one side of the budget: within the grace floor, or within
+// ratio of the fresh traffic.
+func (b *retryBudget) fits(retries, fresh int, ratio float64) bool {
+	if retries <= b.config.MinRetries {
+		return true
+	}
+	return float64(retries) <= ratio*float64(fresh)
+}
+
+// prune drops timestamps that fell out of the rolling window. Callers
+// hold b.mu.
+func (b *retryBudget) prune(byType map[string][]time.Time, key string, now time.Time) {
+	cutoff := now.Add(-b.config.Window)
+	stamps := byType[key]
+	i := 0
+	for ; i < len(stamps); i++ {
+		if !stamps[i].Before(cutoff) {
+			break
+		}
+	}
+	if i == len(stamps) {
+		delete(byType, key)
+		return
+	}
+	byType[key] = stamps[i:]
+}
+
+// total sums the windowed counts across types. Callers hold b.mu.
+func (b *retryBudget) total(byType map[string][]time.Time) int {
+	n := 0
+	for _, stamps := range byType {
+		n += len(stamps)
+	}
+	return n
+}
@@ -0,0 +1,98 @@
+package taskqueue
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryBudgetGraceFloorAlwaysAllows(t *testing.T) {
+	budget := newRetryBudget(RetryBudgetConfig{MinRetries: 3})
+	for i := 0; i < 3; i++ {
+		assert.True(t, budget.allow("email"), "retry %d is within the grace floor", i)
+	}
+	assert.False(t, budget.allow("email"), "no fresh traffic means nothing beyond the floor")
+}
+
+func TestRetryBudgetRatioAgainstFreshTraffic(t *testing.T) {
+	budget := newRetryBudget(RetryBudgetConfig{Ratio: 0.2, MinRetries: 1})
+	for i := 0; i < 20; i++ {
+		budget.recordFresh("email")
+	}
+	allowed := 0
+	for i := 0; i < 10; i++ {
+		if budget.allow("email") {
+			allowed++
+		}
+	}
+	assert.Equal(t, 4, allowed, "20%% of 20 fresh submissions")
+}
+
+func TestRetryBudgetPerTypeOverride(t *testing.T) {
+	budget := newRetryBudget(RetryBudgetConfig{
+		Ratio:      1,
+		TypeRatios: map[string]float64{"email": 0.1},
+		MinRetries: 1,
+	})
+	for i := 0; i < 10; i++ {
+		budget.recordFresh("email")
+		budget.recordFresh("report")
+	}
+	allowed := 0
+	for i := 0; i < 5; i++ {
+		if budget.allow("email") {
+			allowed++
+		}
+	}
+	assert.Equal(t, 1, allowed, "the override budgets email tighter than the global ratio")
+	assert.True(t, budget.allow("report"), "other types keep the global ratio")
+}
+
+func TestRetryBudgetFiresAlertOnExhaustion(t *testing.T) {
+	var fired atomic.Int32
+	var event AlertEvent
+	budget := newRetryBudget(RetryBudgetConfig{MinRetries: 1, OnExhausted: func(e AlertEvent) {
+		fired.Add(1)
+		event = e
+	}})
+
+	require.True(t, budget.allow("email"))
+	assert.False(t, budget.allow("email"))
+	assert.False(t, budget.allow("email"), "the alert is edge triggered")
+	assert.EqualValues(t, 1, fired.Load())
+	assert.Equal(t, "retry_budget", event.Name)
+	assert.Contains(t, event.Message, "email")
+}
+
+func TestRetryBudgetConvertsRetriesToDeadLetters(t *testing.T) {
+	letters := NewMemoryDeadLetterQueue()
+	w := NewWorker(WorkerConfig{
+		WorkerCount: 1,
+		Retry:       &RetryPolicy{Strategy: BackoffConstant, Initial: time.Millisecond},
+		RetryBudget: &RetryBudgetConfig{MinRetries: 1, Ratio: 0.01},
+		DeadLetters: letters,
+	}, newMockRepository(), nil)
+	w.Executors().Register("flaky", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		return nil, errors.New("downstream down")
+	}))
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	for i := 0; i < 3; i++ {
+		task := NewTask("flaky", nil)
+		task.MaxRetries = 5
+		require.NoError(t, w.SubmitTask(context.Background(), task))
+	}
+
+	require.Eventually(t, func() bool { return w.Stats().Failed == 3 }, 2*time.Second, 5*time.Millisecond)
+	parked, err := letters.List(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, parked, 3, "over-budget failures are parked instead of retried forever")
+	snapshot := w.Stats()
+	assert.LessOrEqual(t, snapshot.Retried, uint64(1), "only the grace-floor retry happened")
+}
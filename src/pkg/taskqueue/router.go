@@ -0,0 +1,46 @@
+package taskqueue
+
+import "sync"
+
+// ResultRouter overrides where results are published: task types routed
+// here fan out to their registered topics instead of the namespace
+// default from ResultTopic. It is safe for concurrent use, so routes can
+// be added while the worker runs.
+type ResultRouter struct {
+	mu     sync.RWMutex
+	routes map[string][]string
+}
+
+// NewResultRouter returns an empty router; unrouted types keep the
+// default topic.
+func NewResultRouter() *ResultRouter {
+	return &ResultRouter{routes: make(map[string][]string)}
+}
+
+// Route adds topics to the task type's fan-out set.
+func (r *ResultRouter) Route(taskType string, topics ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.routes[taskType] = append(r.routes[taskType], topics...)
+}
+
+// Topics returns the publication topics for the result: the type's
+// registered fan-out set, or the namespace default when the type is not
+// routed.
+func (r *ResultRouter) Topics(result *TaskResult) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if topics := r.routes[result.TaskType]; len(topics) > 0 {
+		return append([]string(nil), topics...)
+	}
+	return []string{ResultTopic(result.Namespace)}
+}
+
+// SubscribeResults returns a channel of task results for in-process
+// consumers and a cancel function that must be called when done. Like
+// SubscribeEvents, the channel is buffered and slow subscribers miss
+// results rather than blocking workers. It is shorthand for subscribing
+// to Bus().Results.
+func (w *Worker) SubscribeResults(buffer int) (<-chan *TaskResult, func()) {
+	return w.bus.Results.Subscribe(buffer, DropNewest)
+}
@@ -0,0 +1,74 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func publishedOn(p *mockPublisher, topic string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.messages[topic])
+}
+
+func TestResultRouterTopics(t *testing.T) {
+	router := NewResultRouter()
+	router.Route("email", "notifications.email", "audit.results")
+
+	routed := router.Topics(&TaskResult{TaskType: "email"})
+	assert.Equal(t, []string{"notifications.email", "audit.results"}, routed)
+
+	fallback := router.Topics(&TaskResult{TaskType: "sms", Namespace: "acme"})
+	assert.Equal(t, []string{ResultTopic("acme")}, fallback)
+}
+
+func TestPublishResultFansOutThroughRouter(t *testing.T) {
+	router := NewResultRouter()
+	router.Route("email", "notifications.email", "audit.results")
+	publisher := newMockPublisher()
+	w := NewWorker(WorkerConfig{WorkerCount: 1, Router: router}, newMockRepository(), publisher)
+	w.Executors().Register("email", ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		return &TaskResult{Output: "sent"}, nil
+	}))
+	w.Executors().Register("sms", ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		return &TaskResult{Output: "texted"}, nil
+	}))
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	require.NoError(t, w.SubmitTask(context.Background(), NewTask("email", nil)))
+	require.NoError(t, w.SubmitTask(context.Background(), NewTask("sms", nil)))
+
+	require.Eventually(t, func() bool {
+		return publishedOn(publisher, "notifications.email") == 1 &&
+			publishedOn(publisher, "audit.results") == 1 &&
+			publishedOn(publisher, ResultTopic("")) == 1
+	}, 2*time.Second, 5*time.Millisecond)
+}
+
+func TestSubscribeResultsDeliversInProcess(t *testing.T) {
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), nil)
+	w.Executors().Register("email", ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		return &TaskResult{Output: "sent"}, nil
+	}))
+	results, cancel := w.SubscribeResults(4)
+	defer cancel()
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	task := NewTask("email", nil)
+	require.NoError(t, w.SubmitTask(context.Background(), task))
+
+	select {
+	case result := <-results:
+		assert.Equal(t, task.ID, result.TaskID)
+		assert.Equal(t, "sent", result.Output)
+		assert.True(t, result.Success)
+	case <-time.After(2 * time.Second):
+		t.Fatal("result never delivered to subscriber")
+	}
+}
@@ -0,0 +1,129 @@
+package taskqueue
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// RunnerConfig tunes a Runner. Every field has a usable default.
+type RunnerConfig struct {
+	// Concurrency is how many tasks are processed at once. Defaults
+	// to 1.
+	Concurrency int
+
+	// Retries is how many times a failing task is retried before its
+	// error is reported.
+	Retries int
+
+	// StopOnError stops feeding further tasks after the first reported
+	// error.
+	StopOnError bool
+}
+
+// RunnerStats is a point-in-time copy of a Runner's counters.
+type RunnerStats struct {
+	Processed uint64 `json:"processed"`
+	Failed    uint64 `json:"failed"`
+	Retried   uint64 `json:"retried"`
+}
+
+// Runner processes ad-hoc task batches with bounded concurrency,
+// graceful stop on context cancellation, per-task retries and counters —
+// for callers who want batch processing without standing up a full
+// Worker.
+type Runner struct {
+	config RunnerConfig
+
+	processed atomic.Uint64
+	failed    atomic.Uint64
+	retried   atomic.Uint64
+}
+
+// NewRunner builds a Runner, applying defaults for zero config fields.
+func NewRunner(config RunnerConfig) *Runner {
+	if config.Concurrency < 1 {
+		config.Concurrency = 1
+	}
+	return &Runner{config: config}
+}
+
+// Run processes the tasks and returns the errors encountered, in no
+// particular order. Cancelling ctx stops feeding further tasks and
+// contributes the context error; tasks already being processed finish.
+func (r *Runner) Run(ctx context.Context, tasks []*Task, fn ProcessFunc) []error {
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		errs    []error
+		stopped atomic.Bool
+		taskCh  = make(chan *Task)
+	)
+	record := func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+		if r.config.StopOnError {
+			stopped.Store(true)
+		}
+	}
+
+	for i := 0; i < r.config.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range taskCh {
+				if stopped.Load() {
+					continue
+				}
+				if err := r.process(ctx, task, fn); err != nil {
+					record(err)
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, task := range tasks {
+		if stopped.Load() {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			record(ctx.Err())
+			break feed
+		case taskCh <- task:
+		}
+	}
+	close(taskCh)
+	wg.Wait()
+	return errs
+}
+
+// process runs one task with the configured retries.
+func (r *Runner) process(ctx context.Context, task *Task, fn ProcessFunc) error {
+	var err error
+	for attempt := 0; attempt <= r.config.Retries; attempt++ {
+		if attempt > 0 {
+			if ctx.Err() != nil {
+				break
+			}
+			r.retried.Add(1)
+		}
+		if err = fn(ctx, task); err == nil {
+			r.processed.Add(1)
+			return nil
+		}
+	}
+	r.failed.Add(1)
+	return err
+}
+
+// Stats returns the current counters.
+func (r *Runner) Stats() RunnerStats {
+	return RunnerStats{
+		Processed: r.processed.Load(),
+		Failed:    r.failed.Load(),
+		Retried:   r.retried.Load(),
+	}
+}
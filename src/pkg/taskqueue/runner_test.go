@@ -0,0 +1,109 @@
+package taskqueue
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func batchOf(n int) []*Task {
+	tasks := make([]*Task, n)
+	for i := range tasks {
+		tasks[i] = NewTask("email", nil)
+	}
+	return tasks
+}
+
+func TestRunnerBoundsConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	runner := NewRunner(RunnerConfig{Concurrency: 2})
+
+	errs := runner.Run(context.Background(), batchOf(8), func(context.Context, *Task) error {
+		mu.Lock()
+		inFlight++
+		if inFlight > peak {
+			peak = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return nil
+	})
+
+	assert.Empty(t, errs)
+	assert.LessOrEqual(t, peak, 2)
+	assert.EqualValues(t, 8, runner.Stats().Processed)
+}
+
+func TestRunnerRetriesBeforeFailing(t *testing.T) {
+	var calls atomic.Int32
+	runner := NewRunner(RunnerConfig{Retries: 2})
+
+	errs := runner.Run(context.Background(), batchOf(1), func(context.Context, *Task) error {
+		if calls.Add(1) < 3 {
+			return assert.AnError
+		}
+		return nil
+	})
+
+	assert.Empty(t, errs)
+	stats := runner.Stats()
+	assert.EqualValues(t, 1, stats.Processed)
+	assert.EqualValues(t, 2, stats.Retried)
+	assert.Zero(t, stats.Failed)
+
+	errs = runner.Run(context.Background(), batchOf(1), func(context.Context, *Task) error {
+		return assert.AnError
+	})
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], assert.AnError)
+	assert.EqualValues(t, 1, runner.Stats().Failed)
+}
+
+func TestRunnerStopsFeedingOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var processed atomic.Int32
+	runner := NewRunner(RunnerConfig{})
+
+	errs := runner.Run(ctx, batchOf(10), func(context.Context, *Task) error {
+		if processed.Add(1) == 2 {
+			cancel()
+		}
+		return nil
+	})
+
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], context.Canceled)
+	assert.Less(t, processed.Load(), int32(10))
+}
+
+func TestProcessWithGracefulShutdownStopsAtFirstError(t *testing.T) {
+	var processed atomic.Int32
+	err := ProcessWithGracefulShutdown(context.Background(), batchOf(5), func(context.Context, *Task) error {
+		if processed.Add(1) == 2 {
+			return assert.AnError
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.EqualValues(t, 2, processed.Load())
+}
+
+func TestProcessConcurrentCollectsErrors(t *testing.T) {
+	tasks := batchOf(4)
+	errs := ProcessConcurrent(context.Background(), tasks, 2, func(_ context.Context, task *Task) error {
+		if task == tasks[0] || task == tasks[3] {
+			return assert.AnError
+		}
+		return nil
+	})
+	assert.Len(t, errs, 2)
+}
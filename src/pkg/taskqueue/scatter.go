@@ -0,0 +1,147 @@
+package taskqueue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ScatterMetadataKey records which scatter-gather call a task belongs to.
+const ScatterMetadataKey = "taskqueue.scatter"
+
+// scatterState tracks one scatter call until the quorum fires or the
+// quorum becomes unreachable.
+type scatterState struct {
+	mu        sync.Mutex
+	quorum    int
+	total     int
+	settled   int
+	fired     bool
+	abandoned bool
+	results   []*TaskResult
+	done      chan []*TaskResult
+}
+
+// settleLocked counts one landed task. A nil result is a failure. Once
+// quorum successes are in, the results are delivered and the remaining
+// tasks are marked abandoned; once the quorum can no longer be met, the
+// channel is closed without a send. Callers hold the mutex.
+func (s *scatterState) settleLocked(result *TaskResult) {
+	s.settled++
+	if s.fired {
+		return
+	}
+	if result != nil {
+		s.results = append(s.results, result)
+	}
+	if len(s.results) >= s.quorum {
+		s.fired = true
+		s.abandoned = true
+		s.done <- s.results
+		close(s.done)
+		return
+	}
+	if len(s.results)+(s.total-s.settled) < s.quorum {
+		s.fired = true
+		s.abandoned = true
+		close(s.done)
+	}
+}
+
+// ScatterGather submits the tasks in parallel and blocks until a quorum
+// of them succeed, returning those first results. The straggling tasks
+// are cancelled instead of executed once the quorum is in, which makes
+// this suitable for redundant calls to interchangeable providers. A
+// positive timeout bounds the wait; when too many tasks fail for the
+// quorum to ever be met, ErrQuorumNotReached is returned.
+func (w *Worker) ScatterGather(ctx context.Context, tasks []*Task, quorum int, timeout time.Duration) ([]*TaskResult, error) {
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("%w: empty scatter", ErrInvalidTask)
+	}
+	if quorum < 1 || quorum > len(tasks) {
+		return nil, fmt.Errorf("%w: quorum %d outside [1, %d]", ErrInvalidTask, quorum, len(tasks))
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	id := newTaskID()
+	state := &scatterState{quorum: quorum, total: len(tasks), done: make(chan []*TaskResult, 1)}
+	w.scatters.Store(id, state)
+
+	for _, task := range tasks {
+		if task.Metadata == nil {
+			task.Metadata = make(map[string]string, 1)
+		}
+		task.Metadata[ScatterMetadataKey] = id
+		if err := w.SubmitTask(ctx, task); err != nil {
+			// A rejected submission counts against the quorum like any
+			// other failure; the remaining tasks may still reach it.
+			w.recordScatter(task, nil)
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		state.mu.Lock()
+		state.abandoned = true
+		state.mu.Unlock()
+		return nil, ctx.Err()
+	case results, ok := <-state.done:
+		if !ok {
+			state.mu.Lock()
+			succeeded := len(state.results)
+			state.mu.Unlock()
+			return nil, fmt.Errorf("%w: %d of %d", ErrQuorumNotReached, succeeded, quorum)
+		}
+		return results, nil
+	}
+}
+
+// recordScatter counts one landed task against its scatter call. A nil
+// result marks a failure. Once every task has settled — including the
+// stragglers abandoned after the quorum fired — the scatter is dropped
+// so the worker does not accumulate state for the process lifetime.
+func (w *Worker) recordScatter(task *Task, result *TaskResult) {
+	id := task.Metadata[ScatterMetadataKey]
+	if id == "" {
+		return
+	}
+	state, ok := w.scatters.Load(id)
+	if !ok {
+		return
+	}
+	state.mu.Lock()
+	state.settleLocked(result)
+	settled := state.settled >= state.total
+	state.mu.Unlock()
+	if settled {
+		w.scatters.Delete(id)
+	}
+}
+
+// abandonScattered cancels a straggler whose scatter call has already
+// been decided, reporting whether the task was dropped.
+func (w *Worker) abandonScattered(ctx context.Context, task *Task) bool {
+	id := task.Metadata[ScatterMetadataKey]
+	if id == "" {
+		return false
+	}
+	state, ok := w.scatters.Load(id)
+	if !ok {
+		return false
+	}
+	state.mu.Lock()
+	abandoned := state.abandoned
+	state.mu.Unlock()
+	if !abandoned {
+		return false
+	}
+	w.transition(ctx, task, TaskStatusCancelled)
+	w.cache.Delete(task.ID)
+	w.recordScatter(task, nil)
+	return true
+}
@@ -0,0 +1,85 @@
+package taskqueue
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScatterGatherReturnsOnQuorumAndCancelsStragglers(t *testing.T) {
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), nil)
+	var executed atomic.Int64
+	w.Executors().Register("probe", ExecutorFunc(func(_ context.Context, task *Task) (*TaskResult, error) {
+		executed.Add(1)
+		return &TaskResult{Output: task.Data["provider"].(string)}, nil
+	}))
+	w.Start()
+
+	tasks := make([]*Task, 4)
+	for n := range tasks {
+		tasks[n] = NewTask("probe", map[string]any{"provider": strconv.Itoa(n)})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	results, err := w.ScatterGather(ctx, tasks, 2, 0)
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+
+	require.NoError(t, w.Shutdown(context.Background()))
+	assert.Equal(t, int64(2), executed.Load(), "stragglers should be cancelled, not executed")
+	assert.Zero(t, w.scatters.Len(), "a settled scatter leaves no state behind")
+}
+
+func TestScatterGatherQuorumNotReached(t *testing.T) {
+	w := NewWorker(WorkerConfig{WorkerCount: 2}, newMockRepository(), nil)
+	w.Executors().Register("probe", ExecutorFunc(func(_ context.Context, task *Task) (*TaskResult, error) {
+		if task.Data["fail"] == true {
+			return nil, assert.AnError
+		}
+		return &TaskResult{Output: "ok"}, nil
+	}))
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	tasks := []*Task{
+		NewTask("probe", nil),
+		NewTask("probe", map[string]any{"fail": true}),
+		NewTask("probe", map[string]any{"fail": true}),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_, err := w.ScatterGather(ctx, tasks, 2, 0)
+	assert.ErrorIs(t, err, ErrQuorumNotReached)
+}
+
+func TestScatterGatherTimeout(t *testing.T) {
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, newMockRepository(), nil)
+	release := make(chan struct{})
+	w.Executors().Register("probe", ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		<-release
+		return &TaskResult{Output: "late"}, nil
+	}))
+	w.Start()
+
+	_, err := w.ScatterGather(context.Background(), []*Task{NewTask("probe", nil)}, 1, 20*time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	require.NoError(t, w.Shutdown(context.Background()))
+}
+
+func TestScatterGatherRejectsBadQuorum(t *testing.T) {
+	w := NewWorker(WorkerConfig{}, newMockRepository(), nil)
+	defer w.Shutdown(context.Background())
+
+	_, err := w.ScatterGather(context.Background(), nil, 1, 0)
+	assert.ErrorIs(t, err, ErrInvalidTask)
+
+	_, err = w.ScatterGather(context.Background(), []*Task{NewTask("probe", nil)}, 2, 0)
+	assert.ErrorIs(t, err, ErrInvalidTask)
+}
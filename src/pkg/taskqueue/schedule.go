@@ -0,0 +1,137 @@
+package taskqueue
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BusinessCalendar tells the scheduler which days work may run on, so
+// recurring tasks can skip weekends and holidays.
+type BusinessCalendar interface {
+	IsBusinessDay(t time.Time) bool
+}
+
+// WeekdayCalendar is a BusinessCalendar treating Monday through Friday
+// as business days, minus an optional holiday list.
+type WeekdayCalendar struct {
+	// Holidays lists non-business dates formatted as "2006-01-02" in the
+	// queried time's location.
+	Holidays map[string]struct{}
+}
+
+// IsBusinessDay implements BusinessCalendar.
+func (c WeekdayCalendar) IsBusinessDay(t time.Time) bool {
+	switch t.Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+	_, holiday := c.Holidays[t.Format("2006-01-02")]
+	return !holiday
+}
+
+// Schedule computes the next run time of a recurring task.
+type Schedule struct {
+	// Every is the base interval between runs.
+	Every time.Duration
+
+	// Calendar, when set, shifts runs landing on a non-business day
+	// forward to the next business day, keeping the time of day.
+	Calendar BusinessCalendar
+}
+
+// Next returns the first run time after the given instant.
+func (s Schedule) Next(after time.Time) time.Time {
+	next := after.Add(s.Every)
+	if s.Calendar == nil {
+		return next
+	}
+	for !s.Calendar.IsBusinessDay(next) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// RecurringScheduler submits a fresh copy of each registered task
+// template on its schedule. Entries must be added before Run.
+type RecurringScheduler struct {
+	worker *Worker
+
+	mu      sync.Mutex
+	entries []recurringEntry
+}
+
+type recurringEntry struct {
+	name     string
+	template *Task
+	schedule Schedule
+}
+
+// NewRecurringScheduler builds a scheduler submitting to the worker.
+func NewRecurringScheduler(worker *Worker) *RecurringScheduler {
+	return &RecurringScheduler{worker: worker}
+}
+
+// Add registers a recurring task under a name. The template is cloned on
+// every run with a fresh ID and timestamps.
+func (s *RecurringScheduler) Add(name string, template *Task, schedule Schedule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, recurringEntry{name: name, template: template, schedule: schedule})
+}
+
+// Run drives all entries until ctx is cancelled, blocking the caller.
+func (s *RecurringScheduler) Run(ctx context.Context) {
+	s.mu.Lock()
+	entries := make([]recurringEntry, len(s.entries))
+	copy(entries, s.entries)
+	s.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, entry := range entries {
+		wg.Add(1)
+		go func(entry recurringEntry) {
+			defer wg.Done()
+			s.runEntry(ctx, entry)
+		}(entry)
+	}
+	wg.Wait()
+}
+
+// runEntry fires one entry on its schedule until ctx ends.
+func (s *RecurringScheduler) runEntry(ctx context.Context, entry recurringEntry) {
+	timer := time.NewTimer(time.Until(entry.schedule.Next(time.Now())))
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			task := cloneTemplate(entry.template)
+			if err := s.worker.SubmitTask(ctx, task); err != nil {
+				s.worker.logger.Error("taskqueue: scheduled submission failed", "entry", entry.name, "error", err)
+			}
+			timer.Reset(time.Until(entry.schedule.Next(time.Now())))
+		}
+	}
+}
+
+// cloneTemplate copies a template into a submittable pending task.
+func cloneTemplate(template *Task) *Task {
+	task := NewTask(template.Type, nil)
+	if template.Data != nil {
+		task.Data = make(map[string]any, len(template.Data))
+		for key, value := range template.Data {
+			task.Data[key] = value
+		}
+	}
+	if template.Metadata != nil {
+		task.Metadata = make(map[string]string, len(template.Metadata))
+		for key, value := range template.Metadata {
+			task.Metadata[key] = value
+		}
+	}
+	task.Priority = template.Priority
+	task.MaxRetries = template.MaxRetries
+	return task
+}
@@ -0,0 +1,74 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue/testkit"
+)
+
+func TestWeekdayCalendar(t *testing.T) {
+	calendar := WeekdayCalendar{Holidays: map[string]struct{}{"2026-01-01": {}}}
+
+	tests := []struct {
+		name string
+		day  time.Time
+		want bool
+	}{
+		{"monday", time.Date(2025, 12, 29, 9, 0, 0, 0, time.UTC), true},
+		{"saturday", time.Date(2026, 1, 3, 9, 0, 0, 0, time.UTC), false},
+		{"sunday", time.Date(2026, 1, 4, 9, 0, 0, 0, time.UTC), false},
+		{"holiday", time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, calendar.IsBusinessDay(tt.day))
+		})
+	}
+}
+
+func TestScheduleNextShiftsToBusinessDay(t *testing.T) {
+	calendar := WeekdayCalendar{Holidays: map[string]struct{}{"2026-01-05": {}}}
+	// Friday 2026-01-02 at 09:00 + 24h lands on Saturday; the next
+	// business day after the weekend and the Monday holiday is Tuesday.
+	friday := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+
+	schedule := Schedule{Every: 24 * time.Hour, Calendar: calendar}
+	next := schedule.Next(friday)
+	assert.Equal(t, time.Date(2026, 1, 6, 9, 0, 0, 0, time.UTC), next)
+
+	// Without a calendar the interval applies verbatim.
+	assert.Equal(t, friday.Add(24*time.Hour), Schedule{Every: 24 * time.Hour}.Next(friday))
+}
+
+func TestRecurringSchedulerSubmitsClones(t *testing.T) {
+	testkit.LeakCheck(t)
+	worker := NewWorker(WorkerConfig{}, newMockRepository(), nil)
+
+	scheduler := NewRecurringScheduler(worker)
+	template := NewTask("report", map[string]any{"kind": "daily"})
+	template.Priority = 3
+	scheduler.Add("daily-report", template, Schedule{Every: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	scheduler.Run(ctx)
+
+	stats := worker.Stats()
+	require.NotZero(t, stats.Submitted)
+
+	var submitted *Task
+	for task := range worker.cache.Values() {
+		submitted = task
+		break
+	}
+	require.NotNil(t, submitted)
+	assert.NotEqual(t, template.ID, submitted.ID)
+	assert.Equal(t, "report", submitted.Type)
+	assert.Equal(t, 3, submitted.Priority)
+	assert.Equal(t, map[string]any{"kind": "daily"}, submitted.Data)
+}
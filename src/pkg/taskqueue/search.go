@@ -0,0 +1,193 @@
+package taskqueue
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// SearchIndexer indexes tasks for free-text lookup over their type,
+// status, metadata and payload keys, so operators can find tasks by
+// payload fragments without scanning the repository. Implementations
+// backed by external engines should treat Index as upsert-by-ID.
+type SearchIndexer interface {
+	// Index adds or replaces the task in the index.
+	Index(ctx context.Context, task *Task) error
+
+	// Search returns the IDs of up to limit tasks matching every term
+	// of the free-text query (unlimited when limit is zero).
+	Search(ctx context.Context, query string, limit int) ([]string, error)
+}
+
+// MemorySearchIndex is an in-process SearchIndexer backed by an inverted
+// index. It also implements RepositoryListener, so wiring it into
+// WorkerConfig.Search keeps it in sync with every repository write.
+type MemorySearchIndex struct {
+	mu     sync.RWMutex
+	tokens map[string]map[string]struct{}
+	docs   map[string][]string
+}
+
+// NewMemorySearchIndex builds an empty in-process search index.
+func NewMemorySearchIndex() *MemorySearchIndex {
+	return &MemorySearchIndex{
+		tokens: make(map[string]map[string]struct{}),
+		docs:   make(map[string][]string),
+	}
+}
+
+// Index implements SearchIndexer.
+func (i *MemorySearchIndex) Index(_ context.Context, task *Task) error {
+	terms := tokenize(task.Type, string(task.Status))
+	for key, value := range task.Metadata {
+		terms = append(terms, tokenize(key, value)...)
+	}
+	for key, value := range task.Data {
+		terms = append(terms, tokenize(key)...)
+		if s, ok := value.(string); ok {
+			terms = append(terms, tokenize(s)...)
+		}
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.remove(task.ID)
+	i.docs[task.ID] = terms
+	for _, term := range terms {
+		ids, ok := i.tokens[term]
+		if !ok {
+			ids = make(map[string]struct{})
+			i.tokens[term] = ids
+		}
+		ids[task.ID] = struct{}{}
+	}
+	return nil
+}
+
+// Search implements SearchIndexer. Every query term must match; results
+// are sorted by ID for stable output.
+func (i *MemorySearchIndex) Search(_ context.Context, query string, limit int) ([]string, error) {
+	terms := tokenize(query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	var matched map[string]struct{}
+	for _, term := range terms {
+		ids := i.tokens[term]
+		if len(ids) == 0 {
+			return nil, nil
+		}
+		if matched == nil {
+			matched = make(map[string]struct{}, len(ids))
+			for id := range ids {
+				matched[id] = struct{}{}
+			}
+			continue
+		}
+		for id := range matched {
+			if _, ok := ids[id]; !ok {
+				delete(matched, id)
+			}
+		}
+	}
+
+	results := make([]string, 0, len(matched))
+	for id := range matched {
+		results = append(results, id)
+	}
+	sort.Strings(results)
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// TaskSaved implements RepositoryListener.
+func (i *MemorySearchIndex) TaskSaved(ctx context.Context, task *Task) {
+	i.Index(ctx, task)
+}
+
+// TaskStatusChanged implements RepositoryListener. Only the status token
+// of the document is swapped; everything else is left as indexed.
+func (i *MemorySearchIndex) TaskStatusChanged(_ context.Context, id string, status TaskStatus) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	terms, ok := i.docs[id]
+	if !ok {
+		return
+	}
+	for n, term := range terms {
+		if !IsValidStatus(TaskStatus(term)) {
+			continue
+		}
+		if ids := i.tokens[term]; ids != nil {
+			delete(ids, id)
+			if len(ids) == 0 {
+				delete(i.tokens, term)
+			}
+		}
+		terms[n] = string(status)
+	}
+	i.docs[id] = terms
+	ids, ok := i.tokens[string(status)]
+	if !ok {
+		ids = make(map[string]struct{})
+		i.tokens[string(status)] = ids
+	}
+	ids[id] = struct{}{}
+}
+
+// remove drops the document from the index. Callers hold the lock.
+func (i *MemorySearchIndex) remove(id string) {
+	for _, term := range i.docs[id] {
+		if ids := i.tokens[term]; ids != nil {
+			delete(ids, id)
+			if len(ids) == 0 {
+				delete(i.tokens, term)
+			}
+		}
+	}
+	delete(i.docs, id)
+}
+
+// tokenize lowercases the inputs and splits them on anything that is not
+// a letter or digit.
+func tokenize(inputs ...string) []string {
+	var terms []string
+	for _, input := range inputs {
+		fields := strings.FieldsFunc(strings.ToLower(input), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		terms = append(terms, fields...)
+	}
+	return terms
+}
+
+// SearchTasks resolves a free-text query through the configured search
+// index and loads the matching tasks, skipping any that have since
+// disappeared. ErrNoSearchIndex is returned when WorkerConfig.Search is
+// unset.
+func (w *Worker) SearchTasks(ctx context.Context, query string, limit int) ([]*Task, error) {
+	if w.config.Search == nil {
+		return nil, ErrNoSearchIndex
+	}
+	ids, err := w.config.Search.Search(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("taskqueue: search %q: %w", query, err)
+	}
+	tasks := make([]*Task, 0, len(ids))
+	for _, id := range ids {
+		task, err := w.GetTask(ctx, id)
+		if err != nil {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
@@ -0,0 +1,83 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemorySearchIndexMatchesAllTerms(t *testing.T) {
+	index := NewMemorySearchIndex()
+	ctx := context.Background()
+
+	require.NoError(t, index.Index(ctx, &Task{
+		ID: "t1", Type: "email", Status: TaskStatusPending,
+		Data: map[string]any{"recipient": "ops@example.com"},
+	}))
+	require.NoError(t, index.Index(ctx, &Task{
+		ID: "t2", Type: "email", Status: TaskStatusPending,
+		Data: map[string]any{"recipient": "dev@example.com"},
+	}))
+
+	ids, err := index.Search(ctx, "email ops", 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"t1"}, ids)
+
+	ids, err = index.Search(ctx, "email", 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"t1", "t2"}, ids)
+
+	ids, err = index.Search(ctx, "email billing", 0)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
+func TestMemorySearchIndexLimit(t *testing.T) {
+	index := NewMemorySearchIndex()
+	ctx := context.Background()
+	require.NoError(t, index.Index(ctx, &Task{ID: "a", Type: "email", Status: TaskStatusPending}))
+	require.NoError(t, index.Index(ctx, &Task{ID: "b", Type: "email", Status: TaskStatusPending}))
+
+	ids, err := index.Search(ctx, "email", 1)
+	require.NoError(t, err)
+	assert.Len(t, ids, 1)
+}
+
+func TestMemorySearchIndexTracksStatusChanges(t *testing.T) {
+	index := NewMemorySearchIndex()
+	ctx := context.Background()
+	require.NoError(t, index.Index(ctx, &Task{ID: "t1", Type: "email", Status: TaskStatusPending}))
+
+	index.TaskStatusChanged(ctx, "t1", TaskStatusCompleted)
+
+	ids, err := index.Search(ctx, "pending", 0)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	ids, err = index.Search(ctx, "completed email", 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"t1"}, ids)
+}
+
+func TestSearchTasksThroughWorker(t *testing.T) {
+	worker := NewWorker(WorkerConfig{Search: NewMemorySearchIndex()}, newMockRepository(), nil)
+	defer worker.Shutdown(context.Background())
+
+	task := NewTask("report", map[string]any{"customer": "acme"})
+	require.NoError(t, worker.SubmitTask(context.Background(), task))
+
+	tasks, err := worker.SearchTasks(context.Background(), "acme", 10)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, task.ID, tasks[0].ID)
+}
+
+func TestSearchTasksWithoutIndex(t *testing.T) {
+	worker := NewWorker(WorkerConfig{}, newMockRepository(), nil)
+	defer worker.Shutdown(context.Background())
+
+	_, err := worker.SearchTasks(context.Background(), "anything", 10)
+	assert.ErrorIs(t, err, ErrNoSearchIndex)
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue"
+)
+
+// eventsHandler streams task lifecycle events as Server-Sent Events.
+// Optional "type" and "id" query parameters filter by task type and task
+// ID; the stream stays open until the client disconnects.
+func eventsHandler(worker *taskqueue.Worker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+		taskType := r.URL.Query().Get("type")
+		taskID := r.URL.Query().Get("id")
+
+		events, cancel := worker.SubscribeEvents(256)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				if taskType != "" && event.Type != taskType {
+					continue
+				}
+				if taskID != "" && event.TaskID != taskID {
+					continue
+				}
+				data, err := json.Marshal(event)
+				if err != nil {
+					continue
+				}
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Kind, data)
+				flusher.Flush()
+			}
+		}
+	}
+}
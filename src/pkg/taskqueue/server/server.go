@@ -0,0 +1,268 @@
+// Package server provides the embedded one-call mode of the task queue:
+// RunTaskServer wires an in-memory repository, a local publisher, the
+// worker pool, a small admin HTTP endpoint and signal handling together
+// for quick-start users.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue"
+	"github.com/kodflow/taskqueue/pkg/taskqueue/adapters"
+	"github.com/kodflow/taskqueue/pkg/taskqueue/auth"
+	"github.com/kodflow/taskqueue/pkg/taskqueue/tlsconfig"
+)
+
+// Options configures RunTaskServer. Every field has a usable default.
+type Options struct {
+	// Config tunes the worker pool.
+	Config taskqueue.WorkerConfig
+
+	// Repository defaults to an in-memory repository.
+	Repository taskqueue.TaskRepository
+
+	// Publisher defaults to a publisher that logs messages locally.
+	Publisher taskqueue.Publisher
+
+	// Executors maps task types to their executors.
+	Executors map[string]taskqueue.Executor
+
+	// PolicyFile, when set, loads per-task-type policies (timeouts,
+	// retries, concurrency, priority) from a JSON config file into
+	// Config.Policies.
+	PolicyFile string
+
+	// AdminAddr is the listen address of the admin HTTP endpoint.
+	// Defaults to ":8321"; set "-" to disable the endpoint.
+	AdminAddr string
+
+	// Authenticator, when set, protects the admin endpoint with bearer
+	// token authentication and audit logging.
+	Authenticator auth.Authenticator
+
+	// TLS, when set, serves the admin endpoint over (m)TLS with
+	// certificate reload on SIGHUP or file change.
+	TLS *tlsconfig.Config
+
+	// ShutdownTimeout bounds the graceful stop. Defaults to 30s.
+	ShutdownTimeout time.Duration
+}
+
+// RunTaskServer starts a fully wired task server and blocks until ctx is
+// cancelled or SIGINT/SIGTERM is received, then shuts down gracefully. The
+// started worker is passed to ready (if non-nil) so callers can submit
+// tasks.
+func RunTaskServer(ctx context.Context, opts Options, ready func(*taskqueue.Worker)) error {
+	if opts.Repository == nil {
+		opts.Repository = adapters.NewMemoryRepository()
+	}
+	if opts.Publisher == nil {
+		opts.Publisher = adapters.NewLogPublisher(opts.Config.Logger)
+	}
+	if opts.AdminAddr == "" {
+		opts.AdminAddr = ":8321"
+	}
+	if opts.ShutdownTimeout <= 0 {
+		opts.ShutdownTimeout = 30 * time.Second
+	}
+	if opts.PolicyFile != "" && opts.Config.Policies == nil {
+		f, err := os.Open(opts.PolicyFile)
+		if err != nil {
+			return fmt.Errorf("server: open policy file: %w", err)
+		}
+		policies, err := taskqueue.LoadTypePolicies(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("server: policy file %s: %w", opts.PolicyFile, err)
+		}
+		opts.Config.Policies = policies
+	}
+
+	worker := taskqueue.NewWorker(opts.Config, opts.Repository, opts.Publisher)
+	for taskType, executor := range opts.Executors {
+		worker.Executors().Register(taskType, executor)
+	}
+	worker.Start()
+	if ready != nil {
+		ready(worker)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	var admin *http.Server
+	adminErr := make(chan error, 1)
+	if opts.AdminAddr != "-" {
+		var handler http.Handler = adminMux(worker)
+		if opts.Authenticator != nil {
+			handler = auth.Middleware(opts.Authenticator, opts.Config.Logger)(handler)
+		}
+		admin = &http.Server{
+			Addr:              opts.AdminAddr,
+			Handler:           handler,
+			ReadHeaderTimeout: 5 * time.Second,
+		}
+		serve := func() error { return admin.ListenAndServe() }
+		if opts.TLS != nil {
+			tlsCfg, reloader, err := tlsconfig.Server(*opts.TLS)
+			if err != nil {
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), opts.ShutdownTimeout)
+				defer cancel()
+				worker.Shutdown(shutdownCtx)
+				return fmt.Errorf("server: admin TLS: %w", err)
+			}
+			admin.TLSConfig = tlsCfg
+			go reloader.Watch(ctx, opts.TLS.ReloadInterval, func(err error) {
+				if opts.Config.Logger != nil {
+					opts.Config.Logger.Error("server: certificate reload failed", "error", err)
+				}
+			})
+			serve = func() error { return admin.ListenAndServeTLS("", "") }
+		}
+		go func() {
+			if err := serve(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				adminErr <- err
+			}
+		}()
+	}
+
+	select {
+	case <-ctx.Done():
+	case err := <-adminErr:
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), opts.ShutdownTimeout)
+		defer cancel()
+		worker.Shutdown(shutdownCtx)
+		return fmt.Errorf("server: admin endpoint: %w", err)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), opts.ShutdownTimeout)
+	defer cancel()
+	if admin != nil {
+		if err := admin.Shutdown(shutdownCtx); err != nil {
+			worker.Shutdown(shutdownCtx)
+			return fmt.Errorf("server: stop admin endpoint: %w", err)
+		}
+	}
+	return worker.Shutdown(shutdownCtx)
+}
+
+// adminMux exposes the minimal operational endpoints of the embedded mode.
+func adminMux(worker *taskqueue.Worker) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		if health, ok := worker.PublisherHealth(); ok && !health.Connected {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, "degraded: publisher down, %d buffered", health.Buffered)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("GET /stats", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"stats":       worker.Stats(),
+			"queue_depth": worker.QueueDepth(),
+			"pool":        worker.PoolDecision(),
+			"top_errors":  worker.TopErrors(5),
+		})
+	})
+	mux.HandleFunc("GET /dashboard", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(worker.Dashboard())
+	})
+	mux.HandleFunc("GET /statemachine", func(w http.ResponseWriter, r *http.Request) {
+		table := taskqueue.TransitionTable()
+		switch format := r.URL.Query().Get("format"); format {
+		case "", "mermaid":
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			fmt.Fprint(w, taskqueue.MermaidDiagram(table))
+		case "dot":
+			w.Header().Set("Content-Type", "text/vnd.graphviz")
+			fmt.Fprint(w, taskqueue.DOTDiagram(table))
+		case "json":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(table)
+		default:
+			http.Error(w, "unknown format", http.StatusBadRequest)
+		}
+	})
+	mux.HandleFunc("GET /tasks", func(w http.ResponseWriter, r *http.Request) {
+		filter, err := taskqueue.ParseFilter(r.URL.Query().Get("filter"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		tasks, err := worker.ListTasks(r.Context(), filter)
+		if err != nil {
+			if errors.Is(err, taskqueue.ErrNoTaskLister) {
+				http.Error(w, err.Error(), http.StatusNotImplemented)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(tasks)
+	})
+	mux.HandleFunc("GET /search", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query().Get("q")
+		if query == "" {
+			http.Error(w, "missing query", http.StatusBadRequest)
+			return
+		}
+		limit := 50
+		if raw := r.URL.Query().Get("limit"); raw != "" {
+			parsed, err := strconv.Atoi(raw)
+			if err != nil || parsed <= 0 {
+				http.Error(w, "invalid limit", http.StatusBadRequest)
+				return
+			}
+			limit = parsed
+		}
+		tasks, err := worker.SearchTasks(r.Context(), query, limit)
+		if err != nil {
+			if errors.Is(err, taskqueue.ErrNoSearchIndex) {
+				http.Error(w, err.Error(), http.StatusNotImplemented)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(tasks)
+	})
+	mux.HandleFunc("GET /{$}", uiHandler)
+	mux.HandleFunc("GET /events", eventsHandler(worker))
+	mux.HandleFunc("POST /pause", func(w http.ResponseWriter, _ *http.Request) {
+		worker.Pause()
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("POST /resume", func(w http.ResponseWriter, _ *http.Request) {
+		worker.Resume()
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("POST /drain", func(w http.ResponseWriter, r *http.Request) {
+		rate := 10.0
+		if raw := r.URL.Query().Get("rate"); raw != "" {
+			parsed, err := strconv.ParseFloat(raw, 64)
+			if err != nil || parsed <= 0 {
+				http.Error(w, "invalid rate", http.StatusBadRequest)
+				return
+			}
+			rate = parsed
+		}
+		go worker.DrainAtRate(context.Background(), rate)
+		w.WriteHeader(http.StatusAccepted)
+	})
+	return mux
+}
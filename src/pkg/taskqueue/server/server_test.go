@@ -0,0 +1,183 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue"
+	"github.com/kodflow/taskqueue/pkg/taskqueue/adapters"
+)
+
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	l.Close()
+	return addr
+}
+
+func TestRunTaskServerEndToEnd(t *testing.T) {
+	addr := freeAddr(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	processed := make(chan string, 1)
+	done := make(chan error, 1)
+	workerCh := make(chan *taskqueue.Worker, 1)
+	go func() {
+		done <- RunTaskServer(ctx, Options{
+			AdminAddr: addr,
+			Executors: map[string]taskqueue.Executor{
+				"ping": taskqueue.ExecutorFunc(func(_ context.Context, task *taskqueue.Task) (*taskqueue.TaskResult, error) {
+					processed <- task.ID
+					return nil, nil
+				}),
+			},
+		}, func(w *taskqueue.Worker) { workerCh <- w })
+	}()
+
+	worker := <-workerCh
+	require.NoError(t, worker.SubmitTask(context.Background(), &taskqueue.Task{ID: "p1", Type: "ping"}))
+
+	select {
+	case id := <-processed:
+		assert.Equal(t, "p1", id)
+	case <-time.After(5 * time.Second):
+		t.Fatal("task not processed")
+	}
+
+	var resp *http.Response
+	var err error
+	for i := 0; i < 50; i++ {
+		resp, err = http.Get(fmt.Sprintf("http://%s/stats", addr))
+		if err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Contains(t, string(body), `"submitted":1`)
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not stop")
+	}
+}
+
+func TestRunTaskServerLoadsPolicyFile(t *testing.T) {
+	policyFile := filepath.Join(t.TempDir(), "policies.json")
+	require.NoError(t, os.WriteFile(policyFile, []byte(`{"email": {"max_retries": 3}}`), 0o600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	workerCh := make(chan *taskqueue.Worker, 1)
+	go func() {
+		done <- RunTaskServer(ctx, Options{
+			AdminAddr:  "-",
+			PolicyFile: policyFile,
+		}, func(w *taskqueue.Worker) { workerCh <- w })
+	}()
+
+	worker := <-workerCh
+	task := &taskqueue.Task{Type: "email"}
+	require.NoError(t, worker.SubmitTask(context.Background(), task))
+	assert.Equal(t, 3, task.MaxRetries)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestRunTaskServerRejectsBadPolicyFile(t *testing.T) {
+	err := RunTaskServer(context.Background(), Options{
+		AdminAddr:  "-",
+		PolicyFile: filepath.Join(t.TempDir(), "missing.json"),
+	}, nil)
+	assert.Error(t, err)
+}
+
+func TestAdminMuxServesUIAndControls(t *testing.T) {
+	worker := taskqueue.NewWorker(taskqueue.WorkerConfig{WorkerCount: 1}, adapters.NewMemoryRepository(), nil)
+	srv := httptest.NewServer(adminMux(worker))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), "taskqueue admin")
+
+	resp, err = http.Post(srv.URL+"/pause", "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.True(t, worker.Paused())
+
+	resp, err = http.Post(srv.URL+"/resume", "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.False(t, worker.Paused())
+
+	resp, err = http.Post(srv.URL+"/drain?rate=abc", "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestEventsEndpointStreamsSSE(t *testing.T) {
+	worker := taskqueue.NewWorker(taskqueue.WorkerConfig{WorkerCount: 1}, adapters.NewMemoryRepository(), nil)
+	worker.Executors().Register("ping", taskqueue.ExecutorFunc(func(context.Context, *taskqueue.Task) (*taskqueue.TaskResult, error) {
+		return nil, nil
+	}))
+	worker.Start()
+	defer worker.Shutdown(context.Background())
+
+	srv := httptest.NewServer(adminMux(worker))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/events?type=ping", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	require.NoError(t, worker.SubmitTask(context.Background(), &taskqueue.Task{ID: "e1", Type: "ping"}))
+
+	scanner := bufio.NewScanner(resp.Body)
+	var seen []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "event: ") {
+			seen = append(seen, strings.TrimPrefix(line, "event: "))
+		}
+		if len(seen) == 3 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"submitted", "started", "completed"}, seen)
+}
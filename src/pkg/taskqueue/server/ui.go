@@ -0,0 +1,23 @@
+package server
+
+import (
+	"embed"
+	"net/http"
+)
+
+// uiFS holds the embedded single-page admin UI so operators get a
+// browsable dashboard without deploying anything next to the binary.
+//
+//go:embed ui/index.html
+var uiFS embed.FS
+
+// uiHandler serves the embedded admin page.
+func uiHandler(w http.ResponseWriter, _ *http.Request) {
+	page, err := uiFS.ReadFile("ui/index.html")
+	if err != nil {
+		http.Error(w, "ui unavailable", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(page)
+}
@@ -0,0 +1,141 @@
+package taskqueue
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync/atomic"
+)
+
+// ShadowExecutor runs a candidate executor alongside the primary for a
+// sampled fraction of tasks and compares their results. Only the
+// primary's result is ever returned; the shadow runs on a copy of the
+// task so a candidate can be validated against production traffic
+// without affecting it.
+type ShadowExecutor struct {
+	primary Executor
+	shadow  Executor
+	percent atomic.Int32
+
+	sampled     atomic.Uint64
+	diverged    atomic.Uint64
+	shadowErrs  atomic.Uint64
+	primaryErrs atomic.Uint64
+}
+
+// ShadowSnapshot is a point-in-time copy of the divergence counters.
+type ShadowSnapshot struct {
+	Percent         int    `json:"percent"`
+	Sampled         uint64 `json:"sampled"`
+	Diverged        uint64 `json:"diverged"`
+	ShadowFailures  uint64 `json:"shadow_failures"`
+	PrimaryFailures uint64 `json:"primary_failures"`
+}
+
+// NewShadowExecutor dual-runs percent% of executions through the shadow
+// alongside the primary.
+func NewShadowExecutor(primary, shadow Executor, percent int) *ShadowExecutor {
+	e := &ShadowExecutor{primary: primary, shadow: shadow}
+	e.SetPercent(percent)
+	return e
+}
+
+// SetPercent changes the sampled share, clamped to [0, 100]. It is safe
+// to call while tasks are executing.
+func (e *ShadowExecutor) SetPercent(percent int) {
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 100 {
+		percent = 100
+	}
+	e.percent.Store(int32(percent))
+}
+
+// Execute implements Executor. The primary always runs and its result is
+// returned; for sampled tasks the shadow runs too and the outcomes are
+// compared.
+func (e *ShadowExecutor) Execute(ctx context.Context, task *Task) (*TaskResult, error) {
+	result, err := e.primary.Execute(ctx, task)
+	if err != nil {
+		e.primaryErrs.Add(1)
+	}
+	if rand.IntN(100) >= int(e.percent.Load()) {
+		return result, err
+	}
+
+	e.sampled.Add(1)
+	shadowResult, shadowErr := e.shadow.Execute(ctx, shadowCopy(task))
+	if shadowErr != nil {
+		e.shadowErrs.Add(1)
+	}
+	if diverges(result, err, shadowResult, shadowErr) {
+		e.diverged.Add(1)
+	}
+	return result, err
+}
+
+// Stats returns the current sampling share and divergence counters.
+func (e *ShadowExecutor) Stats() ShadowSnapshot {
+	return ShadowSnapshot{
+		Percent:         int(e.percent.Load()),
+		Sampled:         e.sampled.Load(),
+		Diverged:        e.diverged.Load(),
+		ShadowFailures:  e.shadowErrs.Load(),
+		PrimaryFailures: e.primaryErrs.Load(),
+	}
+}
+
+// shadowCopy clones the task so the shadow cannot mutate what the
+// primary produced.
+func shadowCopy(task *Task) *Task {
+	clone := *task
+	if task.Data != nil {
+		clone.Data = make(map[string]any, len(task.Data))
+		for key, value := range task.Data {
+			clone.Data[key] = value
+		}
+	}
+	if task.Metadata != nil {
+		clone.Metadata = make(map[string]string, len(task.Metadata))
+		for key, value := range task.Metadata {
+			clone.Metadata[key] = value
+		}
+	}
+	return &clone
+}
+
+// diverges reports whether the two outcomes differ: one side failing, or
+// both succeeding with different output.
+func diverges(result *TaskResult, err error, shadowResult *TaskResult, shadowErr error) bool {
+	if (err == nil) != (shadowErr == nil) {
+		return true
+	}
+	if err != nil {
+		return false
+	}
+	var output, shadowOutput string
+	if result != nil {
+		output = result.Output
+	}
+	if shadowResult != nil {
+		shadowOutput = shadowResult.Output
+	}
+	return output != shadowOutput
+}
+
+// RegisterShadow wraps the task type's registered executor in a
+// ShadowExecutor dual-running percent% of tasks through the candidate,
+// and returns the wrapper so callers can adjust the share and read its
+// divergence stats.
+func (r *ExecutorRegistry) RegisterShadow(taskType string, shadow Executor, percent int) (*ShadowExecutor, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	primary, ok := r.executors[taskType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrNoExecutor, taskType)
+	}
+	wrapper := NewShadowExecutor(primary, shadow, percent)
+	r.executors[taskType] = wrapper
+	return wrapper, nil
+}
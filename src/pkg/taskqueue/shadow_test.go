@@ -0,0 +1,90 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShadowExecutorComparesOutcomes(t *testing.T) {
+	primary := ExecutorFunc(func(_ context.Context, task *Task) (*TaskResult, error) {
+		return &TaskResult{Output: "v1"}, nil
+	})
+	shadow := ExecutorFunc(func(_ context.Context, task *Task) (*TaskResult, error) {
+		if task.Data["diverge"] == true {
+			return &TaskResult{Output: "v2"}, nil
+		}
+		return &TaskResult{Output: "v1"}, nil
+	})
+	executor := NewShadowExecutor(primary, shadow, 100)
+
+	ctx := context.Background()
+	result, err := executor.Execute(ctx, NewTask("email", nil))
+	require.NoError(t, err)
+	assert.Equal(t, "v1", result.Output)
+
+	_, err = executor.Execute(ctx, NewTask("email", map[string]any{"diverge": true}))
+	require.NoError(t, err)
+
+	stats := executor.Stats()
+	assert.EqualValues(t, 2, stats.Sampled)
+	assert.EqualValues(t, 1, stats.Diverged)
+	assert.Zero(t, stats.ShadowFailures)
+}
+
+func TestShadowExecutorCountsFailureDivergence(t *testing.T) {
+	primary := ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		return &TaskResult{}, nil
+	})
+	shadow := ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		return nil, assert.AnError
+	})
+	executor := NewShadowExecutor(primary, shadow, 100)
+
+	_, err := executor.Execute(context.Background(), NewTask("email", nil))
+	require.NoError(t, err)
+
+	stats := executor.Stats()
+	assert.EqualValues(t, 1, stats.Diverged)
+	assert.EqualValues(t, 1, stats.ShadowFailures)
+	assert.Zero(t, stats.PrimaryFailures)
+}
+
+func TestShadowRunsOnACopyOfTheTask(t *testing.T) {
+	primary := ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		return &TaskResult{}, nil
+	})
+	shadow := ExecutorFunc(func(_ context.Context, task *Task) (*TaskResult, error) {
+		task.Data["mutated"] = true
+		return &TaskResult{}, nil
+	})
+	executor := NewShadowExecutor(primary, shadow, 100)
+
+	task := NewTask("email", map[string]any{"k": "v"})
+	_, err := executor.Execute(context.Background(), task)
+	require.NoError(t, err)
+	assert.NotContains(t, task.Data, "mutated")
+}
+
+func TestRegisterShadowWrapsExistingExecutor(t *testing.T) {
+	registry := NewExecutorRegistry()
+	_, err := registry.RegisterShadow("email", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		return &TaskResult{}, nil
+	}), 50)
+	assert.ErrorIs(t, err, ErrNoExecutor)
+
+	registry.Register("email", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		return &TaskResult{}, nil
+	}))
+	wrapper, err := registry.RegisterShadow("email", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		return &TaskResult{}, nil
+	}), 50)
+	require.NoError(t, err)
+	assert.Equal(t, 50, wrapper.Stats().Percent)
+
+	executor, err := registry.Get("email")
+	require.NoError(t, err)
+	assert.Same(t, wrapper, executor)
+}
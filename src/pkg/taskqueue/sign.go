@@ -0,0 +1,162 @@
+package taskqueue
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// SignatureMetadataKey is the Metadata key carrying a task's signature.
+const SignatureMetadataKey = "taskqueue.signature"
+
+// Signer signs tasks at submission and verifies them before execution, so
+// tasks injected through a shared broker or repository by a party without
+// the signing key are rejected.
+type Signer interface {
+	// Sign computes the task signature and stores it in the metadata.
+	Sign(task *Task) error
+
+	// Verify checks the stored signature against the task fields and
+	// returns an error wrapping ErrInvalidSignature on mismatch.
+	Verify(task *Task) error
+}
+
+// signingPayload returns the canonical bytes covered by a signature: the
+// immutable task fields, excluding metadata so the signature itself and
+// tracing annotations do not invalidate it.
+func signingPayload(task *Task) ([]byte, error) {
+	payload, err := json.Marshal(struct {
+		ID         string         `json:"id"`
+		Type       string         `json:"type"`
+		Data       map[string]any `json:"data,omitempty"`
+		Priority   int            `json:"priority,omitempty"`
+		MaxRetries int            `json:"max_retries,omitempty"`
+		CreatedAt  time.Time      `json:"created_at"`
+	}{
+		ID:         task.ID,
+		Type:       task.Type,
+		Data:       task.Data,
+		Priority:   task.Priority,
+		MaxRetries: task.MaxRetries,
+		CreatedAt:  task.CreatedAt.UTC(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("taskqueue: signing payload for task %s: %w", task.ID, err)
+	}
+	return payload, nil
+}
+
+// storeSignature writes the encoded signature into the task metadata.
+func storeSignature(task *Task, signature []byte) {
+	if task.Metadata == nil {
+		task.Metadata = make(map[string]string, 1)
+	}
+	task.Metadata[SignatureMetadataKey] = hex.EncodeToString(signature)
+}
+
+// storedSignature reads the signature back from the task metadata.
+func storedSignature(task *Task) ([]byte, error) {
+	encoded, ok := task.Metadata[SignatureMetadataKey]
+	if !ok {
+		return nil, fmt.Errorf("%w: task %s is unsigned", ErrInvalidSignature, task.ID)
+	}
+	signature, err := hex.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: task %s: malformed signature", ErrInvalidSignature, task.ID)
+	}
+	return signature, nil
+}
+
+// HMACSigner signs tasks with HMAC-SHA256 over a shared key.
+type HMACSigner struct {
+	key []byte
+}
+
+// NewHMACSigner returns a signer using the shared key.
+func NewHMACSigner(key []byte) *HMACSigner {
+	return &HMACSigner{key: key}
+}
+
+// Sign implements Signer.
+func (s *HMACSigner) Sign(task *Task) error {
+	payload, err := signingPayload(task)
+	if err != nil {
+		return err
+	}
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(payload)
+	storeSignature(task, mac.Sum(nil))
+	return nil
+}
+
+// Verify implements Signer.
+func (s *HMACSigner) Verify(task *Task) error {
+	signature, err := storedSignature(task)
+	if err != nil {
+		return err
+	}
+	payload, err := signingPayload(task)
+	if err != nil {
+		return err
+	}
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(payload)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return fmt.Errorf("%w: task %s", ErrInvalidSignature, task.ID)
+	}
+	return nil
+}
+
+// Ed25519Signer signs tasks with an Ed25519 key pair. A verify-only
+// instance (public key only) can be placed on consumers that must not
+// hold the private key.
+type Ed25519Signer struct {
+	private ed25519.PrivateKey
+	public  ed25519.PublicKey
+}
+
+// NewEd25519Signer returns a signer that can both sign and verify.
+func NewEd25519Signer(private ed25519.PrivateKey) *Ed25519Signer {
+	return &Ed25519Signer{
+		private: private,
+		public:  private.Public().(ed25519.PublicKey),
+	}
+}
+
+// NewEd25519Verifier returns a verify-only signer; Sign fails.
+func NewEd25519Verifier(public ed25519.PublicKey) *Ed25519Signer {
+	return &Ed25519Signer{public: public}
+}
+
+// Sign implements Signer.
+func (s *Ed25519Signer) Sign(task *Task) error {
+	if s.private == nil {
+		return fmt.Errorf("taskqueue: sign task %s: no private key", task.ID)
+	}
+	payload, err := signingPayload(task)
+	if err != nil {
+		return err
+	}
+	storeSignature(task, ed25519.Sign(s.private, payload))
+	return nil
+}
+
+// Verify implements Signer.
+func (s *Ed25519Signer) Verify(task *Task) error {
+	signature, err := storedSignature(task)
+	if err != nil {
+		return err
+	}
+	payload, err := signingPayload(task)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(s.public, payload, signature) {
+		return fmt.Errorf("%w: task %s", ErrInvalidSignature, task.ID)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package taskqueue
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHMACSignerRoundTrip(t *testing.T) {
+	signer := NewHMACSigner([]byte("shared-key"))
+	task := NewTask("email", map[string]any{"to": "ops@example.com"})
+
+	require.NoError(t, signer.Sign(task))
+	assert.NotEmpty(t, task.Metadata[SignatureMetadataKey])
+	assert.NoError(t, signer.Verify(task))
+}
+
+func TestHMACSignerRejectsTampering(t *testing.T) {
+	signer := NewHMACSigner([]byte("shared-key"))
+
+	tests := []struct {
+		name   string
+		mutate func(*Task)
+	}{
+		{"data changed", func(task *Task) { task.Data["to"] = "attacker@example.com" }},
+		{"type changed", func(task *Task) { task.Type = "shell" }},
+		{"signature stripped", func(task *Task) { delete(task.Metadata, SignatureMetadataKey) }},
+		{"signature malformed", func(task *Task) { task.Metadata[SignatureMetadataKey] = "zz" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := NewTask("email", map[string]any{"to": "ops@example.com"})
+			require.NoError(t, signer.Sign(task))
+			tt.mutate(task)
+			assert.ErrorIs(t, signer.Verify(task), ErrInvalidSignature)
+		})
+	}
+}
+
+func TestHMACSignerRejectsWrongKey(t *testing.T) {
+	task := NewTask("email", nil)
+	require.NoError(t, NewHMACSigner([]byte("key-a")).Sign(task))
+
+	assert.ErrorIs(t, NewHMACSigner([]byte("key-b")).Verify(task), ErrInvalidSignature)
+}
+
+func TestEd25519Signer(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer := NewEd25519Signer(private)
+	task := NewTask("email", map[string]any{"to": "ops@example.com"})
+	require.NoError(t, signer.Sign(task))
+
+	verifier := NewEd25519Verifier(public)
+	assert.NoError(t, verifier.Verify(task))
+
+	task.Data["to"] = "attacker@example.com"
+	assert.ErrorIs(t, verifier.Verify(task), ErrInvalidSignature)
+
+	assert.Error(t, verifier.Sign(task))
+}
+
+func TestWorkerRejectsUnsignedTasks(t *testing.T) {
+	repo := newMockRepository()
+	signer := NewHMACSigner([]byte("shared-key"))
+	worker := NewWorker(WorkerConfig{Signer: signer}, repo, nil)
+
+	task := NewTask("email", nil)
+	require.NoError(t, worker.SubmitTask(context.Background(), task))
+	assert.NotEmpty(t, task.Metadata[SignatureMetadataKey])
+	assert.NoError(t, signer.Verify(task))
+}
@@ -0,0 +1,187 @@
+package taskqueue
+
+import (
+	"sync"
+	"time"
+)
+
+// SLO defines a service level objective over task outcomes, e.g. "99% of
+// email tasks complete successfully within 30s over the last hour".
+type SLO struct {
+	// Name identifies the objective in status output and events.
+	Name string
+
+	// TaskType restricts the objective to one task type; empty matches
+	// every task.
+	TaskType string
+
+	// Target is the required good fraction, e.g. 0.99.
+	Target float64
+
+	// Within, when set, additionally requires the task to complete
+	// within this duration to count as good.
+	Within time.Duration
+
+	// Window is the rolling evaluation window. Defaults to one hour.
+	Window time.Duration
+}
+
+// SLOStatus is the current state of one objective.
+type SLOStatus struct {
+	Name           string  `json:"name"`
+	Total          int     `json:"total"`
+	Good           int     `json:"good"`
+	Compliance     float64 `json:"compliance"`
+	BudgetConsumed float64 `json:"budget_consumed"`
+	BurnRate       float64 `json:"burn_rate"`
+}
+
+// SLOEvent is emitted when an objective's error budget consumption
+// crosses a threshold.
+type SLOEvent struct {
+	Name           string    `json:"name"`
+	Threshold      float64   `json:"threshold"`
+	BudgetConsumed float64   `json:"budget_consumed"`
+	BurnRate       float64   `json:"burn_rate"`
+	At             time.Time `json:"at"`
+}
+
+// SLOFunc receives budget threshold crossings.
+type SLOFunc func(SLOEvent)
+
+// sloOutcome is one recorded task outcome within the window.
+type sloOutcome struct {
+	at   time.Time
+	good bool
+}
+
+// sloState tracks one objective's rolling window.
+type sloState struct {
+	slo       SLO
+	outcomes  []sloOutcome
+	lastFired float64
+}
+
+// SLOTracker evaluates objectives continuously as outcomes are recorded,
+// firing callbacks when error budget consumption crosses thresholds.
+type SLOTracker struct {
+	mu         sync.Mutex
+	states     []*sloState
+	thresholds []float64
+	callbacks  []SLOFunc
+}
+
+// DefaultBudgetThresholds are the budget consumption levels that emit
+// events: half spent, nearly spent, fully spent.
+var DefaultBudgetThresholds = []float64{0.5, 0.9, 1.0}
+
+// NewSLOTracker builds a tracker for the given objectives; callbacks
+// fire when any objective crosses a DefaultBudgetThresholds level.
+func NewSLOTracker(slos []SLO, callbacks ...SLOFunc) *SLOTracker {
+	states := make([]*sloState, 0, len(slos))
+	for _, slo := range slos {
+		if slo.Window <= 0 {
+			slo.Window = time.Hour
+		}
+		states = append(states, &sloState{slo: slo})
+	}
+	return &SLOTracker{states: states, thresholds: DefaultBudgetThresholds, callbacks: callbacks}
+}
+
+// Notify publishes future threshold crossings to the topic, typically
+// an EventBus's SLO topic, in addition to the construction callbacks.
+func (t *SLOTracker) Notify(topic *Topic[SLOEvent]) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.callbacks = append(t.callbacks, topic.Publish)
+}
+
+// Record feeds one task outcome into every matching objective.
+func (t *SLOTracker) Record(taskType string, success bool, duration time.Duration) {
+	now := time.Now().UTC()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, state := range t.states {
+		if state.slo.TaskType != "" && state.slo.TaskType != taskType {
+			continue
+		}
+		good := success && (state.slo.Within <= 0 || duration <= state.slo.Within)
+		state.outcomes = append(state.outcomes, sloOutcome{at: now, good: good})
+		state.prune(now)
+		t.fireCrossings(state, now)
+	}
+}
+
+// prune drops outcomes that fell out of the rolling window.
+func (s *sloState) prune(now time.Time) {
+	cutoff := now.Add(-s.slo.Window)
+	i := 0
+	for ; i < len(s.outcomes); i++ {
+		if !s.outcomes[i].at.Before(cutoff) {
+			break
+		}
+	}
+	s.outcomes = s.outcomes[i:]
+}
+
+// status computes the objective's current numbers.
+func (s *sloState) status() SLOStatus {
+	status := SLOStatus{Name: s.slo.Name, Total: len(s.outcomes)}
+	for _, outcome := range s.outcomes {
+		if outcome.good {
+			status.Good++
+		}
+	}
+	if status.Total == 0 {
+		status.Compliance = 1
+		return status
+	}
+	status.Compliance = float64(status.Good) / float64(status.Total)
+	budget := 1 - s.slo.Target
+	if budget > 0 {
+		status.BudgetConsumed = (1 - status.Compliance) / budget
+		status.BurnRate = status.BudgetConsumed
+	}
+	return status
+}
+
+// fireCrossings emits events for thresholds newly crossed upward.
+func (t *SLOTracker) fireCrossings(state *sloState, now time.Time) {
+	status := state.status()
+	for _, threshold := range t.thresholds {
+		if status.BudgetConsumed >= threshold && state.lastFired < threshold {
+			event := SLOEvent{
+				Name:           state.slo.Name,
+				Threshold:      threshold,
+				BudgetConsumed: status.BudgetConsumed,
+				BurnRate:       status.BurnRate,
+				At:             now,
+			}
+			for _, cb := range t.callbacks {
+				cb(event)
+			}
+		}
+	}
+	state.lastFired = status.BudgetConsumed
+}
+
+// Status returns the current state of every objective.
+func (t *SLOTracker) Status() []SLOStatus {
+	now := time.Now().UTC()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	statuses := make([]SLOStatus, 0, len(t.states))
+	for _, state := range t.states {
+		state.prune(now)
+		statuses = append(statuses, state.status())
+	}
+	return statuses
+}
+
+// recordSLO feeds a processed task into the configured tracker.
+func (w *Worker) recordSLO(task *Task, success bool, duration time.Duration) {
+	if w.config.SLOs == nil {
+		return
+	}
+	w.config.SLOs.Record(task.Type, success, duration)
+}
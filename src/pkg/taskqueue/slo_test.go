@@ -0,0 +1,82 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSLOTrackerComputesComplianceAndBudget(t *testing.T) {
+	tracker := NewSLOTracker([]SLO{{Name: "email-fast", TaskType: "email", Target: 0.9, Within: 30 * time.Second}})
+
+	for i := 0; i < 8; i++ {
+		tracker.Record("email", true, time.Second)
+	}
+	tracker.Record("email", true, time.Minute) // too slow counts as bad
+	tracker.Record("email", false, 0)
+	tracker.Record("report", false, 0) // other type is ignored
+
+	status := tracker.Status()
+	require.Len(t, status, 1)
+	assert.Equal(t, 10, status[0].Total)
+	assert.Equal(t, 8, status[0].Good)
+	assert.InDelta(t, 0.8, status[0].Compliance, 0.001)
+	// 20% bad against a 10% budget: budget fully consumed, burning at 2x.
+	assert.InDelta(t, 2.0, status[0].BudgetConsumed, 0.001)
+	assert.InDelta(t, 2.0, status[0].BurnRate, 0.001)
+}
+
+func TestSLOTrackerEmitsThresholdCrossings(t *testing.T) {
+	var events []SLOEvent
+	tracker := NewSLOTracker(
+		[]SLO{{Name: "email", TaskType: "email", Target: 0.5}},
+		func(event SLOEvent) { events = append(events, event) },
+	)
+
+	tracker.Record("email", true, 0)
+	tracker.Record("email", true, 0)
+	tracker.Record("email", true, 0)
+	require.Empty(t, events)
+
+	// One failure in four: 25% bad against a 50% budget, half consumed.
+	tracker.Record("email", false, 0)
+	require.Len(t, events, 1)
+	assert.InDelta(t, 0.5, events[0].Threshold, 0.001)
+	assert.InDelta(t, 0.5, events[0].BudgetConsumed, 0.001)
+
+	// Two in five crosses 0.5 again without re-firing, then three in six
+	// crosses 0.9 and 1.0 together.
+	tracker.Record("email", false, 0)
+	tracker.Record("email", false, 0)
+	require.Len(t, events, 3)
+	assert.InDelta(t, 0.9, events[1].Threshold, 0.001)
+	assert.InDelta(t, 1.0, events[2].Threshold, 0.001)
+}
+
+func TestSLOStatusWithoutOutcomes(t *testing.T) {
+	tracker := NewSLOTracker([]SLO{{Name: "empty", Target: 0.99}})
+	status := tracker.Status()
+	require.Len(t, status, 1)
+	assert.InDelta(t, 1.0, status[0].Compliance, 0.001)
+	assert.Zero(t, status[0].BudgetConsumed)
+}
+
+func TestWorkerFeedsSLOTracker(t *testing.T) {
+	tracker := NewSLOTracker([]SLO{{Name: "email", TaskType: "email", Target: 0.99}})
+	worker := NewWorker(WorkerConfig{WorkerCount: 1, SLOs: tracker}, newMockRepository(), nil)
+	worker.Executors().Register("email", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		return &TaskResult{}, nil
+	}))
+
+	ctx := context.Background()
+	require.NoError(t, worker.SubmitTask(ctx, NewTask("email", nil)))
+	worker.Start()
+	require.NoError(t, worker.Shutdown(ctx))
+
+	status := tracker.Status()
+	require.Len(t, status, 1)
+	assert.Equal(t, 1, status[0].Good)
+}
@@ -0,0 +1,65 @@
+package taskqueue
+
+import (
+	"context"
+	"log/slog"
+)
+
+// taskKey is the context key for the task being processed.
+type taskKey struct{}
+
+// withTask returns a context carrying the task under execution.
+func withTask(ctx context.Context, task *Task) context.Context {
+	return context.WithValue(ctx, taskKey{}, task)
+}
+
+// TaskFromContext returns the task the context's execution belongs to.
+// Executors receive such a context from the worker; elsewhere it
+// reports false.
+func TaskFromContext(ctx context.Context) (*Task, bool) {
+	task, ok := ctx.Value(taskKey{}).(*Task)
+	return task, ok
+}
+
+// TaskContextHandler wraps a slog.Handler and annotates every record
+// whose context belongs to a task execution with the task's ID, type
+// and attempt. Installing it on the process logger correlates
+// executors' own slog calls without passing loggers around:
+//
+//	slog.SetDefault(slog.New(taskqueue.NewTaskContextHandler(handler)))
+type TaskContextHandler struct {
+	inner slog.Handler
+}
+
+// NewTaskContextHandler wraps the handler.
+func NewTaskContextHandler(inner slog.Handler) *TaskContextHandler {
+	return &TaskContextHandler{inner: inner}
+}
+
+// Enabled implements slog.Handler.
+func (h *TaskContextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler, adding the context's task attributes
+// to the record before delegating.
+func (h *TaskContextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if task, ok := TaskFromContext(ctx); ok {
+		record.AddAttrs(
+			slog.String("task_id", task.ID),
+			slog.String("task_type", task.Type),
+			slog.Int("attempt", task.Retries),
+		)
+	}
+	return h.inner.Handle(ctx, record)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *TaskContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &TaskContextHandler{inner: h.inner.WithAttrs(attrs)}
+}
+
+// WithGroup implements slog.Handler.
+func (h *TaskContextHandler) WithGroup(name string) slog.Handler {
+	return &TaskContextHandler{inner: h.inner.WithGroup(name)}
+}
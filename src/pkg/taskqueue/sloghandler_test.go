@@ -0,0 +1,70 @@
+package taskqueue
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskContextHandlerAnnotatesRecords(t *testing.T) {
+	var out syncWriter
+	logger := slog.New(NewTaskContextHandler(slog.NewTextHandler(&out, nil)))
+
+	task := NewTask("email", nil)
+	task.Retries = 2
+	logger.InfoContext(withTask(context.Background(), task), "handling")
+	logger.Info("outside")
+
+	logged := out.String()
+	assert.Contains(t, logged, "task_id="+task.ID)
+	assert.Contains(t, logged, "task_type=email")
+	assert.Contains(t, logged, "attempt=2")
+	assert.Contains(t, logged, "outside")
+}
+
+func TestTaskContextHandlerPreservesAttrsAndGroups(t *testing.T) {
+	var out syncWriter
+	handler := NewTaskContextHandler(slog.NewTextHandler(&out, nil)).
+		WithAttrs([]slog.Attr{slog.String("component", "worker")}).
+		WithGroup("queue")
+	slog.New(handler).Info("grouped", "depth", 3)
+
+	logged := out.String()
+	assert.Contains(t, logged, "component=worker")
+	assert.Contains(t, logged, "queue.depth=3")
+}
+
+func TestExecutorSlogCallsAreCorrelated(t *testing.T) {
+	var out syncWriter
+	logger := slog.New(NewTaskContextHandler(slog.NewTextHandler(&out, nil)))
+
+	w := NewWorker(WorkerConfig{WorkerCount: 1, Logger: logger}, newMockRepository(), nil)
+	w.Executors().Register("email", ExecutorFunc(func(ctx context.Context, _ *Task) (*TaskResult, error) {
+		logger.InfoContext(ctx, "executor speaking")
+		return &TaskResult{}, nil
+	}))
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	task := NewTask("email", nil)
+	require.NoError(t, w.SubmitTask(context.Background(), task))
+	require.Eventually(t, func() bool { return w.Stats().Completed == 1 }, 2*time.Second, 5*time.Millisecond)
+
+	logged := out.String()
+	assert.Contains(t, logged, "executor speaking")
+	assert.Contains(t, logged, "task_id="+task.ID)
+}
+
+func TestTaskFromContext(t *testing.T) {
+	_, ok := TaskFromContext(context.Background())
+	assert.False(t, ok)
+
+	task := NewTask("email", nil)
+	got, ok := TaskFromContext(withTask(context.Background(), task))
+	assert.True(t, ok)
+	assert.Same(t, task, got)
+}
@@ -0,0 +1,185 @@
+package taskqueue
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Defaults applied by speculative execution when the corresponding
+// config field is zero.
+const (
+	DefaultSpeculationSamples = 20
+	DefaultSpeculationWindow  = 256
+)
+
+// SpeculationConfig enables duplicate attempts against tail latency:
+// when an attempt of a declared type runs past the observed p99 for that
+// type, a second attempt is launched and the first successful result
+// wins while the straggler's context is cancelled. Only declare types
+// whose executors are idempotent — both attempts may run to completion.
+type SpeculationConfig struct {
+	// Types lists the task types declared idempotent and therefore safe
+	// to duplicate.
+	Types []string
+
+	// MinSamples is how many completed attempts of a type must be
+	// observed before its p99 is trusted. Defaults to
+	// DefaultSpeculationSamples.
+	MinSamples int
+
+	// WindowSize is how many recent attempt durations are kept per type.
+	// Defaults to DefaultSpeculationWindow.
+	WindowSize int
+}
+
+// speculator decides when a duplicate attempt is worth launching, based
+// on a sliding window of attempt durations per task type.
+type speculator struct {
+	types      map[string]struct{}
+	minSamples int
+	window     int
+
+	mu      sync.Mutex
+	samples map[string][]time.Duration
+	next    map[string]int
+}
+
+// newSpeculator builds a speculator with defaults applied.
+func newSpeculator(config SpeculationConfig) *speculator {
+	if config.MinSamples <= 0 {
+		config.MinSamples = DefaultSpeculationSamples
+	}
+	if config.WindowSize <= 0 {
+		config.WindowSize = DefaultSpeculationWindow
+	}
+	types := make(map[string]struct{}, len(config.Types))
+	for _, taskType := range config.Types {
+		types[taskType] = struct{}{}
+	}
+	return &speculator{
+		types:      types,
+		minSamples: config.MinSamples,
+		window:     config.WindowSize,
+		samples:    make(map[string][]time.Duration),
+		next:       make(map[string]int),
+	}
+}
+
+// eligible reports whether the type is declared safe to duplicate.
+func (s *speculator) eligible(taskType string) bool {
+	_, ok := s.types[taskType]
+	return ok
+}
+
+// observe records one successful attempt duration into the type's
+// sliding window.
+func (s *speculator) observe(taskType string, d time.Duration) {
+	if !s.eligible(taskType) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	window := s.samples[taskType]
+	if len(window) < s.window {
+		s.samples[taskType] = append(window, d)
+		return
+	}
+	window[s.next[taskType]] = d
+	s.next[taskType] = (s.next[taskType] + 1) % s.window
+}
+
+// threshold returns the p99 attempt duration of the type, and whether
+// enough samples back it.
+func (s *speculator) threshold(taskType string) (time.Duration, bool) {
+	s.mu.Lock()
+	window := s.samples[taskType]
+	if len(window) < s.minSamples {
+		s.mu.Unlock()
+		return 0, false
+	}
+	sorted := make([]time.Duration, len(window))
+	copy(sorted, window)
+	s.mu.Unlock()
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[len(sorted)*99/100], true
+}
+
+// attemptOutcome is the result of one execution attempt.
+type attemptOutcome struct {
+	result *TaskResult
+	err    error
+}
+
+// speculativeExecute runs the executor, duplicating the attempt once the
+// type's p99 passes without a result. The first success wins and the
+// other attempt's context is cancelled; when every attempt fails, the
+// last error is returned. Types not declared in SpeculationConfig run
+// exactly once.
+func (w *Worker) speculativeExecute(ctx context.Context, executor Executor, task *Task) (*TaskResult, error) {
+	if w.speculation == nil || !w.speculation.eligible(task.Type) {
+		return executor.Execute(ctx, task)
+	}
+	threshold, ok := w.speculation.threshold(task.Type)
+	if !ok {
+		return w.observedExecute(ctx, executor, task)
+	}
+
+	outcomes := make(chan attemptOutcome, 2)
+	attempt := func(attemptCtx context.Context) {
+		result, err := w.observedExecute(attemptCtx, executor, task)
+		outcomes <- attemptOutcome{result: result, err: err}
+	}
+
+	primaryCtx, cancelPrimary := context.WithCancel(ctx)
+	defer cancelPrimary()
+	go attempt(primaryCtx)
+	remaining := 1
+
+	timer := time.NewTimer(threshold)
+	defer timer.Stop()
+	var cancelDuplicate context.CancelFunc
+
+	var lastErr error
+	for {
+		select {
+		case <-timer.C:
+			if cancelDuplicate != nil || remaining == 0 {
+				continue
+			}
+			var duplicateCtx context.Context
+			duplicateCtx, cancelDuplicate = context.WithCancel(ctx)
+			defer cancelDuplicate()
+			go attempt(duplicateCtx)
+			remaining++
+			w.stats.speculated.Add(1)
+			w.log(ctx).Info("taskqueue: speculative attempt launched", "task_id", task.ID, "type", task.Type, "threshold", threshold)
+		case outcome := <-outcomes:
+			remaining--
+			if outcome.err == nil {
+				cancelPrimary()
+				if cancelDuplicate != nil {
+					cancelDuplicate()
+				}
+				return outcome.result, nil
+			}
+			lastErr = outcome.err
+			if remaining == 0 {
+				return nil, lastErr
+			}
+		}
+	}
+}
+
+// observedExecute runs one attempt and feeds its duration back into the
+// speculation window on success.
+func (w *Worker) observedExecute(ctx context.Context, executor Executor, task *Task) (*TaskResult, error) {
+	start := time.Now()
+	result, err := executor.Execute(ctx, task)
+	if err == nil {
+		w.speculation.observe(task.Type, time.Since(start))
+	}
+	return result, err
+}
@@ -0,0 +1,102 @@
+package taskqueue
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpeculatorThreshold(t *testing.T) {
+	s := newSpeculator(SpeculationConfig{Types: []string{"fetch"}, MinSamples: 3})
+
+	_, ok := s.threshold("fetch")
+	assert.False(t, ok, "threshold needs MinSamples observations")
+
+	s.observe("fetch", time.Millisecond)
+	s.observe("fetch", 2*time.Millisecond)
+	s.observe("fetch", 100*time.Millisecond)
+	threshold, ok := s.threshold("fetch")
+	require.True(t, ok)
+	assert.Equal(t, 100*time.Millisecond, threshold)
+
+	s.observe("other", time.Millisecond)
+	_, ok = s.threshold("other")
+	assert.False(t, ok, "undeclared types are not tracked")
+}
+
+func TestSpeculatorWindowSlides(t *testing.T) {
+	s := newSpeculator(SpeculationConfig{Types: []string{"fetch"}, MinSamples: 1, WindowSize: 2})
+	s.observe("fetch", time.Hour)
+	s.observe("fetch", time.Millisecond)
+	s.observe("fetch", time.Millisecond)
+
+	threshold, ok := s.threshold("fetch")
+	require.True(t, ok)
+	assert.Equal(t, time.Millisecond, threshold, "old outlier should have slid out of the window")
+}
+
+func TestSpeculativeExecuteRacesDuplicate(t *testing.T) {
+	w := NewWorker(WorkerConfig{
+		WorkerCount: 1,
+		Results:     newMockResultRepository(),
+		Speculation: &SpeculationConfig{Types: []string{"fetch"}, MinSamples: 1},
+	}, newMockRepository(), nil)
+	var attempts atomic.Int64
+	w.Executors().Register("fetch", ExecutorFunc(func(ctx context.Context, _ *Task) (*TaskResult, error) {
+		if attempts.Add(1) == 1 {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return &TaskResult{Output: "fast"}, nil
+	}))
+	for i := 0; i < 5; i++ {
+		w.speculation.observe("fetch", time.Millisecond)
+	}
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	task := NewTask("fetch", nil)
+	require.NoError(t, w.SubmitTask(context.Background(), task))
+
+	require.Eventually(t, func() bool {
+		result, err := w.GetResult(context.Background(), task.ID)
+		return err == nil && result.Success && result.Output == "fast"
+	}, 2*time.Second, 5*time.Millisecond)
+	assert.Equal(t, uint64(1), w.Stats().Speculated)
+}
+
+func TestSpeculativeExecuteSkipsUndeclaredTypes(t *testing.T) {
+	w := NewWorker(WorkerConfig{
+		WorkerCount: 1,
+		Speculation: &SpeculationConfig{Types: []string{"fetch"}, MinSamples: 1},
+	}, newMockRepository(), nil)
+	var attempts atomic.Int64
+	executor := ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		attempts.Add(1)
+		return &TaskResult{Output: "ok"}, nil
+	})
+
+	_, err := w.speculativeExecute(context.Background(), executor, NewTask("email", nil))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), attempts.Load())
+	assert.Zero(t, w.Stats().Speculated)
+}
+
+func TestSpeculativeExecuteReturnsErrorWhenAllAttemptsFail(t *testing.T) {
+	w := NewWorker(WorkerConfig{
+		WorkerCount: 1,
+		Speculation: &SpeculationConfig{Types: []string{"fetch"}, MinSamples: 1},
+	}, newMockRepository(), nil)
+	w.speculation.observe("fetch", time.Nanosecond)
+	executor := ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		time.Sleep(5 * time.Millisecond)
+		return nil, assert.AnError
+	})
+
+	_, err := w.speculativeExecute(context.Background(), executor, NewTask("fetch", nil))
+	assert.ErrorIs(t, err, assert.AnError)
+}
@@ -0,0 +1,63 @@
+package taskqueue
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// sortedStatuses returns the table's source statuses in stable order so
+// rendered diagrams are deterministic.
+func sortedStatuses(table map[TaskStatus][]TaskStatus) []TaskStatus {
+	statuses := make([]TaskStatus, 0, len(table))
+	for status := range table {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i] < statuses[j] })
+	return statuses
+}
+
+// MermaidDiagram renders the transition table as a Mermaid state
+// diagram, so the admin UI and docs always reflect the actual rules.
+// Pass TransitionTable() for the built-in machine, or an extended copy.
+func MermaidDiagram(table map[TaskStatus][]TaskStatus) string {
+	var b strings.Builder
+	b.WriteString("stateDiagram-v2\n")
+	fmt.Fprintf(&b, "    [*] --> %s\n", TaskStatusPending)
+	for _, from := range sortedStatuses(table) {
+		targets := append([]TaskStatus(nil), table[from]...)
+		sort.Slice(targets, func(i, j int) bool { return targets[i] < targets[j] })
+		if len(targets) == 0 {
+			fmt.Fprintf(&b, "    %s --> [*]\n", from)
+			continue
+		}
+		for _, to := range targets {
+			fmt.Fprintf(&b, "    %s --> %s\n", from, to)
+		}
+	}
+	return b.String()
+}
+
+// DOTDiagram renders the transition table in Graphviz DOT, with terminal
+// states drawn as double circles.
+func DOTDiagram(table map[TaskStatus][]TaskStatus) string {
+	var b strings.Builder
+	b.WriteString("digraph taskqueue {\n")
+	b.WriteString("    rankdir=LR;\n")
+	for _, from := range sortedStatuses(table) {
+		if len(table[from]) == 0 {
+			fmt.Fprintf(&b, "    %q [shape=doublecircle];\n", from)
+		} else {
+			fmt.Fprintf(&b, "    %q [shape=circle];\n", from)
+		}
+	}
+	for _, from := range sortedStatuses(table) {
+		targets := append([]TaskStatus(nil), table[from]...)
+		sort.Slice(targets, func(i, j int) bool { return targets[i] < targets[j] })
+		for _, to := range targets {
+			fmt.Fprintf(&b, "    %q -> %q;\n", from, to)
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
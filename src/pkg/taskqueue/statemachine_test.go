@@ -0,0 +1,46 @@
+package taskqueue
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMermaidDiagramCoversEveryTransition(t *testing.T) {
+	diagram := MermaidDiagram(TransitionTable())
+
+	assert.True(t, strings.HasPrefix(diagram, "stateDiagram-v2\n"))
+	for from, targets := range TransitionTable() {
+		if len(targets) == 0 {
+			assert.Contains(t, diagram, string(from)+" --> [*]")
+		}
+		for _, to := range targets {
+			assert.Contains(t, diagram, string(from)+" --> "+string(to))
+		}
+	}
+}
+
+func TestDOTDiagramMarksTerminalStates(t *testing.T) {
+	diagram := DOTDiagram(TransitionTable())
+
+	assert.True(t, strings.HasPrefix(diagram, "digraph taskqueue {"))
+	assert.Contains(t, diagram, `"completed" [shape=doublecircle];`)
+	assert.Contains(t, diagram, `"pending" [shape=circle];`)
+	assert.Contains(t, diagram, `"pending" -> "processing";`)
+}
+
+func TestDiagramsAreDeterministic(t *testing.T) {
+	assert.Equal(t, MermaidDiagram(TransitionTable()), MermaidDiagram(TransitionTable()))
+	assert.Equal(t, DOTDiagram(TransitionTable()), DOTDiagram(TransitionTable()))
+}
+
+func TestDiagramsRenderExtendedTables(t *testing.T) {
+	table := TransitionTable()
+	table[TaskStatus("quarantined")] = nil
+	table[TaskStatusFailed] = append(table[TaskStatusFailed], TaskStatus("quarantined"))
+
+	diagram := MermaidDiagram(table)
+	assert.Contains(t, diagram, "failed --> quarantined")
+	assert.Contains(t, diagram, "quarantined --> [*]")
+}
@@ -0,0 +1,47 @@
+package taskqueue
+
+import "sync/atomic"
+
+// WorkerStats tracks queue activity with lock-free counters.
+type WorkerStats struct {
+	submitted    atomic.Uint64
+	completed    atomic.Uint64
+	failed       atomic.Uint64
+	rejected     atomic.Uint64
+	spilled      atomic.Uint64
+	expired      atomic.Uint64
+	retried      atomic.Uint64
+	speculated   atomic.Uint64
+	throttled    atomic.Uint64
+	deduplicated atomic.Uint64
+}
+
+// StatsSnapshot is a point-in-time copy of the worker counters.
+type StatsSnapshot struct {
+	Submitted    uint64 `json:"submitted"`
+	Completed    uint64 `json:"completed"`
+	Failed       uint64 `json:"failed"`
+	Rejected     uint64 `json:"rejected"`
+	Spilled      uint64 `json:"spilled"`
+	Expired      uint64 `json:"expired"`
+	Retried      uint64 `json:"retried"`
+	Speculated   uint64 `json:"speculated"`
+	Throttled    uint64 `json:"throttled"`
+	Deduplicated uint64 `json:"deduplicated"`
+}
+
+// Snapshot returns the current counter values.
+func (s *WorkerStats) Snapshot() StatsSnapshot {
+	return StatsSnapshot{
+		Submitted:    s.submitted.Load(),
+		Completed:    s.completed.Load(),
+		Failed:       s.failed.Load(),
+		Rejected:     s.rejected.Load(),
+		Spilled:      s.spilled.Load(),
+		Expired:      s.expired.Load(),
+		Retried:      s.retried.Load(),
+		Speculated:   s.speculated.Load(),
+		Throttled:    s.throttled.Load(),
+		Deduplicated: s.deduplicated.Load(),
+	}
+}
@@ -0,0 +1,103 @@
+package taskqueue
+
+import (
+	"fmt"
+	"sync"
+)
+
+// statusMu guards the transition table and the custom status set, so
+// plugins may register statuses while workers are reading the machine.
+var statusMu sync.RWMutex
+
+// customStatuses tracks the statuses added via RegisterStatus.
+var customStatuses = make(map[TaskStatus]struct{})
+
+// StatusRule declares where a custom status sits in the state machine.
+type StatusRule struct {
+	// From are the existing statuses that may transition into the new
+	// one. At least one is required, and none may be terminal.
+	From []TaskStatus
+
+	// To are the statuses the new one may transition to. Empty makes it
+	// terminal.
+	To []TaskStatus
+}
+
+// RegisterStatus adds a domain-specific status (for example
+// "awaiting-approval") with its own transition rules, so plugin services
+// can model richer lifecycles without forking the package. The rule is
+// validated against the current machine: names are lowercase words
+// joined by hyphens, sources must exist and must not be terminal —
+// terminal states stay unresurrectable — and targets must exist. Errors
+// wrap ErrInvalidStatusRule.
+func RegisterStatus(status TaskStatus, rule StatusRule) error {
+	if !validStatusName(string(status)) {
+		return fmt.Errorf("%w: bad name %q", ErrInvalidStatusRule, status)
+	}
+	if len(rule.From) == 0 {
+		return fmt.Errorf("%w: %q needs at least one source status", ErrInvalidStatusRule, status)
+	}
+
+	statusMu.Lock()
+	defer statusMu.Unlock()
+	if _, exists := transitions[status]; exists {
+		return fmt.Errorf("%w: %q already registered", ErrInvalidStatusRule, status)
+	}
+	for _, from := range rule.From {
+		targets, exists := transitions[from]
+		if !exists {
+			return fmt.Errorf("%w: unknown source status %q", ErrInvalidStatusRule, from)
+		}
+		if len(targets) == 0 {
+			return fmt.Errorf("%w: source status %q is terminal", ErrInvalidStatusRule, from)
+		}
+	}
+	for _, to := range rule.To {
+		if _, exists := transitions[to]; !exists && to != status {
+			return fmt.Errorf("%w: unknown target status %q", ErrInvalidStatusRule, to)
+		}
+	}
+
+	transitions[status] = append([]TaskStatus(nil), rule.To...)
+	for _, from := range rule.From {
+		transitions[from] = append(transitions[from], status)
+	}
+	customStatuses[status] = struct{}{}
+	return nil
+}
+
+// unregisterStatus removes a custom status and every edge pointing at
+// it. Built-in statuses cannot be removed. Tests use it to keep the
+// global machine clean.
+func unregisterStatus(status TaskStatus) {
+	statusMu.Lock()
+	defer statusMu.Unlock()
+	if _, ok := customStatuses[status]; !ok {
+		return
+	}
+	delete(customStatuses, status)
+	delete(transitions, status)
+	for from, targets := range transitions {
+		kept := targets[:0]
+		for _, to := range targets {
+			if to != status {
+				kept = append(kept, to)
+			}
+		}
+		transitions[from] = kept
+	}
+}
+
+// validStatusName reports whether the name is lowercase words joined by
+// hyphens.
+func validStatusName(name string) bool {
+	if name == "" || name[0] == '-' || name[len(name)-1] == '-' {
+		return false
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+package taskqueue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterStatusExtendsTheMachine(t *testing.T) {
+	approval := TaskStatus("pending-review")
+	require.NoError(t, RegisterStatus(approval, StatusRule{
+		From: []TaskStatus{TaskStatusPending},
+		To:   []TaskStatus{TaskStatusProcessing, TaskStatusCancelled},
+	}))
+	t.Cleanup(func() { unregisterStatus(approval) })
+
+	assert.True(t, IsValidStatus(approval))
+
+	task := &Task{Status: TaskStatusPending}
+	assert.True(t, task.CanTransition(approval))
+	task.Status = approval
+	assert.False(t, task.IsTerminal())
+	assert.True(t, task.CanTransition(TaskStatusProcessing))
+	assert.False(t, task.CanTransition(TaskStatusCompleted))
+
+	table := TransitionTable()
+	assert.Contains(t, table[TaskStatusPending], approval)
+}
+
+func TestRegisterStatusTerminal(t *testing.T) {
+	quarantined := TaskStatus("quarantined")
+	require.NoError(t, RegisterStatus(quarantined, StatusRule{
+		From: []TaskStatus{TaskStatusFailed},
+	}))
+	t.Cleanup(func() { unregisterStatus(quarantined) })
+
+	task := &Task{Status: quarantined}
+	assert.True(t, task.IsTerminal())
+}
+
+func TestRegisterStatusValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		status TaskStatus
+		rule   StatusRule
+	}{
+		{"empty name", "", StatusRule{From: []TaskStatus{TaskStatusPending}}},
+		{"uppercase name", "AwaitingApproval", StatusRule{From: []TaskStatus{TaskStatusPending}}},
+		{"leading hyphen", "-bad", StatusRule{From: []TaskStatus{TaskStatusPending}}},
+		{"no sources", "orphan", StatusRule{}},
+		{"already registered", TaskStatusPending, StatusRule{From: []TaskStatus{TaskStatusFailed}}},
+		{"unknown source", "floating", StatusRule{From: []TaskStatus{"nowhere"}}},
+		{"terminal source", "resurrected", StatusRule{From: []TaskStatus{TaskStatusCompleted}}},
+		{"unknown target", "dangling", StatusRule{From: []TaskStatus{TaskStatusPending}, To: []TaskStatus{"nowhere"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RegisterStatus(tt.status, tt.rule)
+			assert.ErrorIs(t, err, ErrInvalidStatusRule)
+		})
+	}
+}
+
+func TestUnregisterStatusRestoresTheMachine(t *testing.T) {
+	held := TaskStatus("held")
+	require.NoError(t, RegisterStatus(held, StatusRule{From: []TaskStatus{TaskStatusPending}}))
+	unregisterStatus(held)
+
+	assert.False(t, IsValidStatus(held))
+	assert.NotContains(t, TransitionTable()[TaskStatusPending], held)
+}
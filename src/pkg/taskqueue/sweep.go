@@ -0,0 +1,50 @@
+package taskqueue
+
+import (
+	"context"
+	"hash/fnv"
+	"time"
+)
+
+// Sweep lock names used with SweepLocker.
+const (
+	// sweepLockRecovery serializes recovery polls across instances.
+	sweepLockRecovery = "recovery"
+
+	// sweepLockExpiry serializes expiry sweeps across instances.
+	sweepLockExpiry = "expiry"
+)
+
+// SweepLocker is the optional repository capability coordinating
+// periodic sweeps across instances: only the owner holding the named
+// lock runs the sweep, so a fleet restarting together does not hammer
+// the repository with identical ListPending scans.
+type SweepLocker interface {
+	// TryLockSweep acquires the named sweep lock for the owner until the
+	// ttl elapses, reporting false when another owner holds it.
+	TryLockSweep(ctx context.Context, name, owner string, ttl time.Duration) (bool, error)
+}
+
+// sweepJitter returns a deterministic offset in [0, interval) derived
+// from the owner identity. Staggering identical schedules by a stable
+// per-instance amount keeps sweeps spread out across restarts without
+// the coordination randomness would need to stay spread.
+func sweepJitter(owner string, interval time.Duration) time.Duration {
+	if owner == "" || interval <= 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write([]byte(owner))
+	return time.Duration(h.Sum64() % uint64(interval))
+}
+
+// acquireSweepLock takes the named sweep lock when the repository
+// coordinates sweeps across instances; repositories without the
+// capability always grant it.
+func acquireSweepLock(ctx context.Context, repo TaskRepository, name, owner string, ttl time.Duration) (bool, error) {
+	locker, ok := repositoryAs[SweepLocker](repo)
+	if !ok {
+		return true, nil
+	}
+	return locker.TryLockSweep(ctx, name, owner, ttl)
+}
@@ -0,0 +1,94 @@
+package taskqueue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lockingRepository extends the mock repository with sweep locks that a
+// single owner holds per name, as a shared database table would.
+type lockingRepository struct {
+	*mockRepository
+	mu    sync.Mutex
+	locks map[string]string
+}
+
+func newLockingRepository() *lockingRepository {
+	return &lockingRepository{mockRepository: newMockRepository(), locks: make(map[string]string)}
+}
+
+func (r *lockingRepository) TryLockSweep(_ context.Context, name, owner string, _ time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if holder, held := r.locks[name]; held && holder != owner {
+		return false, nil
+	}
+	r.locks[name] = owner
+	return true, nil
+}
+
+func TestSweepJitterIsDeterministicAndBounded(t *testing.T) {
+	interval := 5 * time.Second
+	first := sweepJitter("instance-a", interval)
+	assert.Equal(t, first, sweepJitter("instance-a", interval), "same owner, same offset")
+	assert.Less(t, first, interval)
+	assert.NotEqual(t, first, sweepJitter("instance-b", interval), "different owners spread out")
+	assert.Zero(t, sweepJitter("", interval))
+	assert.Zero(t, sweepJitter("instance-a", 0))
+}
+
+func TestPollSkipsWhenRecoveryLockIsHeld(t *testing.T) {
+	repo := newLockingRepository()
+	require.NoError(t, repo.Save(context.Background(), NewTask("email", nil)))
+	repo.locks[sweepLockRecovery] = "someone-else"
+
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, repo, nil)
+	defer w.Shutdown(context.Background())
+
+	submitted, err := NewPoller(w, PollerConfig{Owner: "instance-a"}).Poll(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, submitted, "losing the lock is a quiet no-op")
+}
+
+func TestPollRunsAfterWinningRecoveryLock(t *testing.T) {
+	repo := newLockingRepository()
+	require.NoError(t, repo.Save(context.Background(), NewTask("email", nil)))
+
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, repo, nil)
+	w.Executors().Register("email", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		return &TaskResult{}, nil
+	}))
+	w.Start()
+	defer w.Shutdown(context.Background())
+
+	submitted, err := NewPoller(w, PollerConfig{Owner: "instance-a"}).Poll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, submitted)
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	assert.Equal(t, "instance-a", repo.locks[sweepLockRecovery])
+}
+
+func TestExpirySweepRunSkipsWhenLockIsHeld(t *testing.T) {
+	repo := newLockingRepository()
+	repo.locks[sweepLockExpiry] = "someone-else"
+	expired := NewTask("email", nil)
+	expired.ExpiresAt = time.Now().Add(-time.Hour)
+	require.NoError(t, repo.Save(context.Background(), expired))
+
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, repo, nil)
+	defer w.Shutdown(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	NewExpirySweeper(w).Run(ctx, 10*time.Millisecond)
+
+	task, err := repo.GetByID(context.Background(), expired.ID)
+	require.NoError(t, err)
+	assert.Equal(t, TaskStatusPending, task.Status, "the instance without the lock leaves the sweep alone")
+}
@@ -0,0 +1,76 @@
+// Package syncmap wraps sync.Map with type parameters so callers get
+// compile-time type safety instead of pervasive value.(*T) assertions.
+package syncmap
+
+import (
+	"iter"
+	"sync"
+)
+
+// Map is a typed concurrent map. The zero value is empty and ready to use.
+type Map[K comparable, V any] struct {
+	m sync.Map
+}
+
+// Store sets the value for a key.
+func (m *Map[K, V]) Store(key K, value V) {
+	m.m.Store(key, value)
+}
+
+// Load returns the value stored for key and whether it was present.
+func (m *Map[K, V]) Load(key K) (V, bool) {
+	v, ok := m.m.Load(key)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return v.(V), true
+}
+
+// LoadOrStore returns the existing value for key if present, otherwise it
+// stores and returns value. loaded is true if the value was present.
+func (m *Map[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	v, loaded := m.m.LoadOrStore(key, value)
+	return v.(V), loaded
+}
+
+// LoadAndDelete deletes the value for key, returning it if present.
+func (m *Map[K, V]) LoadAndDelete(key K) (V, bool) {
+	v, ok := m.m.LoadAndDelete(key)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return v.(V), true
+}
+
+// Delete removes the value for key.
+func (m *Map[K, V]) Delete(key K) {
+	m.m.Delete(key)
+}
+
+// Range calls fn for each entry until fn returns false.
+func (m *Map[K, V]) Range(fn func(key K, value V) bool) {
+	m.m.Range(func(k, v any) bool {
+		return fn(k.(K), v.(V))
+	})
+}
+
+// All returns an iterator over the entries.
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.m.Range(func(k, v any) bool {
+			return yield(k.(K), v.(V))
+		})
+	}
+}
+
+// Len returns the number of entries by scanning the map.
+func (m *Map[K, V]) Len() int {
+	n := 0
+	m.m.Range(func(_, _ any) bool {
+		n++
+		return true
+	})
+	return n
+}
@@ -0,0 +1,139 @@
+package taskqueue
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// TaskStatus represents the lifecycle state of a task.
+type TaskStatus string
+
+// Task lifecycle states.
+const (
+	TaskStatusPending    TaskStatus = "pending"
+	TaskStatusProcessing TaskStatus = "processing"
+	TaskStatusCompleted  TaskStatus = "completed"
+	TaskStatusFailed     TaskStatus = "failed"
+	TaskStatusExpired    TaskStatus = "expired"
+	TaskStatusCancelled  TaskStatus = "cancelled"
+)
+
+// IsValidStatus reports whether s is one of the known task statuses,
+// built-in or registered via RegisterStatus.
+func IsValidStatus(s TaskStatus) bool {
+	switch s {
+	case TaskStatusPending, TaskStatusProcessing, TaskStatusCompleted, TaskStatusFailed, TaskStatusExpired, TaskStatusCancelled:
+		return true
+	}
+	statusMu.RLock()
+	defer statusMu.RUnlock()
+	_, ok := customStatuses[s]
+	return ok
+}
+
+// Task is the unit of work handled by the queue.
+type Task struct {
+	ID         string            `json:"id"`
+	Type       string            `json:"type"`
+	Data       map[string]any    `json:"data,omitempty"`
+	Status     TaskStatus        `json:"status"`
+	Priority   int               `json:"priority,omitempty"`
+	Retries    int               `json:"retries"`
+	MaxRetries int               `json:"max_retries"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+	ExpiresAt  time.Time         `json:"expires_at,omitzero"`
+
+	// Timeout, when positive, bounds this task's execution and overrides
+	// the type policy and WorkerConfig.ProcessTimeout.
+	Timeout time.Duration `json:"timeout,omitempty"`
+
+	// Deadline, when set, is the wall-clock instant execution must finish
+	// by, applied on top of the timeout.
+	Deadline time.Time `json:"deadline,omitzero"`
+}
+
+// NewTask returns a pending task of the given type with a generated ID.
+func NewTask(taskType string, data map[string]any) *Task {
+	now := time.Now().UTC()
+	return &Task{
+		ID:        newTaskID(),
+		Type:      taskType,
+		Data:      data,
+		Status:    TaskStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// transitions maps each status to the statuses it may legally move to.
+var transitions = map[TaskStatus][]TaskStatus{
+	TaskStatusPending:    {TaskStatusProcessing, TaskStatusExpired, TaskStatusCancelled},
+	TaskStatusProcessing: {TaskStatusCompleted, TaskStatusFailed, TaskStatusPending, TaskStatusCancelled},
+	TaskStatusCompleted:  {},
+	TaskStatusFailed:     {TaskStatusPending, TaskStatusCancelled},
+	TaskStatusExpired:    {},
+	TaskStatusCancelled:  {},
+}
+
+// TransitionTable returns a copy of the status transition table, keyed
+// by source status, so tooling and tests can consume the state machine
+// as data instead of re-deriving it from CanTransition.
+func TransitionTable() map[TaskStatus][]TaskStatus {
+	statusMu.RLock()
+	defer statusMu.RUnlock()
+	table := make(map[TaskStatus][]TaskStatus, len(transitions))
+	for from, tos := range transitions {
+		table[from] = append([]TaskStatus(nil), tos...)
+	}
+	return table
+}
+
+// CanTransition reports whether the task may move from its current
+// status to the target status. Beyond the transition table it enforces
+// the retry bound: a failed task whose retries are exhausted may no
+// longer return to pending (MaxRetries zero means unbounded).
+func (t *Task) CanTransition(to TaskStatus) bool {
+	if t.Status == TaskStatusFailed && to == TaskStatusPending &&
+		t.MaxRetries > 0 && t.Retries >= t.MaxRetries {
+		return false
+	}
+	statusMu.RLock()
+	defer statusMu.RUnlock()
+	for _, allowed := range transitions[t.Status] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpired reports whether the task's TTL or execution deadline has
+// passed: either timestamp makes buffered work not worth starting.
+// Tasks with neither an ExpiresAt nor a Deadline never expire.
+func (t *Task) IsExpired(now time.Time) bool {
+	if !t.ExpiresAt.IsZero() && !now.Before(t.ExpiresAt) {
+		return true
+	}
+	return !t.Deadline.IsZero() && !now.Before(t.Deadline)
+}
+
+// IsTerminal reports whether the task is in a final state.
+func (t *Task) IsTerminal() bool {
+	statusMu.RLock()
+	defer statusMu.RUnlock()
+	return len(transitions[t.Status]) == 0
+}
+
+// newTaskID returns a random 128-bit hex identifier.
+func newTaskID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand never fails on supported platforms; fall back to
+		// a timestamp so submission still works.
+		return hex.EncodeToString([]byte(time.Now().UTC().Format("20060102150405.000000000")))
+	}
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,140 @@
+package taskqueue
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue/testkit"
+)
+
+func TestIsValidStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		status TaskStatus
+		want   bool
+	}{
+		{"pending", TaskStatusPending, true},
+		{"processing", TaskStatusProcessing, true},
+		{"completed", TaskStatusCompleted, true},
+		{"failed", TaskStatusFailed, true},
+		{"empty", TaskStatus(""), false},
+		{"unknown", TaskStatus("archived"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsValidStatus(tt.status))
+		})
+	}
+}
+
+func TestTaskCanTransition(t *testing.T) {
+	tests := []struct {
+		name string
+		from TaskStatus
+		to   TaskStatus
+		want bool
+	}{
+		{"pending to processing", TaskStatusPending, TaskStatusProcessing, true},
+		{"pending to completed", TaskStatusPending, TaskStatusCompleted, false},
+		{"processing to completed", TaskStatusProcessing, TaskStatusCompleted, true},
+		{"processing to failed", TaskStatusProcessing, TaskStatusFailed, true},
+		{"failed to pending", TaskStatusFailed, TaskStatusPending, true},
+		{"completed is terminal", TaskStatusCompleted, TaskStatusPending, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{Status: tt.from}
+			assert.Equal(t, tt.want, task.CanTransition(tt.to))
+		})
+	}
+}
+
+func TestNewTask(t *testing.T) {
+	task := NewTask("email", map[string]any{"to": "ops@example.com"})
+
+	require.NotEmpty(t, task.ID)
+	assert.Equal(t, "email", task.Type)
+	assert.Equal(t, TaskStatusPending, task.Status)
+	assert.False(t, task.CreatedAt.IsZero())
+
+	other := NewTask("email", nil)
+	assert.NotEqual(t, task.ID, other.ID)
+}
+
+func TestTransitionTableIsACopy(t *testing.T) {
+	table := TransitionTable()
+	require.Contains(t, table, TaskStatusPending)
+	table[TaskStatusCompleted] = append(table[TaskStatusCompleted], TaskStatusPending)
+
+	task := &Task{Status: TaskStatusCompleted}
+	assert.False(t, task.CanTransition(TaskStatusPending))
+}
+
+func TestRetryBoundBlocksRequeue(t *testing.T) {
+	task := &Task{Status: TaskStatusFailed, Retries: 3, MaxRetries: 3}
+	assert.False(t, task.CanTransition(TaskStatusPending))
+	assert.True(t, task.CanTransition(TaskStatusCancelled))
+
+	task.MaxRetries = 0
+	assert.True(t, task.CanTransition(TaskStatusPending),
+		"zero MaxRetries means unbounded retries")
+}
+
+// TestStatusMachineProperties drives randomized walks over the state
+// machine and asserts its structural invariants.
+func TestStatusMachineProperties(t *testing.T) {
+	statuses := []TaskStatus{
+		TaskStatusPending, TaskStatusProcessing, TaskStatusCompleted,
+		TaskStatusFailed, TaskStatusExpired, TaskStatusCancelled,
+	}
+	table := TransitionTable()
+
+	t.Run("table agrees with CanTransition", func(t *testing.T) {
+		testkit.Property(t, 0, func(t *testing.T, r *rand.Rand) {
+			from, to := testkit.Pick(r, statuses...), testkit.Pick(r, statuses...)
+			task := &Task{Status: from}
+			inTable := false
+			for _, allowed := range table[from] {
+				if allowed == to {
+					inTable = true
+				}
+			}
+			if from == TaskStatusFailed && to == TaskStatusPending {
+				return // subject to the retry bound, checked separately
+			}
+			assert.Equal(t, inTable, task.CanTransition(to), "%s -> %s", from, to)
+		})
+	})
+
+	t.Run("terminal states cannot be resurrected", func(t *testing.T) {
+		testkit.Property(t, 0, func(t *testing.T, r *rand.Rand) {
+			task := &Task{Status: testkit.Pick(r, TaskStatusCompleted, TaskStatusExpired, TaskStatusCancelled)}
+			require.True(t, task.IsTerminal())
+			assert.False(t, task.CanTransition(testkit.Pick(r, statuses...)))
+		})
+	})
+
+	t.Run("walks end terminal with bounded retries", func(t *testing.T) {
+		testkit.Property(t, 0, func(t *testing.T, r *rand.Rand) {
+			task := &Task{Status: TaskStatusPending, MaxRetries: r.IntN(3) + 1}
+			for range 100 {
+				if task.IsTerminal() {
+					break
+				}
+				next := testkit.Pick(r, table[task.Status]...)
+				if !task.CanTransition(next) {
+					continue
+				}
+				if task.Status == TaskStatusFailed && next == TaskStatusPending {
+					task.Retries++
+				}
+				task.Status = next
+			}
+			assert.LessOrEqual(t, task.Retries, task.MaxRetries)
+			assert.True(t, IsValidStatus(task.Status))
+		})
+	})
+}
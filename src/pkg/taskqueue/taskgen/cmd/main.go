@@ -0,0 +1,38 @@
+// Command cmd is the go:generate entry point of taskgen. It reads a
+// JSON definitions file and writes the generated typed client source.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue/taskgen"
+)
+
+func main() {
+	defs := flag.String("defs", "tasks.json", "path to the task definitions document")
+	out := flag.String("out", "tasks_gen.go", "path of the generated file")
+	flag.Parse()
+
+	if err := run(*defs, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "taskgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(defsPath, outPath string) error {
+	data, err := os.ReadFile(defsPath)
+	if err != nil {
+		return err
+	}
+	definitions, err := taskgen.Parse(data)
+	if err != nil {
+		return err
+	}
+	source, err := definitions.Generate()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outPath, source, 0o644)
+}
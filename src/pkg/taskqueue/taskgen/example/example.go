@@ -0,0 +1,7 @@
+// Package example shows taskgen in use: tasks.json declares the task
+// types and the checked-in tasks_gen.go is the generator's output. It
+// doubles as a compile-time check that generated clients build against
+// the current core API.
+package example
+
+//go:generate go run github.com/kodflow/taskqueue/pkg/taskqueue/taskgen/cmd -defs tasks.json -out tasks_gen.go
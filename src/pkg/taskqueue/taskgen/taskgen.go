@@ -0,0 +1,245 @@
+// Package taskgen generates typed task clients from task type
+// definitions. Given a type's name and payload struct it emits a
+// Submit function, an Await function and an executor adapter, so
+// services get compile-time checked payloads instead of hand-rolled
+// map[string]any boilerplate for every task type.
+//
+// It is meant to run through go:generate:
+//
+//	//go:generate go run github.com/kodflow/taskqueue/pkg/taskqueue/taskgen/cmd -defs tasks.json -out tasks_gen.go
+//
+// where tasks.json holds a Definitions document.
+package taskgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// Definitions is the document a go:generate run consumes.
+type Definitions struct {
+	// Package is the package name of the generated file.
+	Package string `json:"package"`
+
+	// Types are the task types to generate clients for.
+	Types []TypeDef `json:"types"`
+}
+
+// TypeDef declares one task type and its payload shape.
+type TypeDef struct {
+	// Name is the task type string submitted to the queue, for example
+	// "email" or "report-export".
+	Name string `json:"name"`
+
+	// GoName is the identifier prefix of the generated declarations.
+	// Defaults to the camel-cased Name.
+	GoName string `json:"go_name,omitempty"`
+
+	// Fields describe the payload struct. An empty list generates a
+	// payload struct with no fields.
+	Fields []FieldDef `json:"fields,omitempty"`
+}
+
+// FieldDef declares one payload field.
+type FieldDef struct {
+	// Name is the exported Go field name.
+	Name string `json:"name"`
+
+	// Type is the Go type literal, for example "string" or "[]int".
+	Type string `json:"type"`
+
+	// JSON is the task data key the field maps to. Defaults to the
+	// lower-cased Name.
+	JSON string `json:"json,omitempty"`
+}
+
+// Parse decodes and validates a definitions document.
+func Parse(data []byte) (*Definitions, error) {
+	var defs Definitions
+	if err := json.Unmarshal(data, &defs); err != nil {
+		return nil, fmt.Errorf("taskgen: parse definitions: %w", err)
+	}
+	if err := defs.validate(); err != nil {
+		return nil, err
+	}
+	return &defs, nil
+}
+
+// validate checks the document and fills in the defaulted names.
+func (d *Definitions) validate() error {
+	if d.Package == "" {
+		return fmt.Errorf("taskgen: package is required")
+	}
+	if len(d.Types) == 0 {
+		return fmt.Errorf("taskgen: at least one type is required")
+	}
+	seen := make(map[string]struct{}, len(d.Types))
+	for i := range d.Types {
+		t := &d.Types[i]
+		if t.Name == "" {
+			return fmt.Errorf("taskgen: type %d has no name", i)
+		}
+		if _, dup := seen[t.Name]; dup {
+			return fmt.Errorf("taskgen: duplicate type %q", t.Name)
+		}
+		seen[t.Name] = struct{}{}
+		if t.GoName == "" {
+			t.GoName = camelCase(t.Name)
+		}
+		for j := range t.Fields {
+			f := &t.Fields[j]
+			if f.Name == "" || f.Type == "" {
+				return fmt.Errorf("taskgen: type %q field %d needs a name and a type", t.Name, j)
+			}
+			if f.JSON == "" {
+				f.JSON = strings.ToLower(f.Name)
+			}
+		}
+	}
+	sort.Slice(d.Types, func(i, j int) bool { return d.Types[i].Name < d.Types[j].Name })
+	return nil
+}
+
+// Generate renders the typed client source for the definitions, already
+// gofmt-formatted.
+func (d *Definitions) Generate() ([]byte, error) {
+	if err := d.validate(); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := clientTemplate.Execute(&buf, d); err != nil {
+		return nil, fmt.Errorf("taskgen: render: %w", err)
+	}
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("taskgen: format generated source: %w", err)
+	}
+	return source, nil
+}
+
+// camelCase turns a kebab- or snake-case task type name into an
+// exported Go identifier.
+func camelCase(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if r == '-' || r == '_' || r == '.' {
+			upper = true
+			continue
+		}
+		if upper {
+			b.WriteString(strings.ToUpper(string(r)))
+			upper = false
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// clientTemplate renders one file with a payload struct, Submit, Await
+// and executor adapter per task type.
+var clientTemplate = template.Must(template.New("client").Parse(`// Code generated by taskgen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue"
+)
+{{range .Types}}
+// {{.GoName}}TaskType is the task type string {{.GoName}} tasks are
+// submitted under.
+const {{.GoName}}TaskType = "{{.Name}}"
+
+// {{.GoName}}Payload is the typed payload of a {{.Name}} task.
+type {{.GoName}}Payload struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} ` + "`" + `json:"{{.JSON}}"` + "`" + `
+{{- end}}
+}
+
+// Submit{{.GoName}} submits a {{.Name}} task built from the payload and
+// returns the task ID.
+func Submit{{.GoName}}(ctx context.Context, w *taskqueue.Worker, payload {{.GoName}}Payload) (string, error) {
+	task, err := new{{.GoName}}Task(payload)
+	if err != nil {
+		return "", err
+	}
+	if err := w.SubmitTask(ctx, task); err != nil {
+		return "", err
+	}
+	return task.ID, nil
+}
+
+// Await{{.GoName}} submits a {{.Name}} task and blocks until its result
+// arrives or the context is done.
+func Await{{.GoName}}(ctx context.Context, w *taskqueue.Worker, payload {{.GoName}}Payload) (*taskqueue.TaskResult, error) {
+	task, err := new{{.GoName}}Task(payload)
+	if err != nil {
+		return nil, err
+	}
+	results, cancel := w.SubscribeResults(16)
+	defer cancel()
+	if err := w.SubmitTask(ctx, task); err != nil {
+		return nil, err
+	}
+	for {
+		select {
+		case result := <-results:
+			if result.TaskID == task.ID {
+				return result, nil
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// {{.GoName}}Executor adapts a typed handler to the Executor interface,
+// decoding the task data into the payload struct before each call.
+func {{.GoName}}Executor(handler func(context.Context, {{.GoName}}Payload) (*taskqueue.TaskResult, error)) taskqueue.Executor {
+	return taskqueue.ExecutorFunc(func(ctx context.Context, task *taskqueue.Task) (*taskqueue.TaskResult, error) {
+		payload, err := decode{{.GoName}}Payload(task)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, payload)
+	})
+}
+
+// new{{.GoName}}Task converts the payload into task data through JSON.
+func new{{.GoName}}Task(payload {{.GoName}}Payload) (*taskqueue.Task, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", taskqueue.ErrInvalidTask, err)
+	}
+	var data map[string]any
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("%w: %w", taskqueue.ErrInvalidTask, err)
+	}
+	return taskqueue.NewTask({{.GoName}}TaskType, data), nil
+}
+
+// decode{{.GoName}}Payload converts the task data back into the typed
+// payload through JSON.
+func decode{{.GoName}}Payload(task *taskqueue.Task) ({{.GoName}}Payload, error) {
+	var payload {{.GoName}}Payload
+	raw, err := json.Marshal(task.Data)
+	if err != nil {
+		return payload, fmt.Errorf("%w: %w", taskqueue.ErrInvalidTask, err)
+	}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return payload, fmt.Errorf("%w: %w", taskqueue.ErrInvalidTask, err)
+	}
+	return payload, nil
+}
+{{end}}`))
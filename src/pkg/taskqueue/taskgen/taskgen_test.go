@@ -0,0 +1,85 @@
+package taskgen
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{name: "missing package", doc: `{"types":[{"name":"email"}]}`, want: "package is required"},
+		{name: "no types", doc: `{"package":"tasks"}`, want: "at least one type"},
+		{name: "unnamed type", doc: `{"package":"tasks","types":[{}]}`, want: "has no name"},
+		{name: "duplicate type", doc: `{"package":"tasks","types":[{"name":"email"},{"name":"email"}]}`, want: "duplicate type"},
+		{
+			name: "field without type",
+			doc:  `{"package":"tasks","types":[{"name":"email","fields":[{"name":"To"}]}]}`,
+			want: "needs a name and a type",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.doc))
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestParseAppliesDefaults(t *testing.T) {
+	defs, err := Parse([]byte(`{"package":"tasks","types":[{"name":"report-export","fields":[{"name":"Format","type":"string"}]}]}`))
+	require.NoError(t, err)
+
+	require.Len(t, defs.Types, 1)
+	assert.Equal(t, "ReportExport", defs.Types[0].GoName)
+	assert.Equal(t, "format", defs.Types[0].Fields[0].JSON)
+}
+
+func TestGenerateEmitsTypedClient(t *testing.T) {
+	defs, err := Parse([]byte(`{
+		"package": "tasks",
+		"types": [
+			{"name": "email", "fields": [
+				{"name": "To", "type": "string"},
+				{"name": "Retries", "type": "int", "json": "retries"}
+			]},
+			{"name": "report-export"}
+		]
+	}`))
+	require.NoError(t, err)
+
+	source, err := defs.Generate()
+	require.NoError(t, err)
+
+	file, parseErr := parser.ParseFile(token.NewFileSet(), "tasks_gen.go", source, 0)
+	require.NoError(t, parseErr, "generated source must parse")
+	assert.Equal(t, "tasks", file.Name.Name)
+
+	for _, declaration := range []string{
+		"// Code generated by taskgen. DO NOT EDIT.",
+		`const EmailTaskType = "email"`,
+		"type EmailPayload struct {",
+		"To      string `json:\"to\"`",
+		"Retries int    `json:\"retries\"`",
+		"func SubmitEmail(ctx context.Context, w *taskqueue.Worker, payload EmailPayload) (string, error) {",
+		"func AwaitEmail(ctx context.Context, w *taskqueue.Worker, payload EmailPayload) (*taskqueue.TaskResult, error) {",
+		"func EmailExecutor(handler func(context.Context, EmailPayload) (*taskqueue.TaskResult, error)) taskqueue.Executor {",
+		`const ReportExportTaskType = "report-export"`,
+		"func SubmitReportExport(ctx context.Context, w *taskqueue.Worker, payload ReportExportPayload) (string, error) {",
+	} {
+		assert.Contains(t, string(source), declaration)
+	}
+}
+
+func TestCamelCase(t *testing.T) {
+	assert.Equal(t, "Email", camelCase("email"))
+	assert.Equal(t, "ReportExport", camelCase("report-export"))
+	assert.Equal(t, "FileMove", camelCase("file_move"))
+}
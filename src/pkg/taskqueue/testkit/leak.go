@@ -0,0 +1,92 @@
+// Package testkit provides shared helpers for the task queue's own
+// tests. It has no dependency on the core package.
+package testkit
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// defaultAllow lists stack fragments of goroutines the runtime and test
+// framework keep alive between tests; they are never reported as leaks.
+var defaultAllow = []string{
+	"testing.(*T).Run",
+	"testing.Main",
+	"testing.runTests",
+	"runtime.goexit",
+	"runtime/trace",
+	"os/signal.signal_recv",
+	"created by runtime",
+}
+
+// LeakCheck snapshots the running goroutines and, when the test
+// finishes, fails it if new goroutines are still alive. Call it first
+// thing in lifecycle tests, before Start, so everything spawned by the
+// code under test is attributed to it. Stacks containing any of the
+// allow fragments are ignored on top of the built-in runtime and
+// testing allowlist. The check retries briefly so goroutines still
+// winding down after Shutdown are not misreported.
+func LeakCheck(t *testing.T, allow ...string) {
+	t.Helper()
+	before := goroutineCounts()
+
+	t.Cleanup(func() {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		var leaked []string
+		for {
+			leaked = leaked[:0]
+			for stack, count := range goroutineCounts() {
+				if count <= before[stack] || allowed(stack, allow) {
+					continue
+				}
+				leaked = append(leaked, fmt.Sprintf("%d leaked goroutine(s):\n%s", count-before[stack], stack))
+			}
+			if len(leaked) == 0 || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		for _, leak := range leaked {
+			t.Error(leak)
+		}
+	})
+}
+
+// goroutineCounts returns how many live goroutines share each stack,
+// keyed by the stack text with the goroutine ID stripped.
+func goroutineCounts() map[string]int {
+	buf := make([]byte, 1<<20)
+	n := runtime.Stack(buf, true)
+	counts := make(map[string]int)
+	for _, stack := range strings.Split(strings.TrimSpace(string(buf[:n])), "\n\n") {
+		if allowed(stack, nil) {
+			continue
+		}
+		// Drop the "goroutine N [state]:" header so identical stacks
+		// collapse regardless of ID.
+		if _, body, found := strings.Cut(stack, "\n"); found {
+			counts[body]++
+		}
+	}
+	return counts
+}
+
+// allowed reports whether the stack matches the built-in or caller
+// allowlist.
+func allowed(stack string, allow []string) bool {
+	for _, fragment := range defaultAllow {
+		if strings.Contains(stack, fragment) {
+			return true
+		}
+	}
+	for _, fragment := range allow {
+		if strings.Contains(stack, fragment) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package testkit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLeakCheckPassesWhenGoroutinesStop(t *testing.T) {
+	LeakCheck(t)
+
+	done := make(chan struct{})
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(done)
+	}()
+	<-done
+}
+
+func TestLeakCheckAllowlist(t *testing.T) {
+	LeakCheck(t, "testkit.slowWorker")
+
+	go slowWorker()
+}
+
+// slowWorker outlives its test on purpose; the allowlist covers it.
+func slowWorker() {
+	time.Sleep(500 * time.Millisecond)
+}
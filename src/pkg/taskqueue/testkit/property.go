@@ -0,0 +1,29 @@
+package testkit
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// DefaultPropertyRuns is how many cases Property generates per test.
+const DefaultPropertyRuns = 200
+
+// Property runs fn against runs generated cases (DefaultPropertyRuns
+// when runs is not positive), each with a deterministically seeded
+// random source so a failing case can be replayed from its subtest name.
+func Property(t *testing.T, runs int, fn func(t *testing.T, r *rand.Rand)) {
+	t.Helper()
+	if runs <= 0 {
+		runs = DefaultPropertyRuns
+	}
+	for seed := range runs {
+		t.Run("", func(t *testing.T) {
+			fn(t, rand.New(rand.NewPCG(uint64(seed), 0)))
+		})
+	}
+}
+
+// Pick returns a uniformly random element of choices.
+func Pick[T any](r *rand.Rand, choices ...T) T {
+	return choices[r.IntN(len(choices))]
+}
@@ -0,0 +1,144 @@
+package taskqueue
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TopicLimit caps one topic's publish traffic.
+type TopicLimit struct {
+	// Rate is the sustained publishes per second. Zero means unlimited.
+	Rate float64
+
+	// Burst is the token bucket size. Defaults to 1.
+	Burst int
+
+	// Concurrency caps in-flight publishes to the topic. Zero means
+	// unlimited.
+	Concurrency int
+}
+
+// ThrottleStats are the per-topic counters of a ThrottledPublisher.
+type ThrottleStats struct {
+	// Queued counts publishes that had to wait for a token or slot.
+	Queued uint64 `json:"queued"`
+
+	// Shed counts publishes abandoned because their context ended while
+	// waiting.
+	Shed uint64 `json:"shed"`
+}
+
+// topicState is the limiter state of one topic.
+type topicState struct {
+	limit  TopicLimit
+	sem    chan struct{}
+	queued atomic.Uint64
+	shed   atomic.Uint64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// ThrottledPublisher wraps a Publisher with per-topic rate limits and
+// concurrency caps so a burst of results cannot overwhelm a fragile
+// downstream topic. Publishes over the limit queue until their context
+// ends, then count as shed. Topics without a limit pass through.
+type ThrottledPublisher struct {
+	next   Publisher
+	topics map[string]*topicState
+}
+
+// NewThrottledPublisher wraps next with the given per-topic limits.
+// Register all limits at construction; the map is not locked.
+func NewThrottledPublisher(next Publisher, limits map[string]TopicLimit) *ThrottledPublisher {
+	topics := make(map[string]*topicState, len(limits))
+	for topic, limit := range limits {
+		if limit.Burst <= 0 {
+			limit.Burst = 1
+		}
+		state := &topicState{limit: limit, tokens: float64(limit.Burst), last: time.Now()}
+		if limit.Concurrency > 0 {
+			state.sem = make(chan struct{}, limit.Concurrency)
+		}
+		topics[topic] = state
+	}
+	return &ThrottledPublisher{next: next, topics: topics}
+}
+
+// Publish implements Publisher, enforcing the topic's limit.
+func (p *ThrottledPublisher) Publish(ctx context.Context, topic string, payload []byte) error {
+	state, ok := p.topics[topic]
+	if !ok {
+		return p.next.Publish(ctx, topic, payload)
+	}
+	if state.sem != nil {
+		select {
+		case state.sem <- struct{}{}:
+		default:
+			state.queued.Add(1)
+			select {
+			case state.sem <- struct{}{}:
+			case <-ctx.Done():
+				state.shed.Add(1)
+				return ctx.Err()
+			}
+		}
+		defer func() { <-state.sem }()
+	}
+	if err := state.wait(ctx); err != nil {
+		return err
+	}
+	return p.next.Publish(ctx, topic, payload)
+}
+
+// wait blocks until the topic's bucket grants a token or ctx ends.
+func (s *topicState) wait(ctx context.Context) error {
+	if s.limit.Rate <= 0 {
+		return nil
+	}
+	waited := false
+	for {
+		s.mu.Lock()
+		now := time.Now()
+		s.tokens += now.Sub(s.last).Seconds() * s.limit.Rate
+		if s.tokens > float64(s.limit.Burst) {
+			s.tokens = float64(s.limit.Burst)
+		}
+		s.last = now
+		if s.tokens >= 1 {
+			s.tokens--
+			s.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - s.tokens) / s.limit.Rate * float64(time.Second))
+		s.mu.Unlock()
+
+		if !waited {
+			waited = true
+			s.queued.Add(1)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			s.shed.Add(1)
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Stats returns the per-topic queued and shed counters.
+func (p *ThrottledPublisher) Stats() map[string]ThrottleStats {
+	stats := make(map[string]ThrottleStats, len(p.topics))
+	for topic, state := range p.topics {
+		stats[topic] = ThrottleStats{
+			Queued: state.queued.Load(),
+			Shed:   state.shed.Load(),
+		}
+	}
+	return stats
+}
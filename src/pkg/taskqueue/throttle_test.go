@@ -0,0 +1,96 @@
+package taskqueue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThrottledPublisherPassesUnlimitedTopics(t *testing.T) {
+	sink := newMockPublisher()
+	publisher := NewThrottledPublisher(sink, map[string]TopicLimit{"task.shed": {Rate: 1}})
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, publisher.Publish(context.Background(), "task.results", []byte("r")))
+	}
+	assert.Len(t, sink.messages["task.results"], 5)
+	assert.Empty(t, publisher.Stats()["task.results"])
+}
+
+func TestThrottledPublisherPacesAndCountsQueued(t *testing.T) {
+	sink := newMockPublisher()
+	publisher := NewThrottledPublisher(sink, map[string]TopicLimit{
+		"task.results": {Rate: 50, Burst: 1},
+	})
+
+	ctx := context.Background()
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, publisher.Publish(ctx, "task.results", []byte("r")))
+	}
+	// Burst of one, then two paced publishes at 50/s: at least ~40ms.
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+	assert.Len(t, sink.messages["task.results"], 3)
+	assert.EqualValues(t, 2, publisher.Stats()["task.results"].Queued)
+}
+
+func TestThrottledPublisherShedsOnContextEnd(t *testing.T) {
+	sink := newMockPublisher()
+	publisher := NewThrottledPublisher(sink, map[string]TopicLimit{
+		"task.results": {Rate: 0.1, Burst: 1},
+	})
+
+	require.NoError(t, publisher.Publish(context.Background(), "task.results", []byte("r")))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := publisher.Publish(ctx, "task.results", []byte("r"))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.EqualValues(t, 1, publisher.Stats()["task.results"].Shed)
+	assert.Len(t, sink.messages["task.results"], 1)
+}
+
+func TestThrottledPublisherCapsConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	slow := publisherFunc(func(ctx context.Context, topic string, payload []byte) error {
+		mu.Lock()
+		inFlight++
+		if inFlight > peak {
+			peak = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return nil
+	})
+	publisher := NewThrottledPublisher(slow, map[string]TopicLimit{
+		"task.results": {Concurrency: 2},
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, publisher.Publish(context.Background(), "task.results", []byte("r")))
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, peak, 2)
+	assert.Positive(t, publisher.Stats()["task.results"].Queued)
+}
+
+// publisherFunc adapts a function to the Publisher interface.
+type publisherFunc func(ctx context.Context, topic string, payload []byte) error
+
+func (f publisherFunc) Publish(ctx context.Context, topic string, payload []byte) error {
+	return f(ctx, topic, payload)
+}
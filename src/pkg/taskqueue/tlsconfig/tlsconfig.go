@@ -0,0 +1,184 @@
+// Package tlsconfig builds TLS and mutual-TLS configurations for the task
+// queue's network surfaces (admin HTTP, gRPC, broker adapters), with
+// certificate reload on SIGHUP or file change so rotations do not require
+// a restart.
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Config describes the TLS material for one listener or client.
+type Config struct {
+	// CertFile and KeyFile hold the PEM certificate and private key.
+	CertFile string
+	KeyFile  string
+
+	// ClientCAFile, when set on a server, enables mutual TLS: client
+	// certificates must chain to one of these CAs.
+	ClientCAFile string
+
+	// ReloadInterval is how often the files are polled for changes.
+	// Defaults to one minute; SIGHUP always forces a reload.
+	ReloadInterval time.Duration
+}
+
+// CertReloader serves the current certificate and transparently swaps it
+// when the underlying files change.
+type CertReloader struct {
+	certFile string
+	keyFile  string
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+// NewCertReloader loads the initial certificate pair.
+func NewCertReloader(certFile, keyFile string) (*CertReloader, error) {
+	r := &CertReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload re-reads the certificate files unconditionally.
+func (r *CertReloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("tlsconfig: load key pair: %w", err)
+	}
+	info, err := os.Stat(r.certFile)
+	if err != nil {
+		return fmt.Errorf("tlsconfig: stat cert: %w", err)
+	}
+	r.mu.Lock()
+	r.cert = &cert
+	r.modTime = info.ModTime()
+	r.mu.Unlock()
+	return nil
+}
+
+// reloadIfChanged reloads when the certificate file's mtime moved.
+func (r *CertReloader) reloadIfChanged() error {
+	info, err := os.Stat(r.certFile)
+	if err != nil {
+		return err
+	}
+	r.mu.RLock()
+	changed := info.ModTime().After(r.modTime)
+	r.mu.RUnlock()
+	if !changed {
+		return nil
+	}
+	return r.Reload()
+}
+
+// GetCertificate is the tls.Config callback serving the current cert.
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// GetClientCertificate is the client-side tls.Config callback.
+func (r *CertReloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// Watch polls for file changes and listens for SIGHUP until ctx ends.
+// Reload failures keep the previous certificate and are reported through
+// onError (which may be nil).
+func (r *CertReloader) Watch(ctx context.Context, interval time.Duration, onError func(error)) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			err = r.Reload()
+		case <-ticker.C:
+			err = r.reloadIfChanged()
+		}
+		if err != nil && onError != nil {
+			onError(err)
+		}
+	}
+}
+
+// Server builds a *tls.Config for a listener from cfg, wiring the
+// reloader in and enabling mutual TLS when a client CA file is set. The
+// returned reloader should be watched with Watch for rotation.
+func Server(cfg Config) (*tls.Config, *CertReloader, error) {
+	reloader, err := NewCertReloader(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	tlsCfg := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.GetCertificate,
+	}
+	if cfg.ClientCAFile != "" {
+		pool, err := loadCertPool(cfg.ClientCAFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		tlsCfg.ClientCAs = pool
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsCfg, reloader, nil
+}
+
+// Client builds a *tls.Config for an outbound connection presenting the
+// certificate in cfg. ClientCAFile, when set, overrides the system roots
+// used to verify the server.
+func Client(cfg Config) (*tls.Config, *CertReloader, error) {
+	reloader, err := NewCertReloader(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	tlsCfg := &tls.Config{
+		MinVersion:           tls.VersionTLS12,
+		GetClientCertificate: reloader.GetClientCertificate,
+	}
+	if cfg.ClientCAFile != "" {
+		pool, err := loadCertPool(cfg.ClientCAFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		tlsCfg.RootCAs = pool
+	}
+	return tlsCfg, reloader, nil
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("tlsconfig: read CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("tlsconfig: no certificates in %s", path)
+	}
+	return pool, nil
+}
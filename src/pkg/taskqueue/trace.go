@@ -0,0 +1,110 @@
+package taskqueue
+
+import (
+	"context"
+	"time"
+)
+
+// Metadata keys used to carry trace context across the queue hand-off,
+// since the submitting goroutine and the processing goroutine never share
+// a call stack.
+const (
+	TraceIDMetadataKey    = "taskqueue.trace_id"
+	submitSpanMetadataKey = "taskqueue.submit_span"
+	enqueuedAtMetadataKey = "taskqueue.enqueued_at"
+)
+
+// Span records one traced phase of a task's journey through the queue.
+// Links reference the span IDs of related phases, so backends can connect
+// the submit, queue and process spans of one task end to end.
+type Span struct {
+	TraceID string    `json:"trace_id"`
+	SpanID  string    `json:"span_id"`
+	Name    string    `json:"name"`
+	TaskID  string    `json:"task_id"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Links   []string  `json:"links,omitempty"`
+}
+
+// TraceSink receives completed spans. Implementations bridge to a tracing
+// backend; they must be safe for concurrent use.
+type TraceSink interface {
+	Record(ctx context.Context, span Span)
+}
+
+// traceSubmit stamps the task with trace context and records the submit
+// span, marking the moment the task entered the queue.
+func (w *Worker) traceSubmit(ctx context.Context, task *Task) {
+	if w.config.Tracer == nil {
+		return
+	}
+	now := time.Now().UTC()
+	spanID := newTaskID()
+	if task.Metadata == nil {
+		task.Metadata = make(map[string]string, 3)
+	}
+	if task.Metadata[TraceIDMetadataKey] == "" {
+		task.Metadata[TraceIDMetadataKey] = newTaskID()
+	}
+	task.Metadata[submitSpanMetadataKey] = spanID
+	task.Metadata[enqueuedAtMetadataKey] = now.Format(time.RFC3339Nano)
+	w.config.Tracer.Record(ctx, Span{
+		TraceID: task.Metadata[TraceIDMetadataKey],
+		SpanID:  spanID,
+		Name:    "task.submit",
+		TaskID:  task.ID,
+		Start:   now,
+		End:     now,
+	})
+}
+
+// traceDequeue records the queue span covering the time between enqueue
+// and the start of processing, linked to the submit span, and returns the
+// span ID the process span should link to. It also feeds the queue wait
+// into the metrics collector.
+func (w *Worker) traceDequeue(ctx context.Context, task *Task, start time.Time) string {
+	enqueuedAt, err := time.Parse(time.RFC3339Nano, task.Metadata[enqueuedAtMetadataKey])
+	if err != nil {
+		return ""
+	}
+	if w.config.Metrics != nil {
+		w.config.Metrics.Observe("task.queue_wait", start.Sub(enqueuedAt))
+	}
+	if w.config.Tracer == nil {
+		return ""
+	}
+	spanID := newTaskID()
+	span := Span{
+		TraceID: task.Metadata[TraceIDMetadataKey],
+		SpanID:  spanID,
+		Name:    "task.queue",
+		TaskID:  task.ID,
+		Start:   enqueuedAt,
+		End:     start,
+	}
+	if submitSpan := task.Metadata[submitSpanMetadataKey]; submitSpan != "" {
+		span.Links = []string{submitSpan}
+	}
+	w.config.Tracer.Record(ctx, span)
+	return spanID
+}
+
+// traceProcess records the processing span linked to the queue span.
+func (w *Worker) traceProcess(ctx context.Context, task *Task, queueSpan string, start, end time.Time) {
+	if w.config.Tracer == nil {
+		return
+	}
+	span := Span{
+		TraceID: task.Metadata[TraceIDMetadataKey],
+		SpanID:  newTaskID(),
+		Name:    "task.process",
+		TaskID:  task.ID,
+		Start:   start,
+		End:     end,
+	}
+	if queueSpan != "" {
+		span.Links = []string{queueSpan}
+	}
+	w.config.Tracer.Record(ctx, span)
+}
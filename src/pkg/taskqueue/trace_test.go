@@ -0,0 +1,78 @@
+package taskqueue
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockTraceSink collects recorded spans.
+type mockTraceSink struct {
+	mu    sync.Mutex
+	spans []Span
+}
+
+func (s *mockTraceSink) Record(_ context.Context, span Span) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.spans = append(s.spans, span)
+}
+
+func (s *mockTraceSink) byName(name string) []Span {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var matches []Span
+	for _, span := range s.spans {
+		if span.Name == name {
+			matches = append(matches, span)
+		}
+	}
+	return matches
+}
+
+func TestQueueHandOffSpansAreLinked(t *testing.T) {
+	sink := &mockTraceSink{}
+	worker := NewWorker(WorkerConfig{WorkerCount: 1, Tracer: sink}, newMockRepository(), nil)
+	worker.Executors().Register("ping", ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		return &TaskResult{}, nil
+	}))
+
+	ctx := context.Background()
+	task := NewTask("ping", nil)
+	require.NoError(t, worker.SubmitTask(ctx, task))
+	worker.Start()
+	require.NoError(t, worker.Shutdown(ctx))
+
+	submits := sink.byName("task.submit")
+	queues := sink.byName("task.queue")
+	processes := sink.byName("task.process")
+	require.Len(t, submits, 1)
+	require.Len(t, queues, 1)
+	require.Len(t, processes, 1)
+
+	// All three spans belong to one trace and chain via links.
+	assert.Equal(t, submits[0].TraceID, queues[0].TraceID)
+	assert.Equal(t, queues[0].TraceID, processes[0].TraceID)
+	assert.Equal(t, []string{submits[0].SpanID}, queues[0].Links)
+	assert.Equal(t, []string{queues[0].SpanID}, processes[0].Links)
+
+	// The queue span covers enqueue to processing start.
+	assert.False(t, queues[0].End.Before(queues[0].Start))
+	assert.Equal(t, task.ID, queues[0].TaskID)
+}
+
+func TestTraceSubmitKeepsCallerTraceID(t *testing.T) {
+	sink := &mockTraceSink{}
+	worker := NewWorker(WorkerConfig{Tracer: sink}, newMockRepository(), nil)
+
+	task := NewTask("ping", nil)
+	task.Metadata = map[string]string{TraceIDMetadataKey: "trace-from-caller"}
+	require.NoError(t, worker.SubmitTask(context.Background(), task))
+
+	submits := sink.byName("task.submit")
+	require.Len(t, submits, 1)
+	assert.Equal(t, "trace-from-caller", submits[0].TraceID)
+}
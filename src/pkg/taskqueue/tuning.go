@@ -0,0 +1,89 @@
+package taskqueue
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime"
+)
+
+// TuningRecommendation is the analyzer's suggested configuration, with the
+// observations that led to it so operators can learn the right settings.
+type TuningRecommendation struct {
+	BufferSize  int      `json:"buffer_size"`
+	WorkerCount int      `json:"worker_count"`
+	Reasons     []string `json:"reasons"`
+}
+
+// TuningAnalyzer observes rejection rate, queue pressure and process
+// memory and recommends buffer and worker count adjustments. The current
+// buffer is fixed at construction time, so recommendations apply to the
+// next restart (or reconfiguration once queue backends are swappable).
+type TuningAnalyzer struct {
+	worker *Worker
+	logger *slog.Logger
+}
+
+// NewTuningAnalyzer builds an analyzer for the given worker.
+func NewTuningAnalyzer(worker *Worker, logger *slog.Logger) *TuningAnalyzer {
+	if logger == nil {
+		logger = worker.logger
+	}
+	return &TuningAnalyzer{worker: worker, logger: logger}
+}
+
+// Analyze inspects the current worker state and returns a recommendation,
+// logging the reasoning behind every adjustment.
+func (a *TuningAnalyzer) Analyze() TuningRecommendation {
+	cfg := a.worker.config
+	stats := a.worker.Stats()
+	rec := TuningRecommendation{BufferSize: cfg.BufferSize, WorkerCount: cfg.WorkerCount}
+
+	total := stats.Submitted + stats.Rejected
+	if total > 0 {
+		rejectionRate := float64(stats.Rejected) / float64(total)
+		if rejectionRate > 0.01 {
+			rec.BufferSize = cfg.BufferSize * 2
+			rec.Reasons = append(rec.Reasons, fmt.Sprintf(
+				"rejection rate %.1f%% above 1%%: doubling buffer from %d to %d",
+				rejectionRate*100, cfg.BufferSize, rec.BufferSize))
+		}
+	}
+
+	depth := a.worker.QueueDepth()
+	if depth > cfg.BufferSize*3/4 {
+		rec.WorkerCount = cfg.WorkerCount + cfg.WorkerCount/2 + 1
+		rec.Reasons = append(rec.Reasons, fmt.Sprintf(
+			"queue depth %d above 75%% of buffer %d: raising workers from %d to %d",
+			depth, cfg.BufferSize, cfg.WorkerCount, rec.WorkerCount))
+	}
+
+	if cfg.Metrics != nil {
+		wait := cfg.Metrics.Duration("task.process")
+		if wait.Count > 0 && wait.Max > cfg.ProcessTimeout/2 {
+			rec.Reasons = append(rec.Reasons, fmt.Sprintf(
+				"max processing time %s is over half of the %s timeout: consider slower-task isolation before resizing",
+				wait.Max, cfg.ProcessTimeout))
+		}
+	}
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	if rec.BufferSize > cfg.BufferSize && depth > 0 && mem.Sys > 0 {
+		perTask := mem.HeapAlloc / uint64(depth)
+		projected := perTask * uint64(rec.BufferSize)
+		if projected > mem.Sys/2 {
+			rec.BufferSize = cfg.BufferSize
+			rec.Reasons = append(rec.Reasons, fmt.Sprintf(
+				"projected buffer memory %d bytes exceeds half of %d: keeping buffer at %d",
+				projected, mem.Sys, cfg.BufferSize))
+		}
+	}
+
+	if len(rec.Reasons) == 0 {
+		rec.Reasons = append(rec.Reasons, "no pressure observed: current settings look right")
+	}
+	for _, reason := range rec.Reasons {
+		a.logger.Info("taskqueue: tuning", "reason", reason)
+	}
+	return rec
+}
@@ -0,0 +1,26 @@
+package taskqueue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTuningAnalyzerRecommendsLargerBuffer(t *testing.T) {
+	w := NewWorker(WorkerConfig{BufferSize: 10}, newMockRepository(), newMockPublisher())
+	w.stats.submitted.Add(90)
+	w.stats.rejected.Add(10)
+
+	rec := NewTuningAnalyzer(w, nil).Analyze()
+	assert.Equal(t, 20, rec.BufferSize)
+	assert.NotEmpty(t, rec.Reasons)
+}
+
+func TestTuningAnalyzerQuietWhenHealthy(t *testing.T) {
+	w := NewWorker(WorkerConfig{BufferSize: 10}, newMockRepository(), newMockPublisher())
+	w.stats.submitted.Add(100)
+
+	rec := NewTuningAnalyzer(w, nil).Analyze()
+	assert.Equal(t, 10, rec.BufferSize)
+	assert.Equal(t, w.config.WorkerCount, rec.WorkerCount)
+}
@@ -0,0 +1,732 @@
+package taskqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue/metrics"
+	"github.com/kodflow/taskqueue/pkg/taskqueue/syncmap"
+)
+
+// Defaults applied by NewWorker when the corresponding config field is
+// zero. The default worker count is not a constant: it is derived at
+// Start from GOMAXPROCS and the registered executors' workload classes.
+const (
+	DefaultBufferSize     = 100
+	DefaultProcessTimeout = 30 * time.Second
+)
+
+// WorkerConfig tunes a Worker pool.
+type WorkerConfig struct {
+	// WorkerCount is the number of processing goroutines. When zero it is
+	// derived at Start from GOMAXPROCS and the registered executors'
+	// workload classes; see WorkerCountDecision.
+	WorkerCount int
+
+	// BufferSize is the capacity of the in-memory task buffer.
+	BufferSize int
+
+	// ProcessTimeout bounds the execution of a single task.
+	ProcessTimeout time.Duration
+
+	// Logger receives worker lifecycle and task logs. Defaults to
+	// slog.Default.
+	Logger *slog.Logger
+
+	// Metrics, when set, receives task counters and processing durations.
+	Metrics *metrics.Collector
+
+	// OverflowDir, when set, enables spilling submissions to a disk-backed
+	// overflow queue in that directory instead of rejecting them when the
+	// buffer is full.
+	OverflowDir string
+
+	// OverflowSegmentSize is the number of tasks per overflow segment
+	// file. Defaults to DefaultOverflowSegmentSize.
+	OverflowSegmentSize int
+
+	// Queue is the buffering discipline between submission and the
+	// worker pool. Defaults to a channel-backed FIFO of BufferSize.
+	Queue Queue
+
+	// Ordering selects the dequeue discipline of the default buffer when
+	// Queue is unset: FIFO (the default), LIFO or priority.
+	Ordering TaskOrdering
+
+	// Redactor masks sensitive task data in logs and published messages.
+	// Defaults to a redactor for DefaultRedactedKeys.
+	Redactor *Redactor
+
+	// Signer, when set, signs tasks at submission and verifies them
+	// before execution; tasks failing verification are failed without
+	// being executed.
+	Signer Signer
+
+	// Governor, when set, sheds submissions with ErrMemoryPressure while
+	// the process is close to its memory limit. Run it alongside the
+	// worker with Governor.Run.
+	Governor *MemoryGovernor
+
+	// Policies, when set, supplies per-task-type defaults for timeout,
+	// retries, priority and concurrency, consulted at submission and
+	// execution time.
+	Policies *TypePolicies
+
+	// Results, when set, stores every task result for later querying via
+	// GetResult and ListResults.
+	Results ResultRepository
+
+	// Tracer, when set, receives submit, queue and process spans with
+	// links connecting them, so traces show where task latency was spent.
+	Tracer TraceSink
+
+	// Flags, when set, is consulted at dispatch so behavior (such as
+	// disabling a task type) can be changed at runtime from an external
+	// flag system.
+	Flags FlagProvider
+
+	// Experiments deterministically bucket tasks into variants exposed
+	// to executors via the context, with per-variant outcome stats.
+	Experiments []*Experiment
+
+	// Costs, when set, accumulates per-task cost units (processing
+	// duration × per-type weight) aggregated by namespace.
+	Costs *CostAccountant
+
+	// SLOs, when set, evaluates task outcomes against service level
+	// objectives and emits error budget events.
+	SLOs *SLOTracker
+
+	// Bus receives the worker's lifecycle events; SLO trackers and
+	// governors can publish to it too so every consumer subscribes in
+	// one place. Defaults to a new bus, available via Bus().
+	Bus *EventBus
+
+	// Queues declares additional named queues, each with its own buffer
+	// and worker allocation, addressed via WithQueue at submission. The
+	// "default" queue always exists and is backed by Queue/BufferSize.
+	Queues map[string]QueueConfig
+
+	// RepositoryTimeout, when set, bounds every repository call with
+	// this deadline, records call durations to Metrics and retries
+	// idempotent reads.
+	RepositoryTimeout time.Duration
+
+	// RepositoryListeners are notified after every successful Save and
+	// UpdateStatus so secondary indexes stay in sync without polling.
+	// See RepositoryListener for the delivery semantics.
+	RepositoryListeners []RepositoryListener
+
+	// Search, when set, powers SearchTasks. Indexers that also implement
+	// RepositoryListener are kept in sync with repository writes
+	// automatically.
+	Search SearchIndexer
+
+	// Retry, when set, re-enqueues failed tasks with backoff up to their
+	// MaxRetries budget instead of leaving them failed.
+	Retry *RetryPolicy
+
+	// RetryBudget, when set, caps retries relative to fresh traffic;
+	// failures beyond the budget skip the retry policy and take the
+	// normal failure path, dead-lettering when DeadLetters is set.
+	RetryBudget *RetryBudgetConfig
+
+	// DeadLetters, when set, parks permanently failed tasks for
+	// inspection and replay instead of dropping them, announcing each on
+	// the DeadLetterTopic.
+	DeadLetters DeadLetterQueue
+
+	// Idempotency, when set, replays the cached result of a completed
+	// task for resubmissions carrying the same idempotency key instead of
+	// executing them again.
+	Idempotency *IdempotencyConfig
+
+	// Speculation, when set, duplicates attempts of the declared task
+	// types once they run past their observed p99, racing the two and
+	// keeping the first successful result.
+	Speculation *SpeculationConfig
+
+	// Heartbeat, when set, periodically publishes a liveness envelope to
+	// the HeartbeatTopic so fleet dashboards can see which worker
+	// instances are alive.
+	Heartbeat *HeartbeatConfig
+
+	// TracerProvider, when set, wraps task submission, processing,
+	// execution and result publication in spans, with the trace ID from
+	// WithTraceID carried across the queue hand-off. It complements
+	// Tracer, which receives the queue-internal latency spans.
+	TracerProvider TracerProvider
+
+	// Router, when set, overrides the result publication topics per task
+	// type, fanning one result out to several topics. Unrouted types keep
+	// the ResultTopic default.
+	Router *ResultRouter
+
+	// ResultEncoder selects which result fields published messages carry.
+	// Defaults to DefaultResultEncoder, which includes every field.
+	ResultEncoder *ResultEncoder
+
+	// Codec, when set, serializes published result messages in its
+	// format instead of the JSON ResultEncoder path. Resolve one from
+	// configuration with CodecByName.
+	Codec Codec
+
+	// RateLimiter, when set, throttles submissions: SubmitTask returns
+	// ErrRateLimited for anything over the configured rate. Ships with
+	// token bucket and leaky bucket implementations.
+	RateLimiter RateLimiter
+
+	// Dedup, when set, rejects resubmissions sharing a dedup key within
+	// the TTL window with ErrDuplicateTask, so producer retries do not
+	// execute a task twice.
+	Dedup *DedupConfig
+}
+
+// Worker is a pool of goroutines that processes submitted tasks, persisting
+// every lifecycle transition through the repository and publishing results.
+type Worker struct {
+	config    WorkerConfig
+	repo      TaskRepository
+	publisher Publisher
+	executors *ExecutorRegistry
+	cache     *TaskCache
+	registry  *WorkerRegistry
+	statuses  *StatusIndex
+	stats     *WorkerStats
+	failures  *failureStats
+	sla       *slaStats
+	bus       *EventBus
+	logger    *slog.Logger
+
+	queue          Queue
+	named          map[string]*namedQueue
+	moves          syncmap.Map[string, string]
+	holds          syncmap.Map[string, *Task]
+	groups         syncmap.Map[string, *groupState]
+	scatters       syncmap.Map[string, *scatterState]
+	cancels        syncmap.Map[string, context.CancelFunc]
+	cancelRequests syncmap.Map[string, struct{}]
+	progress       syncmap.Map[string, TaskProgress]
+	slots          slotMap
+	pace           atomic.Pointer[time.Ticker]
+	gate           atomic.Pointer[chan struct{}]
+	stopCh         chan struct{}
+	wg             sync.WaitGroup
+	drainWg        sync.WaitGroup
+	overflow       *overflowQueue
+	carry          *Task
+
+	idempotent  *idempotencyCache
+	speculation *speculator
+	dedup       *Deduplicator
+	retryBudget *retryBudget
+	instanceID  string
+
+	mu           sync.Mutex
+	started      bool
+	stopped      bool
+	draining     bool
+	decision     WorkerCountDecision
+	poolSize     int
+	shrink       int
+	nextWorkerID int
+}
+
+// NewWorker builds a Worker with the given dependencies, applying defaults
+// for any zero config fields.
+func NewWorker(config WorkerConfig, repo TaskRepository, publisher Publisher) *Worker {
+	if config.BufferSize <= 0 {
+		config.BufferSize = DefaultBufferSize
+	}
+	if config.ProcessTimeout <= 0 {
+		config.ProcessTimeout = DefaultProcessTimeout
+	}
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+	if config.Redactor == nil {
+		config.Redactor = defaultRedactor
+	}
+	if config.ResultEncoder == nil {
+		encoder := DefaultResultEncoder()
+		config.ResultEncoder = &encoder
+	}
+	if config.Bus == nil {
+		config.Bus = NewEventBus()
+	}
+	if listener, ok := config.Search.(RepositoryListener); ok {
+		config.RepositoryListeners = append(config.RepositoryListeners, listener)
+	}
+	if len(config.RepositoryListeners) > 0 {
+		repo = ListenRepository(repo, config.RepositoryListeners...)
+	}
+	if config.RepositoryTimeout > 0 {
+		repo = newGuardedRepository(repo, config.RepositoryTimeout, config.Metrics)
+	}
+	w := &Worker{
+		config:    config,
+		repo:      repo,
+		publisher: publisher,
+		executors: NewExecutorRegistry(),
+		cache:     NewTaskCache(),
+		registry:  NewWorkerRegistry(),
+		statuses:  NewStatusIndex(),
+		stats:     &WorkerStats{},
+		failures:  newFailureStats(),
+		sla:       newSLAStats(),
+		bus:       config.Bus,
+		logger:    config.Logger,
+		queue:     config.Queue,
+		stopCh:    make(chan struct{}),
+	}
+	if w.queue == nil {
+		w.queue = newOrderedQueue(config.Ordering, config.BufferSize)
+	}
+	w.named = map[string]*namedQueue{
+		DefaultQueueName: {name: DefaultQueueName, queue: w.queue},
+	}
+	for name, qc := range config.Queues {
+		if name == DefaultQueueName {
+			continue
+		}
+		if qc.Buffer <= 0 {
+			qc.Buffer = config.BufferSize
+		}
+		if qc.Workers <= 0 {
+			qc.Workers = 1
+		}
+		w.named[name] = &namedQueue{name: name, queue: newOrderedQueue(qc.Ordering, qc.Buffer), workers: qc.Workers}
+	}
+	if config.WorkerCount > 0 {
+		w.decision = WorkerCountDecision{
+			WorkerCount: config.WorkerCount,
+			Configured:  true,
+			Reason:      "worker count set explicitly in config",
+		}
+	}
+	if config.Idempotency != nil {
+		w.idempotent = newIdempotencyCache(*config.Idempotency)
+	}
+	if config.Speculation != nil {
+		w.speculation = newSpeculator(*config.Speculation)
+	}
+	if config.Dedup != nil {
+		w.dedup = NewDeduplicator(*config.Dedup)
+	}
+	if config.RetryBudget != nil {
+		w.retryBudget = newRetryBudget(*config.RetryBudget)
+	}
+	if config.Heartbeat != nil {
+		w.instanceID = config.Heartbeat.InstanceID
+		if w.instanceID == "" {
+			w.instanceID = newTaskID()
+		}
+	}
+	if config.OverflowDir != "" {
+		overflow, err := newOverflowQueue(config.OverflowDir, config.OverflowSegmentSize)
+		if err != nil {
+			w.logger.Error("taskqueue: overflow queue disabled", "dir", config.OverflowDir, "error", err)
+		} else {
+			w.overflow = overflow
+		}
+	}
+	return w
+}
+
+// Executors returns the worker's executor registry for task type registration.
+func (w *Worker) Executors() *ExecutorRegistry {
+	return w.executors
+}
+
+// Bus returns the event bus the worker publishes lifecycle events to.
+func (w *Worker) Bus() *EventBus {
+	return w.bus
+}
+
+// PoolDecision returns how the worker count was (or will be) chosen, for
+// diagnostics. The derived decision is only final after Start.
+func (w *Worker) PoolDecision() WorkerCountDecision {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.decision.WorkerCount == 0 {
+		return decideWorkerCount(w.executors.All())
+	}
+	return w.decision
+}
+
+// Stats returns a snapshot of the worker counters.
+func (w *Worker) Stats() StatsSnapshot {
+	return w.stats.Snapshot()
+}
+
+// QueueDepth returns the number of tasks currently buffered.
+func (w *Worker) QueueDepth() int {
+	return w.queue.Len()
+}
+
+// SubmitTask validates the task, persists it and enqueues it for
+// processing. The task is saved before it is enqueued so the repository and
+// the queue cannot diverge: a task visible to a worker goroutine is always
+// recoverable from the store.
+func (w *Worker) SubmitTask(ctx context.Context, task *Task, opts ...SubmitOption) (err error) {
+	if task == nil {
+		return ErrNilTask
+	}
+	propagateTraceID(ctx, task)
+	ctx, endSpan := w.startSpan(ctx, "taskqueue.SubmitTask", task)
+	defer endSpan()
+	if w.config.Governor != nil && w.config.Governor.Shedding() {
+		w.stats.rejected.Add(1)
+		return ErrMemoryPressure
+	}
+	if w.config.RateLimiter != nil && !w.config.RateLimiter.Allow(task.Type) {
+		w.stats.throttled.Add(1)
+		return ErrRateLimited
+	}
+	if err := w.validateSubmission(task); err != nil {
+		w.stats.rejected.Add(1)
+		return err
+	}
+	if w.dedup != nil {
+		if err := w.dedup.Check(task); err != nil {
+			w.stats.deduplicated.Add(1)
+			return err
+		}
+		// A submission rejected further down must release the key, or
+		// the producer's legitimate retry would be refused as a
+		// duplicate of a task that was never accepted.
+		defer func() {
+			if err != nil {
+				w.dedup.Forget(task)
+			}
+		}()
+	}
+	var options submitOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	nq, err := w.resolveQueue(task, options)
+	if err != nil {
+		w.stats.rejected.Add(1)
+		return err
+	}
+	if nq.name != DefaultQueueName {
+		if task.Metadata == nil {
+			task.Metadata = make(map[string]string)
+		}
+		task.Metadata[QueueMetadataKey] = nq.name
+	}
+	if w.config.Signer != nil {
+		if err := w.config.Signer.Sign(task); err != nil {
+			w.stats.rejected.Add(1)
+			return err
+		}
+	}
+	propagateCorrelation(ctx, task)
+	w.traceSubmit(ctx, task)
+	if err := w.repo.Save(ctx, task); err != nil {
+		w.stats.rejected.Add(1)
+		return fmt.Errorf("taskqueue: save task %s: %w", task.ID, err)
+	}
+	w.cache.Store(task)
+
+	select {
+	case <-w.stopCh:
+		w.stats.rejected.Add(1)
+		return ErrWorkerStopped
+	case <-ctx.Done():
+		w.stats.rejected.Add(1)
+		return ctx.Err()
+	default:
+	}
+
+	err = nq.queue.Enqueue(task)
+	switch {
+	case err == nil:
+		w.stats.submitted.Add(1)
+		nq.stats.submitted.Add(1)
+		if w.retryBudget != nil {
+			w.retryBudget.recordFresh(task.Type)
+		}
+		w.statuses.Increment(task.Status)
+		w.emitEvent(EventSubmitted, task, TaskStatusPending, nil)
+		return nil
+	case errors.Is(err, ErrQueueFull) && nq.name == DefaultQueueName && w.overflow != nil:
+		if err := w.overflow.Push(task); err != nil {
+			w.stats.rejected.Add(1)
+			return err
+		}
+		w.stats.submitted.Add(1)
+		w.stats.spilled.Add(1)
+		nq.stats.submitted.Add(1)
+		nq.stats.spilled.Add(1)
+		if w.retryBudget != nil {
+			w.retryBudget.recordFresh(task.Type)
+		}
+		w.emitEvent(EventSubmitted, task, TaskStatusPending, nil)
+		return nil
+	case errors.Is(err, ErrQueueClosed):
+		w.stats.rejected.Add(1)
+		return ErrWorkerStopped
+	default:
+		w.stats.rejected.Add(1)
+		return err
+	}
+}
+
+// validateSubmission normalizes and checks a task before it enters the
+// queue. It fills in a generated ID, timestamps and the pending status when
+// absent, and rejects tasks whose fields are inconsistent.
+func (w *Worker) validateSubmission(task *Task) error {
+	if task.ID == "" {
+		task.ID = newTaskID()
+	}
+	if task.Type == "" {
+		return fmt.Errorf("%w: type is required", ErrInvalidTask)
+	}
+	w.applyPolicy(task)
+	if task.Status == "" {
+		task.Status = TaskStatusPending
+	}
+	if !IsValidStatus(task.Status) {
+		return fmt.Errorf("%w: %q", ErrInvalidStatus, task.Status)
+	}
+	if task.Status != TaskStatusPending {
+		return fmt.Errorf("%w: cannot submit task in status %q", ErrInvalidTask, task.Status)
+	}
+	if task.MaxRetries < 0 {
+		return fmt.Errorf("%w: max retries must not be negative, got %d", ErrInvalidTask, task.MaxRetries)
+	}
+	if task.Retries < 0 || task.Retries > task.MaxRetries {
+		return fmt.Errorf("%w: retries %d outside [0, %d]", ErrInvalidTask, task.Retries, task.MaxRetries)
+	}
+	now := time.Now().UTC()
+	if task.IsExpired(now) {
+		return fmt.Errorf("%w: task already expired at %s", ErrInvalidTask, task.ExpiresAt)
+	}
+	if task.CreatedAt.IsZero() {
+		task.CreatedAt = now
+	}
+	task.UpdatedAt = now
+	return nil
+}
+
+// processTask drives one task through its lifecycle: processing, execution
+// and the terminal transition. It returns the status the task ended on;
+// callers must use the return value instead of re-reading task.Status,
+// since held or retried tasks may already be owned by another goroutine.
+func (w *Worker) processTask(task *Task) TaskStatus {
+	ctx, cancel := context.WithTimeout(context.Background(), w.processTimeout(task))
+	defer cancel()
+	if !task.Deadline.IsZero() {
+		var cancelDeadline context.CancelFunc
+		ctx, cancelDeadline = context.WithDeadline(ctx, task.Deadline)
+		defer cancelDeadline()
+	}
+	w.cancels.Store(task.ID, cancel)
+	defer w.cancels.Delete(task.ID)
+	ctx, endSpan := w.startSpan(ctx, "taskqueue.processTask", task)
+	defer endSpan()
+	ctx = withTask(ctx, task)
+	ctx = WithLogger(ctx, w.taskLogger(ctx, task))
+	ctx = w.withProgress(ctx, task)
+	defer w.progress.Delete(task.ID)
+
+	if w.config.Metrics != nil {
+		start := time.Now()
+		defer func() { w.config.Metrics.Observe("task.process", time.Since(start)) }()
+	}
+
+	start := time.Now().UTC()
+	queueSpan := w.traceDequeue(ctx, task, start)
+	defer func() { w.traceProcess(ctx, task, queueSpan, start, time.Now().UTC()) }()
+	if w.config.Costs != nil {
+		defer func() { w.config.Costs.record(task, time.Since(start)) }()
+	}
+
+	if task.IsExpired(start) {
+		w.expire(ctx, task)
+		return TaskStatusExpired
+	}
+
+	if w.cancelRequested(task) {
+		w.finishCancel(ctx, task)
+		return TaskStatusCancelled
+	}
+
+	if w.abandonScattered(ctx, task) {
+		return TaskStatusCancelled
+	}
+
+	if w.typeDisabled(task.Type) {
+		return w.handleTaskError(ctx, task, errTypeDisabled(task.Type))
+	}
+
+	if task.RequiresApproval() {
+		w.hold(ctx, task)
+		return TaskStatusAwaitingApproval
+	}
+
+	if slot := w.typeSlot(task); slot != nil {
+		slot <- struct{}{}
+		defer func() { <-slot }()
+	}
+
+	if w.config.Signer != nil {
+		if err := w.config.Signer.Verify(task); err != nil {
+			return w.handleTaskError(ctx, task, err)
+		}
+	}
+
+	if status, ok := w.replayIdempotent(ctx, task); ok {
+		return status
+	}
+
+	w.transition(ctx, task, TaskStatusProcessing)
+	w.emitEvent(EventStarted, task, TaskStatusProcessing, nil)
+
+	result, err := w.executeTask(ctx, task)
+	if err != nil {
+		return w.handleTaskError(ctx, task, err)
+	}
+
+	w.transition(ctx, task, TaskStatusCompleted)
+	w.cache.Delete(task.ID)
+	w.stats.completed.Add(1)
+	w.recordSLO(task, true, result.Duration)
+	w.recordSLA(task, result.CompletedAt)
+	w.emitEvent(EventCompleted, task, TaskStatusCompleted, nil)
+	w.storeResult(ctx, result)
+	w.publishResult(ctx, result)
+	w.recordGroup(task, true)
+	w.recordScatter(task, result)
+	w.rememberIdempotent(task, result)
+	w.cancelRequests.Delete(task.ID)
+	return TaskStatusCompleted
+}
+
+// executeTask resolves the executor for the task type and runs it.
+func (w *Worker) executeTask(ctx context.Context, task *Task) (*TaskResult, error) {
+	executor, err := w.executors.Get(task.Type)
+	if err != nil {
+		return nil, err
+	}
+	ctx, endSpan := w.startSpan(ctx, "taskqueue.executeTask", task)
+	defer endSpan()
+	ctx = w.assignExperiments(ctx, task)
+	start := time.Now()
+	result, err := w.speculativeExecute(ctx, executor, task)
+	w.recordExperiments(task, err == nil)
+	if err != nil {
+		return nil, classifyExecutionError(task, err)
+	}
+	if result == nil {
+		result = &TaskResult{}
+	}
+	result.TaskID = task.ID
+	result.TaskType = task.Type
+	result.Namespace = task.Namespace()
+	result.CorrelationID = task.CorrelationID()
+	result.Success = true
+	result.Duration = time.Since(start)
+	result.CompletedAt = time.Now().UTC()
+	return result, nil
+}
+
+// classifyExecutionError converts a context deadline hit into the typed
+// ErrTaskDeadlineExceeded when the task carried its own timeout or
+// deadline, so failure results distinguish per-task budgets from
+// executor errors.
+func classifyExecutionError(task *Task, err error) error {
+	if !errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	if task.Timeout > 0 || !task.Deadline.IsZero() {
+		return fmt.Errorf("%w: %w", ErrTaskDeadlineExceeded, err)
+	}
+	return err
+}
+
+// handleTaskError retries a failed execution when the retry policy
+// allows it, recording the failure otherwise. It returns the status the
+// task ended on.
+func (w *Worker) handleTaskError(ctx context.Context, task *Task, err error) TaskStatus {
+	if w.cancelRequested(task) {
+		w.finishCancel(ctx, task)
+		return TaskStatusCancelled
+	}
+	if w.maybeRetry(ctx, task, err) {
+		return TaskStatusPending
+	}
+	w.log(ctx).Error("taskqueue: task failed", "task", FormatTaskSummary(w.config.Redactor.RedactTask(task)), "error", err)
+	w.transition(ctx, task, TaskStatusFailed)
+	w.cache.Delete(task.ID)
+	w.stats.failed.Add(1)
+	w.recordSLO(task, false, 0)
+	if _, ok := task.SLADeadline(); ok {
+		w.sla.record(task.Type, false)
+	}
+	w.failures.Record(task.Type, err)
+	w.emitEvent(EventFailed, task, TaskStatusFailed, err)
+	w.storeResult(ctx, failureResult(task, err))
+	if w.config.DeadLetters != nil {
+		w.deadletter(ctx, task, err)
+	}
+	w.recordGroup(task, false)
+	w.recordScatter(task, nil)
+	return TaskStatusFailed
+}
+
+// transition moves the task to the given status in memory, in the status
+// index and in the repository.
+func (w *Worker) transition(ctx context.Context, task *Task, to TaskStatus) {
+	w.statuses.Decrement(task.Status)
+	task.Status = to
+	task.UpdatedAt = time.Now().UTC()
+	w.statuses.Increment(to)
+	if err := w.repo.UpdateStatus(ctx, task.ID, to); err != nil {
+		w.log(ctx).Error("taskqueue: update status failed", "task_id", task.ID, "status", to, "error", err)
+	}
+}
+
+// encodeResultMessage serializes the result for publication through the
+// configured codec, falling back to the JSON ResultEncoder path.
+func (w *Worker) encodeResultMessage(result *TaskResult) ([]byte, error) {
+	if w.config.Codec != nil {
+		return w.config.Codec.EncodeResult(result)
+	}
+	return w.config.ResultEncoder.Encode(result)
+}
+
+// publishResult sends the result message to the namespace's result topic.
+func (w *Worker) publishResult(ctx context.Context, result *TaskResult) {
+	if w.publisher == nil {
+		return
+	}
+	if w.config.TracerProvider != nil {
+		var span ActiveSpan
+		ctx, span = w.config.TracerProvider.Start(ctx, "taskqueue.publishResult")
+		span.SetAttribute("task.id", result.TaskID)
+		span.SetAttribute("task.type", result.TaskType)
+		defer span.End()
+	}
+	topics := []string{ResultTopic(result.Namespace)}
+	if w.config.Router != nil {
+		topics = w.config.Router.Topics(result)
+	}
+	message, err := w.encodeResultMessage(result)
+	if err != nil {
+		w.log(ctx).Error("taskqueue: encode result failed", "task_id", result.TaskID, "error", err)
+		return
+	}
+	for _, topic := range topics {
+		if err := w.publisher.Publish(ctx, topic, message); err != nil {
+			w.log(ctx).Error("taskqueue: publish result failed", "task_id", result.TaskID, "topic", topic, "error", err)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package taskqueue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kodflow/taskqueue/pkg/taskqueue/testkit"
+)
+
+// mockRepository is an in-memory TaskRepository for tests.
+type mockRepository struct {
+	mu      sync.Mutex
+	tasks   map[string]*Task
+	saveErr error
+}
+
+func newMockRepository() *mockRepository {
+	return &mockRepository{tasks: make(map[string]*Task)}
+}
+
+func (r *mockRepository) Save(_ context.Context, task *Task) error {
+	if r.saveErr != nil {
+		return r.saveErr
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clone := *task
+	r.tasks[task.ID] = &clone
+	return nil
+}
+
+func (r *mockRepository) GetByID(_ context.Context, id string) (*Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	task, ok := r.tasks[id]
+	if !ok {
+		return nil, ErrTaskNotFound
+	}
+	clone := *task
+	return &clone, nil
+}
+
+func (r *mockRepository) UpdateStatus(_ context.Context, id string, status TaskStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	task, ok := r.tasks[id]
+	if !ok {
+		return ErrTaskNotFound
+	}
+	task.Status = status
+	return nil
+}
+
+func (r *mockRepository) ListPending(_ context.Context, limit int) ([]*Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var pending []*Task
+	for _, task := range r.tasks {
+		if task.Status == TaskStatusPending && (limit <= 0 || len(pending) < limit) {
+			clone := *task
+			pending = append(pending, &clone)
+		}
+	}
+	return pending, nil
+}
+
+// mockPublisher records published messages.
+type mockPublisher struct {
+	mu       sync.Mutex
+	messages map[string][][]byte
+}
+
+func newMockPublisher() *mockPublisher {
+	return &mockPublisher{messages: make(map[string][][]byte)}
+}
+
+func (p *mockPublisher) Publish(_ context.Context, topic string, payload []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.messages[topic] = append(p.messages[topic], payload)
+	return nil
+}
+
+func TestSubmitTaskValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		task    *Task
+		wantErr error
+	}{
+		{"nil task", nil, ErrNilTask},
+		{"missing type", &Task{ID: "t1"}, ErrInvalidTask},
+		{"invalid status", &Task{Type: "email", Status: TaskStatus("bogus")}, ErrInvalidStatus},
+		{"non-pending status", &Task{Type: "email", Status: TaskStatusCompleted}, ErrInvalidTask},
+		{"negative max retries", &Task{Type: "email", MaxRetries: -1}, ErrInvalidTask},
+		{"retries above max", &Task{Type: "email", Retries: 3, MaxRetries: 1}, ErrInvalidTask},
+		{"valid", &Task{Type: "email"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWorker(WorkerConfig{}, newMockRepository(), newMockPublisher())
+			err := w.SubmitTask(context.Background(), tt.task)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSubmitTaskGeneratesIDAndPersists(t *testing.T) {
+	repo := newMockRepository()
+	w := NewWorker(WorkerConfig{}, repo, newMockPublisher())
+
+	task := &Task{Type: "email"}
+	require.NoError(t, w.SubmitTask(context.Background(), task))
+
+	assert.NotEmpty(t, task.ID)
+	assert.Equal(t, TaskStatusPending, task.Status)
+
+	saved, err := repo.GetByID(context.Background(), task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, task.ID, saved.ID)
+	assert.EqualValues(t, 1, w.Stats().Submitted)
+}
+
+func TestSubmitTaskQueueFull(t *testing.T) {
+	w := NewWorker(WorkerConfig{BufferSize: 1}, newMockRepository(), newMockPublisher())
+
+	require.NoError(t, w.SubmitTask(context.Background(), &Task{Type: "email"}))
+	err := w.SubmitTask(context.Background(), &Task{Type: "email"})
+	assert.ErrorIs(t, err, ErrQueueFull)
+	assert.EqualValues(t, 1, w.Stats().Rejected)
+}
+
+func TestWorkerProcessesTask(t *testing.T) {
+	testkit.LeakCheck(t)
+	repo := newMockRepository()
+	pub := newMockPublisher()
+	w := NewWorker(WorkerConfig{WorkerCount: 1}, repo, pub)
+
+	done := make(chan struct{})
+	w.Executors().Register("email", ExecutorFunc(func(_ context.Context, _ *Task) (*TaskResult, error) {
+		defer close(done)
+		return &TaskResult{Output: "sent"}, nil
+	}))
+	w.Start()
+
+	task := &Task{Type: "email"}
+	require.NoError(t, w.SubmitTask(context.Background(), task))
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("task was not processed")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	require.NoError(t, w.Shutdown(ctx))
+
+	saved, err := repo.GetByID(context.Background(), task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, TaskStatusCompleted, saved.Status)
+	assert.EqualValues(t, 1, w.Stats().Completed)
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+	assert.Len(t, pub.messages[resultTopic], 1)
+}
@@ -0,0 +1,69 @@
+package taskqueue
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Workload classifies the resource profile of an executor's tasks, used
+// to derive the default worker count.
+type Workload int
+
+// Workload classes. IO-bound is the default for executors that do not
+// declare a class, since most task types wait on the network or disk.
+const (
+	WorkloadIOBound Workload = iota
+	WorkloadCPUBound
+)
+
+// Worker-per-core multipliers applied per workload class: CPU-bound pools
+// gain nothing from oversubscription, IO-bound pools spend most of their
+// time blocked.
+const (
+	cpuBoundMultiplier = 1
+	ioBoundMultiplier  = 4
+)
+
+// WorkloadClassifier is optionally implemented by executors to declare
+// whether their tasks are CPU-bound or IO-bound.
+type WorkloadClassifier interface {
+	Workload() Workload
+}
+
+// WorkerCountDecision records how the pool size was chosen, exposed in
+// diagnostics so operators can see why a deployment runs N workers.
+type WorkerCountDecision struct {
+	WorkerCount int    `json:"worker_count"`
+	GOMAXPROCS  int    `json:"gomaxprocs"`
+	Multiplier  int    `json:"multiplier,omitempty"`
+	Configured  bool   `json:"configured"`
+	Reason      string `json:"reason"`
+}
+
+// decideWorkerCount derives the pool size from GOMAXPROCS and the
+// registered executors' workload classes: one worker per core when every
+// executor is CPU-bound, oversubscribed when any is (or defaults to)
+// IO-bound.
+func decideWorkerCount(executors []Executor) WorkerCountDecision {
+	procs := runtime.GOMAXPROCS(0)
+	multiplier := cpuBoundMultiplier
+	class := "cpu-bound"
+	if len(executors) == 0 {
+		multiplier = ioBoundMultiplier
+		class = "unclassified"
+	}
+	for _, executor := range executors {
+		classifier, ok := executor.(WorkloadClassifier)
+		if !ok || classifier.Workload() == WorkloadIOBound {
+			multiplier = ioBoundMultiplier
+			class = "io-bound"
+			break
+		}
+	}
+	return WorkerCountDecision{
+		WorkerCount: procs * multiplier,
+		GOMAXPROCS:  procs,
+		Multiplier:  multiplier,
+		Reason:      fmt.Sprintf("%s workload: %d×GOMAXPROCS(%d)", class, multiplier, procs),
+	}
+}
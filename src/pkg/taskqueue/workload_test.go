@@ -0,0 +1,71 @@
+package taskqueue
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// classifiedExecutor is an executor with a declared workload class.
+type classifiedExecutor struct {
+	workload Workload
+}
+
+func (e *classifiedExecutor) Execute(context.Context, *Task) (*TaskResult, error) {
+	return &TaskResult{}, nil
+}
+
+func (e *classifiedExecutor) Workload() Workload {
+	return e.workload
+}
+
+func TestDecideWorkerCount(t *testing.T) {
+	procs := runtime.GOMAXPROCS(0)
+	cpu := &classifiedExecutor{workload: WorkloadCPUBound}
+	io := &classifiedExecutor{workload: WorkloadIOBound}
+	unclassified := ExecutorFunc(func(context.Context, *Task) (*TaskResult, error) {
+		return &TaskResult{}, nil
+	})
+
+	tests := []struct {
+		name      string
+		executors []Executor
+		want      int
+	}{
+		{"no executors", nil, procs * ioBoundMultiplier},
+		{"all cpu-bound", []Executor{cpu, cpu}, procs * cpuBoundMultiplier},
+		{"mixed", []Executor{cpu, io}, procs * ioBoundMultiplier},
+		{"unclassified defaults to io-bound", []Executor{unclassified}, procs * ioBoundMultiplier},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := decideWorkerCount(tt.executors)
+			assert.Equal(t, tt.want, decision.WorkerCount)
+			assert.Equal(t, procs, decision.GOMAXPROCS)
+			assert.False(t, decision.Configured)
+			assert.NotEmpty(t, decision.Reason)
+		})
+	}
+}
+
+func TestPoolDecisionRespectsConfiguredCount(t *testing.T) {
+	worker := NewWorker(WorkerConfig{WorkerCount: 3}, newMockRepository(), nil)
+
+	decision := worker.PoolDecision()
+	assert.Equal(t, 3, decision.WorkerCount)
+	assert.True(t, decision.Configured)
+}
+
+func TestStartDerivesWorkerCountFromExecutors(t *testing.T) {
+	worker := NewWorker(WorkerConfig{}, newMockRepository(), nil)
+	worker.Executors().Register("hash", &classifiedExecutor{workload: WorkloadCPUBound})
+
+	worker.Start()
+	defer worker.Shutdown(context.Background())
+
+	decision := worker.PoolDecision()
+	assert.Equal(t, runtime.GOMAXPROCS(0)*cpuBoundMultiplier, decision.WorkerCount)
+	assert.False(t, decision.Configured)
+}